@@ -1,65 +1,273 @@
 package config
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
 )
 
+// Fields tagged secret:"true" are redacted by Redacted. Fields tagged
+// reload:"true" are applied by a running server when the configuration is
+// reloaded; changes to any other field need a restart.
+
+// DBConfig controls the Postgres connection. DSN, when set, replaces the
+// connection settings from Host to SSLRootCert; the pool settings and
+// StatementTimeout still apply. ConnectRetryTimeout is how long startup keeps
+// retrying while Postgres is unreachable; zero tries once. AutoMigrate applies
+// pending migrations when the server starts; turn it off to run them with the
+// migrate command instead.
 type DBConfig struct {
-	Host           string
-	Port           int
-	User           string
-	Password       string
-	Name           string
-	MigrationsPath string
+	Host                string        `yaml:"host" toml:"host"`
+	Port                int           `yaml:"port" toml:"port"`
+	User                string        `yaml:"user" toml:"user"`
+	Password            string        `yaml:"password" toml:"password" secret:"true"`
+	Name                string        `yaml:"name" toml:"name"`
+	SSLMode             string        `yaml:"sslmode" toml:"sslmode"`
+	SSLRootCert         string        `yaml:"sslrootcert" toml:"sslrootcert"`
+	DSN                 string        `yaml:"dsn" toml:"dsn" secret:"true"`
+	MaxOpenConns        int           `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns        int           `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime     time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	StatementTimeout    time.Duration `yaml:"statement_timeout" toml:"statement_timeout"`
+	ConnectRetryTimeout time.Duration `yaml:"connect_retry_timeout" toml:"connect_retry_timeout"`
+	AutoMigrate         bool          `yaml:"auto_migrate" toml:"auto_migrate"`
 }
 
 type GRPCServerConfig struct {
-	Port string
+	Port string `yaml:"port" toml:"port"`
+}
+
+// HTTPServerConfig controls the HTTP server serving the REST gateway, Connect
+// and gRPC-Web next to the gRPC server.
+type HTTPServerConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled"`
+	Port    string `yaml:"port" toml:"port"`
+}
+
+// CORSConfig lists the browser origins allowed to call the HTTP server, e.g.
+// the admin UI, and how long browsers may cache preflight responses. No
+// origin is allowed by default.
+type CORSConfig struct {
+	AllowedOrigins []string      `yaml:"allowed_origins" toml:"allowed_origins"`
+	MaxAge         time.Duration `yaml:"max_age" toml:"max_age"`
 }
 
+// SlackConfig overrides the base URL of the Slack Web API, e.g. to run against
+// a fake Slack server. Empty uses Slack's own API.
+type SlackConfig struct {
+	APIURL string `yaml:"api_url" toml:"api_url"`
+}
+
+// AWSConfig selects how the service authenticates to AWS. Credentials come
+// from the SDK's default chain (environment, shared files, web identity, then
+// the ECS or EC2 role), or from the shared files' Profile when set. RoleARN
+// assumes a role with those credentials, or with the token in
+// WebIdentityTokenFile when set. Endpoint overrides the Secrets Manager
+// endpoint, e.g. for LocalStack, and KMSKeyID encrypts connector secrets with
+// a customer managed key instead of the AWS managed one.
 type AWSConfig struct {
-	Endpoint string
-	Region   string
+	Endpoint             string `yaml:"endpoint" toml:"endpoint"`
+	Region               string `yaml:"region" toml:"region"`
+	Profile              string `yaml:"profile" toml:"profile"`
+	RoleARN              string `yaml:"role_arn" toml:"role_arn"`
+	RoleSessionName      string `yaml:"role_session_name" toml:"role_session_name"`
+	ExternalID           string `yaml:"external_id" toml:"external_id" secret:"true"`
+	WebIdentityTokenFile string `yaml:"web_identity_token_file" toml:"web_identity_token_file"`
+	KMSKeyID             string `yaml:"kms_key_id" toml:"kms_key_id"`
 }
 
-type Config struct {
-	DB         DBConfig
-	GRPCServer GRPCServerConfig
-	AWS        AWSConfig
+// DeliveryConfig controls retries of message deliveries before they are
+// dead-lettered, and the default dedup and aggregation windows.
+type DeliveryConfig struct {
+	MaxAttempts       int           `yaml:"max_attempts" toml:"max_attempts"`
+	RetryBaseDelay    time.Duration `yaml:"retry_base_delay" toml:"retry_base_delay"`
+	RetryMaxDelay     time.Duration `yaml:"retry_max_delay" toml:"retry_max_delay"`
+	DedupWindow       time.Duration `yaml:"dedup_window" toml:"dedup_window"`
+	AggregationWindow time.Duration `yaml:"aggregation_window" toml:"aggregation_window"`
+}
+
+// SchedulerConfig controls the background loop dispatching scheduled messages.
+type SchedulerConfig struct {
+	Enabled  bool          `yaml:"enabled" toml:"enabled"`
+	Interval time.Duration `yaml:"interval" toml:"interval"`
 }
 
-// LoadConfig loads configuration from environment variables or defaults.
-func LoadConfig() *Config {
-	port, err := strconv.Atoi(GetEnv("DB_PORT", "5432"))
-	if err != nil {
-		log.Fatalf("Invalid DB_PORT: %v", err)
+// BatchConfig bounds BatchSendMessage: the items per request, how many are sent
+// at once, and the messages per second (with bursts) posted to one workspace.
+type BatchConfig struct {
+	MaxItems       int     `yaml:"max_items" toml:"max_items"`
+	Parallelism    int     `yaml:"parallelism" toml:"parallelism"`
+	WorkspaceRate  float64 `yaml:"workspace_rate" toml:"workspace_rate" reload:"true"`
+	WorkspaceBurst int     `yaml:"workspace_burst" toml:"workspace_burst" reload:"true"`
+}
+
+// EventsConfig controls WatchConnectorEvents: the events buffered per watcher
+// and how long events are kept for watchers resuming from an event ID.
+type EventsConfig struct {
+	Buffer    int           `yaml:"buffer" toml:"buffer"`
+	Retention time.Duration `yaml:"retention" toml:"retention"`
+}
+
+// WebhookConfig controls retries of connector events posted to tenant callback URLs.
+type WebhookConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts" toml:"max_attempts"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" toml:"retry_base_delay"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay" toml:"retry_max_delay"`
+}
+
+// AuditConfig controls the audit log. When ExportPath is set, audit events are
+// also appended to that file as JSON lines. TrustedProxies lists the addresses
+// or CIDR ranges of the proxies whose X-Forwarded-For is believed when
+// recording a caller's address; loopback is always trusted, since the REST
+// gateway and Connect handlers call the gRPC server over it.
+type AuditConfig struct {
+	ExportPath     string   `yaml:"export_path" toml:"export_path"`
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// TrustedProxyPrefixes parses TrustedProxies. Single addresses become
+// single-address prefixes.
+func (c AuditConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, raw := range c.TrustedProxies {
+		if addr, err := netip.ParseAddr(raw); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR range", raw)
+		}
+		prefixes = append(prefixes, p.Masked())
 	}
+	return prefixes, nil
+}
+
+// QuotaConfig holds the quotas of tenants without one set with SetTenantQuota.
+// Zero limits are unlimited.
+type QuotaConfig struct {
+	MaxConnectors     int `yaml:"max_connectors" toml:"max_connectors" reload:"true"`
+	MessagesPerMinute int `yaml:"messages_per_minute" toml:"messages_per_minute" reload:"true"`
+	MessagesPerDay    int `yaml:"messages_per_day" toml:"messages_per_day" reload:"true"`
+}
+
+// LogConfig sets the minimum level logged: debug, info, warn or error.
+type LogConfig struct {
+	Level string `yaml:"level" toml:"level" reload:"true"`
+}
+
+type Config struct {
+	DB         DBConfig         `yaml:"db" toml:"db"`
+	GRPCServer GRPCServerConfig `yaml:"grpc_server" toml:"grpc_server"`
+	HTTPServer HTTPServerConfig `yaml:"http_server" toml:"http_server"`
+	CORS       CORSConfig       `yaml:"cors" toml:"cors"`
+	AWS        AWSConfig        `yaml:"aws" toml:"aws"`
+	Slack      SlackConfig      `yaml:"slack" toml:"slack"`
+	Delivery   DeliveryConfig   `yaml:"delivery" toml:"delivery"`
+	Scheduler  SchedulerConfig  `yaml:"scheduler" toml:"scheduler"`
+	Batch      BatchConfig      `yaml:"batch" toml:"batch"`
+	Events     EventsConfig     `yaml:"events" toml:"events"`
+	Webhooks   WebhookConfig    `yaml:"webhooks" toml:"webhooks"`
+	Audit      AuditConfig      `yaml:"audit" toml:"audit"`
+	Quota      QuotaConfig      `yaml:"quota" toml:"quota"`
+	Log        LogConfig        `yaml:"log" toml:"log"`
+}
 
+// Default returns the configuration used when neither a config file nor an
+// environment variable sets a value.
+func Default() *Config {
 	return &Config{
 		DB: DBConfig{
-			Host:           GetEnv("DB_HOST", "localhost"),
-			Port:           port,
-			User:           GetEnv("DB_USER", "aryon"),
-			Password:       GetEnv("DB_PASS", "aryon"),
-			Name:           GetEnv("DB_NAME", "aryondb"),
-			MigrationsPath: GetEnv("DB_MIGRATIONS_PATH", "migrations"),
+			Host:                "localhost",
+			Port:                5432,
+			Name:                "aryondb",
+			SSLMode:             "disable",
+			MaxOpenConns:        25,
+			MaxIdleConns:        10,
+			ConnMaxLifetime:     30 * time.Minute,
+			ConnectRetryTimeout: time.Minute,
+			AutoMigrate:         true,
 		},
 		GRPCServer: GRPCServerConfig{
-			Port: GetEnv("GRPC_SERVER_PORT", "50051"),
+			Port: "50051",
+		},
+		HTTPServer: HTTPServerConfig{
+			Enabled: true,
+			Port:    "8080",
+		},
+		CORS: CORSConfig{
+			MaxAge: 2 * time.Hour,
 		},
 		AWS: AWSConfig{
-			Endpoint: GetEnv("AWS_ENDPOINT", "http://localhost:4566"),
-			Region:   GetEnv("AWS_REGION", "us-east-1"),
+			Region: "us-east-1",
+		},
+		Delivery: DeliveryConfig{
+			MaxAttempts:       3,
+			RetryBaseDelay:    500 * time.Millisecond,
+			RetryMaxDelay:     30 * time.Second,
+			DedupWindow:       5 * time.Minute,
+			AggregationWindow: time.Minute,
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:  true,
+			Interval: 10 * time.Second,
 		},
+		Batch: BatchConfig{
+			MaxItems:       1000,
+			Parallelism:    16,
+			WorkspaceRate:  1,
+			WorkspaceBurst: 5,
+		},
+		Events: EventsConfig{
+			Buffer:    256,
+			Retention: 7 * 24 * time.Hour,
+		},
+		Webhooks: WebhookConfig{
+			MaxAttempts:    8,
+			RetryBaseDelay: 30 * time.Second,
+			RetryMaxDelay:  time.Hour,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, then the YAML or TOML file
+// at path (if path is not empty), then environment variables, and validates
+// the result. The returned error joins every problem found, not just the
+// first.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	var errs []error
+	if path != "" {
+		fieldErrs, err := decodeFile(path, cfg)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, fieldErrs...)
+	}
+	if err := applyEnv(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
+	return cfg, nil
 }
 
-func GetEnv(key, defaultVal string) string {
-	val := os.Getenv(key)
-	if val == "" {
-		return defaultVal
+// splitList splits a comma-separated value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-	return val
+	return items
 }
@@ -0,0 +1,217 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/config"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// setDBCredentials sets the database credentials, which have no default.
+func setDBCredentials(t *testing.T) {
+	t.Helper()
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_PASS", "secret")
+}
+
+// validConfig returns the defaults with the database credentials set.
+func validConfig() *config.Config {
+	cfg := config.Default()
+	cfg.DB.User = "app"
+	cfg.DB.Password = "secret"
+	return cfg
+}
+
+func TestLoad_YAMLUnderEnv(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+db:
+  host: db.internal
+  port: 6432
+delivery:
+  dedup_window: 10m
+cors:
+  allowed_origins: [https://admin.example.com]
+quota:
+  max_connectors: 5
+`)
+	setDBCredentials(t)
+	t.Setenv("DB_PORT", "7432")
+	t.Setenv("QUOTA_MAX_CONNECTORS", "")
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "db.internal", cfg.DB.Host)
+	require.Equal(t, 7432, cfg.DB.Port)
+	require.Equal(t, 10*time.Minute, cfg.Delivery.DedupWindow)
+	require.Equal(t, []string{"https://admin.example.com"}, cfg.CORS.AllowedOrigins)
+	require.Equal(t, 5, cfg.Quota.MaxConnectors)
+	// Settings missing from the file keep their defaults.
+	require.Equal(t, config.Default().Batch, cfg.Batch)
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[grpc_server]
+port = "9090"
+
+[batch]
+workspace_rate = 2.5
+
+[log]
+level = "debug"
+`)
+	setDBCredentials(t)
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "9090", cfg.GRPCServer.Port)
+	require.Equal(t, 2.5, cfg.Batch.WorkspaceRate)
+	require.Equal(t, "debug", cfg.Log.Level)
+}
+
+func TestLoad_ReportsAllErrors(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+db:
+  hostname: db.internal
+  port: many
+batch:
+  parallelism: 0
+`)
+	t.Setenv("DELIVERY_RETRY_BASE_DELAY", "soon")
+	t.Setenv("LOG_LEVEL", "loud")
+
+	_, err := config.Load(path)
+	require.Error(t, err)
+	for _, want := range []string{
+		"field hostname not found",
+		"cannot unmarshal !!str `many`",
+		`DELIVERY_RETRY_BASE_DELAY: "soon" is not a duration`,
+		"batch.parallelism: must be at least 1",
+		"log.level: must be debug, info, warn or error",
+	} {
+		require.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLoad_TOMLUnknownKey(t *testing.T) {
+	path := writeFile(t, "config.toml", "[quota]\nmax_tenants = 3\n")
+
+	_, err := config.Load(path)
+	require.ErrorContains(t, err, `unknown key "quota.max_tenants"`)
+}
+
+func TestLoad_UnsupportedExtension(t *testing.T) {
+	_, err := config.Load(writeFile(t, "config.json", "{}"))
+	require.ErrorContains(t, err, "unsupported config file extension")
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := validConfig()
+	cfg.DB.Password = "hunter2"
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Redacted().WriteYAML(&out))
+	require.NotContains(t, out.String(), "hunter2")
+	require.Contains(t, out.String(), "password: "+config.RedactedValue)
+	require.Equal(t, "hunter2", cfg.DB.Password)
+
+	// The dump is a valid config file.
+	cfg, err := config.Load(writeFile(t, "dump.yaml", out.String()))
+	require.NoError(t, err)
+	require.Equal(t, config.Default().Delivery, cfg.Delivery)
+}
+
+func TestRestartRequired(t *testing.T) {
+	cur := config.Default()
+	next := config.Default()
+	next.Log.Level = "debug"
+	next.Batch.WorkspaceRate = 10
+	next.Quota.MessagesPerDay = 100
+	require.Empty(t, cur.RestartRequired(next))
+
+	next.DB.Host = "elsewhere"
+	next.Delivery.MaxAttempts = 5
+	require.Equal(t, []string{"db.host", "delivery.max_attempts"}, cur.RestartRequired(next))
+}
+
+func TestWatch_ReloadsChangedFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", "log:\n  level: info\n")
+	setDBCredentials(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan *config.Config, 1)
+	go config.Watch(ctx, path, 10*time.Millisecond, func(cfg *config.Config) { reloaded <- cfg })
+
+	// An invalid file is skipped; the next valid one is applied.
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: chatty\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\n"), 0o600))
+
+	select {
+	case cfg := <-reloaded:
+		require.Equal(t, "debug", cfg.Log.Level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+}
+
+func TestValidate_AWSRoleSettings(t *testing.T) {
+	cfg := validConfig()
+	cfg.AWS.WebIdentityTokenFile = "/var/run/secrets/token"
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "aws.web_identity_token_file: requires aws.role_arn")
+
+	cfg.AWS.RoleARN = "arn:aws:iam::123456789012:role/connector"
+	require.NoError(t, cfg.Validate())
+
+	cfg.AWS.ExternalID = "ext-1"
+	require.ErrorContains(t, cfg.Validate(), "aws.external_id: cannot be used with aws.web_identity_token_file")
+}
+
+func TestValidate_DBSettings(t *testing.T) {
+	cfg := config.Default()
+	cfg.DB.SSLMode = "prefer"
+	cfg.DB.MaxOpenConns = 5
+	cfg.DB.MaxIdleConns = 10
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "db.user: is required")
+	require.ErrorContains(t, err, "db.password: is required")
+	require.ErrorContains(t, err, "db.sslmode: must be disable, require, verify-ca or verify-full")
+	require.ErrorContains(t, err, "db.max_idle_conns: must not exceed max_open_conns")
+
+	// A DSN replaces the individual connection settings.
+	cfg = config.Default()
+	cfg.DB.DSN = "postgres://app@primary/app"
+	cfg.DB.Host = ""
+	cfg.DB.SSLMode = ""
+	require.NoError(t, cfg.Validate())
+}
+
+func TestAuditConfig_TrustedProxyPrefixes(t *testing.T) {
+	cfg := validConfig()
+	cfg.Audit.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.10", "172.16.5.0/16"}
+	require.NoError(t, cfg.Validate())
+
+	prefixes, err := cfg.Audit.TrustedProxyPrefixes()
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8", "192.168.1.10/32", "172.16.0.0/16"}, []string{
+		prefixes[0].String(), prefixes[1].String(), prefixes[2].String(),
+	})
+
+	cfg.Audit.TrustedProxies = []string{"proxy.internal"}
+	require.ErrorContains(t, cfg.Validate(), `audit.trusted_proxies: "proxy.internal" is not an IP address or CIDR range`)
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// applyEnv overrides cfg with the environment variables that are set. An
+// empty variable counts as unset.
+func applyEnv(cfg *Config) error {
+	var e envLoader
+
+	e.string(&cfg.DB.Host, "DB_HOST")
+	e.int(&cfg.DB.Port, "DB_PORT")
+	e.string(&cfg.DB.User, "DB_USER")
+	e.string(&cfg.DB.Password, "DB_PASS")
+	e.string(&cfg.DB.Name, "DB_NAME")
+	e.string(&cfg.DB.SSLMode, "DB_SSLMODE")
+	e.string(&cfg.DB.SSLRootCert, "DB_SSLROOTCERT")
+	e.string(&cfg.DB.DSN, "DB_DSN")
+	e.int(&cfg.DB.MaxOpenConns, "DB_MAX_OPEN_CONNS")
+	e.int(&cfg.DB.MaxIdleConns, "DB_MAX_IDLE_CONNS")
+	e.duration(&cfg.DB.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME")
+	e.duration(&cfg.DB.StatementTimeout, "DB_STATEMENT_TIMEOUT")
+	e.duration(&cfg.DB.ConnectRetryTimeout, "DB_CONNECT_RETRY_TIMEOUT")
+	e.bool(&cfg.DB.AutoMigrate, "DB_AUTO_MIGRATE")
+
+	e.string(&cfg.GRPCServer.Port, "GRPC_SERVER_PORT")
+	e.bool(&cfg.HTTPServer.Enabled, "HTTP_SERVER_ENABLED")
+	e.string(&cfg.HTTPServer.Port, "HTTP_SERVER_PORT")
+
+	e.list(&cfg.CORS.AllowedOrigins, "CORS_ALLOWED_ORIGINS")
+	e.duration(&cfg.CORS.MaxAge, "CORS_MAX_AGE")
+
+	e.string(&cfg.AWS.Endpoint, "AWS_ENDPOINT")
+	e.string(&cfg.AWS.Region, "AWS_REGION")
+	e.string(&cfg.AWS.Profile, "AWS_PROFILE")
+	e.string(&cfg.AWS.RoleARN, "AWS_ROLE_ARN")
+	e.string(&cfg.AWS.RoleSessionName, "AWS_ROLE_SESSION_NAME")
+	e.string(&cfg.AWS.ExternalID, "AWS_ROLE_EXTERNAL_ID")
+	e.string(&cfg.AWS.WebIdentityTokenFile, "AWS_WEB_IDENTITY_TOKEN_FILE")
+	e.string(&cfg.AWS.KMSKeyID, "AWS_KMS_KEY_ID")
+
+	e.string(&cfg.Slack.APIURL, "SLACK_API_URL")
+
+	e.int(&cfg.Delivery.MaxAttempts, "DELIVERY_MAX_ATTEMPTS")
+	e.duration(&cfg.Delivery.RetryBaseDelay, "DELIVERY_RETRY_BASE_DELAY")
+	e.duration(&cfg.Delivery.RetryMaxDelay, "DELIVERY_RETRY_MAX_DELAY")
+	e.duration(&cfg.Delivery.DedupWindow, "DELIVERY_DEDUP_WINDOW")
+	e.duration(&cfg.Delivery.AggregationWindow, "DELIVERY_AGGREGATION_WINDOW")
+
+	e.bool(&cfg.Scheduler.Enabled, "SCHEDULER_ENABLED")
+	e.duration(&cfg.Scheduler.Interval, "SCHEDULER_INTERVAL")
+
+	e.int(&cfg.Batch.MaxItems, "BATCH_MAX_ITEMS")
+	e.int(&cfg.Batch.Parallelism, "BATCH_PARALLELISM")
+	e.float(&cfg.Batch.WorkspaceRate, "BATCH_WORKSPACE_RATE")
+	e.int(&cfg.Batch.WorkspaceBurst, "BATCH_WORKSPACE_BURST")
+
+	e.int(&cfg.Events.Buffer, "EVENTS_BUFFER")
+	e.duration(&cfg.Events.Retention, "EVENTS_RETENTION")
+
+	e.int(&cfg.Webhooks.MaxAttempts, "WEBHOOK_MAX_ATTEMPTS")
+	e.duration(&cfg.Webhooks.RetryBaseDelay, "WEBHOOK_RETRY_BASE_DELAY")
+	e.duration(&cfg.Webhooks.RetryMaxDelay, "WEBHOOK_RETRY_MAX_DELAY")
+
+	e.string(&cfg.Audit.ExportPath, "AUDIT_EXPORT_PATH")
+	e.list(&cfg.Audit.TrustedProxies, "AUDIT_TRUSTED_PROXIES")
+
+	e.int(&cfg.Quota.MaxConnectors, "QUOTA_MAX_CONNECTORS")
+	e.int(&cfg.Quota.MessagesPerMinute, "QUOTA_MESSAGES_PER_MINUTE")
+	e.int(&cfg.Quota.MessagesPerDay, "QUOTA_MESSAGES_PER_DAY")
+
+	e.string(&cfg.Log.Level, "LOG_LEVEL")
+
+	return errors.Join(e.errs...)
+}
+
+// envLoader reads typed environment variables, collecting every invalid
+// value instead of stopping at the first.
+type envLoader struct {
+	errs []error
+}
+
+func (e *envLoader) string(dst *string, key string) {
+	if val := os.Getenv(key); val != "" {
+		*dst = val
+	}
+}
+
+func (e *envLoader) list(dst *[]string, key string) {
+	if val := os.Getenv(key); val != "" {
+		*dst = splitList(val)
+	}
+}
+
+func (e *envLoader) int(dst *int, key string) {
+	parse(e, dst, key, "an integer", strconv.Atoi)
+}
+
+func (e *envLoader) float(dst *float64, key string) {
+	parse(e, dst, key, "a number", func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+}
+
+func (e *envLoader) bool(dst *bool, key string) {
+	parse(e, dst, key, "true or false", strconv.ParseBool)
+}
+
+func (e *envLoader) duration(dst *time.Duration, key string) {
+	parse(e, dst, key, "a duration such as 30s", time.ParseDuration)
+}
+
+func parse[T any](e *envLoader, dst *T, key, want string, fn func(string) (T, error)) {
+	val := os.Getenv(key)
+	if val == "" {
+		return
+	}
+	v, err := fn(val)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not %s", key, val, want))
+		return
+	}
+	*dst = v
+}
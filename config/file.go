@@ -0,0 +1,55 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeFile decodes the YAML or TOML file at path over cfg, picking the
+// format from the extension. Unknown keys and values of the wrong type are
+// returned as fieldErrs, one per problem, so they can be reported together
+// with other invalid settings; err is set when the file cannot be read or
+// parsed at all.
+func decodeFile(path string, cfg *Config) (fieldErrs []error, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err := dec.Decode(cfg)
+		var typeErr *yaml.TypeError
+		switch {
+		case err == nil, errors.Is(err, io.EOF):
+			return nil, nil
+		case errors.As(err, &typeErr):
+			for _, msg := range typeErr.Errors {
+				fieldErrs = append(fieldErrs, fmt.Errorf("%s: %s", path, msg))
+			}
+			return fieldErrs, nil
+		default:
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, key := range md.Undecoded() {
+			fieldErrs = append(fieldErrs, fmt.Errorf("%s: unknown key %q", path, key.String()))
+		}
+		return fieldErrs, nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported config file extension %q, use .yaml, .yml or .toml", path, ext)
+	}
+}
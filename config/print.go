@@ -0,0 +1,51 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue replaces secrets in Redacted configurations.
+const RedactedValue = "REDACTED"
+
+// Redacted returns a copy of c with every non-empty secret replaced by
+// RedactedValue, safe to print or log.
+func (c *Config) Redacted() *Config {
+	out := *c
+	eachField(reflect.ValueOf(&out).Elem(), "", func(_ string, f reflect.StructField, v reflect.Value) {
+		if f.Tag.Get("secret") == "true" && v.Kind() == reflect.String && v.String() != "" {
+			v.SetString(RedactedValue)
+		}
+	})
+	return &out
+}
+
+// WriteYAML writes c in the YAML config file format.
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// eachField calls fn with every leaf setting of the struct v, keyed by its
+// dotted config file path.
+func eachField(v reflect.Value, prefix string, fn func(key string, f reflect.StructField, v reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		key := f.Tag.Get("yaml")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if f.Type.Kind() == reflect.Struct {
+			eachField(v.Field(i), key, fn)
+			continue
+		}
+		fn(key, f, v.Field(i))
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+)
+
+// RestartRequired returns the keys of the settings that differ between c and
+// next but are not reloadable, so a running server ignores them until it is
+// restarted.
+func (c *Config) RestartRequired(next *Config) []string {
+	nextValues := make(map[string]any)
+	eachField(reflect.ValueOf(next).Elem(), "", func(key string, _ reflect.StructField, v reflect.Value) {
+		nextValues[key] = v.Interface()
+	})
+
+	var keys []string
+	eachField(reflect.ValueOf(c).Elem(), "", func(key string, f reflect.StructField, v reflect.Value) {
+		if f.Tag.Get("reload") != "true" && !reflect.DeepEqual(v.Interface(), nextValues[key]) {
+			keys = append(keys, key)
+		}
+	})
+	return keys
+}
+
+// Watch reloads the configuration from path whenever the process receives
+// SIGHUP or, when path is set, the file changes (checked every interval), and
+// passes each valid result to apply. Invalid configurations are logged and
+// skipped, leaving the running configuration in place. Watch returns when ctx
+// is done.
+func Watch(ctx context.Context, path string, interval time.Duration, apply func(*Config)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var poll <-chan time.Time
+	if path != "" {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	last := stat(path)
+	changed := false
+
+	reload := func(reason string) {
+		cfg, err := Load(path)
+		if err != nil {
+			slog.Error("Failed to reload configuration, keeping the current one", "reason", reason, "error", err)
+			return
+		}
+		slog.Info("Reloading configuration", "reason", reason)
+		apply(cfg)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			last, changed = stat(path), false
+			reload("SIGHUP")
+		case <-poll:
+			// Reload once the file has stayed unchanged for an interval, so
+			// a file caught halfway through being written is not loaded.
+			if cur := stat(path); cur != last {
+				last = cur
+				changed = true
+			} else if changed {
+				changed = false
+				reload("file changed")
+			}
+		}
+	}
+}
+
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func stat(path string) fileVersion {
+	if path == "" {
+		return fileVersion{}
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileVersion{}
+	}
+	return fileVersion{modTime: fi.ModTime(), size: fi.Size()}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate checks every setting and returns all invalid ones joined in a
+// single error, each prefixed with its config file key.
+func (c *Config) Validate() error {
+	var v validator
+
+	if c.DB.DSN == "" {
+		v.check(c.DB.Host != "", "db.host", "is required")
+		v.check(c.DB.Port > 0 && c.DB.Port <= 65535, "db.port", "must be between 1 and 65535")
+		v.check(c.DB.User != "", "db.user", "is required")
+		v.check(c.DB.Password != "", "db.password", "is required")
+		v.check(c.DB.Name != "", "db.name", "is required")
+		v.check(slices.Contains([]string{"disable", "require", "verify-ca", "verify-full"}, c.DB.SSLMode),
+			"db.sslmode", "must be disable, require, verify-ca or verify-full")
+		v.check(c.DB.SSLRootCert == "" || c.DB.SSLMode != "disable", "db.sslrootcert", "cannot be used with sslmode disable")
+	}
+	v.check(c.DB.MaxOpenConns >= 0, "db.max_open_conns", "must not be negative")
+	v.check(c.DB.MaxIdleConns >= 0, "db.max_idle_conns", "must not be negative")
+	v.check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns, "db.max_idle_conns", "must not exceed max_open_conns")
+	v.check(c.DB.ConnMaxLifetime >= 0, "db.conn_max_lifetime", "must not be negative")
+	v.check(c.DB.StatementTimeout >= 0, "db.statement_timeout", "must not be negative")
+	v.check(c.DB.ConnectRetryTimeout >= 0, "db.connect_retry_timeout", "must not be negative")
+
+	v.port(c.GRPCServer.Port, "grpc_server.port")
+	if c.HTTPServer.Enabled {
+		v.port(c.HTTPServer.Port, "http_server.port")
+		v.check(c.HTTPServer.Port != c.GRPCServer.Port, "http_server.port", "must differ from grpc_server.port")
+	}
+	v.check(c.CORS.MaxAge >= 0, "cors.max_age", "must not be negative")
+
+	v.check(c.AWS.Region != "", "aws.region", "is required")
+	if c.AWS.Endpoint != "" {
+		v.url(c.AWS.Endpoint, "aws.endpoint")
+	}
+	if c.AWS.RoleARN != "" {
+		v.check(strings.HasPrefix(c.AWS.RoleARN, "arn:"), "aws.role_arn", "must be a role ARN")
+	} else {
+		v.check(c.AWS.RoleSessionName == "", "aws.role_session_name", "requires aws.role_arn")
+		v.check(c.AWS.ExternalID == "", "aws.external_id", "requires aws.role_arn")
+		v.check(c.AWS.WebIdentityTokenFile == "", "aws.web_identity_token_file", "requires aws.role_arn")
+	}
+	v.check(c.AWS.ExternalID == "" || c.AWS.WebIdentityTokenFile == "", "aws.external_id", "cannot be used with aws.web_identity_token_file")
+	if c.Slack.APIURL != "" {
+		v.url(c.Slack.APIURL, "slack.api_url")
+	}
+
+	v.retries(c.Delivery.MaxAttempts, c.Delivery.RetryBaseDelay, c.Delivery.RetryMaxDelay, "delivery")
+	v.check(c.Delivery.DedupWindow >= 0, "delivery.dedup_window", "must not be negative")
+	v.check(c.Delivery.AggregationWindow >= 0, "delivery.aggregation_window", "must not be negative")
+
+	if c.Scheduler.Enabled {
+		v.check(c.Scheduler.Interval > 0, "scheduler.interval", "must be positive")
+	}
+
+	v.check(c.Batch.MaxItems > 0, "batch.max_items", "must be at least 1")
+	v.check(c.Batch.Parallelism > 0, "batch.parallelism", "must be at least 1")
+	v.check(c.Batch.WorkspaceRate >= 0, "batch.workspace_rate", "must not be negative")
+	v.check(c.Batch.WorkspaceBurst > 0, "batch.workspace_burst", "must be at least 1")
+
+	v.check(c.Events.Buffer > 0, "events.buffer", "must be at least 1")
+	v.check(c.Events.Retention > 0, "events.retention", "must be positive")
+
+	v.retries(c.Webhooks.MaxAttempts, c.Webhooks.RetryBaseDelay, c.Webhooks.RetryMaxDelay, "webhooks")
+
+	if _, err := c.Audit.TrustedProxyPrefixes(); err != nil {
+		v.check(false, "audit.trusted_proxies", err.Error())
+	}
+
+	v.check(c.Quota.MaxConnectors >= 0, "quota.max_connectors", "must not be negative")
+	v.check(c.Quota.MessagesPerMinute >= 0, "quota.messages_per_minute", "must not be negative")
+	v.check(c.Quota.MessagesPerDay >= 0, "quota.messages_per_day", "must not be negative")
+
+	_, err := c.Log.SlogLevel()
+	v.check(err == nil, "log.level", "must be debug, info, warn or error")
+
+	return errors.Join(v.errs...)
+}
+
+// SlogLevel parses Level.
+func (c LogConfig) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(c.Level))
+	return level, err
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, key, problem string) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", key, problem))
+	}
+}
+
+func (v *validator) port(port, key string) {
+	n, err := strconv.Atoi(port)
+	v.check(err == nil && n > 0 && n <= 65535, key, "must be a port number between 1 and 65535")
+}
+
+func (v *validator) url(raw, key string) {
+	u, err := url.Parse(raw)
+	v.check(err == nil && u.Scheme != "" && u.Host != "", key, "must be an absolute URL")
+}
+
+func (v *validator) retries(maxAttempts int, baseDelay, maxDelay time.Duration, prefix string) {
+	v.check(maxAttempts > 0, prefix+".max_attempts", "must be at least 1")
+	v.check(baseDelay > 0, prefix+".retry_base_delay", "must be positive")
+	v.check(maxDelay >= baseDelay, prefix+".retry_max_delay", "must not be less than retry_base_delay")
+}
@@ -0,0 +1,9 @@
+// Package openapiv2 embeds the OpenAPI spec generated from proto/connector.proto.
+package openapiv2
+
+import _ "embed"
+
+// Spec is the OpenAPI v2 document describing the REST gateway.
+//
+//go:embed connector.swagger.json
+var Spec []byte
@@ -0,0 +1,1156 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/connector.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	proto "github.com/iBoBoTi/connector-service/gen/proto"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SlackConnectorServiceName is the fully-qualified name of the SlackConnectorService service.
+	SlackConnectorServiceName = "connector.v1.SlackConnectorService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SlackConnectorServiceCreateConnectorProcedure is the fully-qualified name of the
+	// SlackConnectorService's CreateConnector RPC.
+	SlackConnectorServiceCreateConnectorProcedure = "/connector.v1.SlackConnectorService/CreateConnector"
+	// SlackConnectorServiceGetConnectorProcedure is the fully-qualified name of the
+	// SlackConnectorService's GetConnector RPC.
+	SlackConnectorServiceGetConnectorProcedure = "/connector.v1.SlackConnectorService/GetConnector"
+	// SlackConnectorServiceListConnectorsProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListConnectors RPC.
+	SlackConnectorServiceListConnectorsProcedure = "/connector.v1.SlackConnectorService/ListConnectors"
+	// SlackConnectorServiceUpdateConnectorProcedure is the fully-qualified name of the
+	// SlackConnectorService's UpdateConnector RPC.
+	SlackConnectorServiceUpdateConnectorProcedure = "/connector.v1.SlackConnectorService/UpdateConnector"
+	// SlackConnectorServiceVerifyConnectorProcedure is the fully-qualified name of the
+	// SlackConnectorService's VerifyConnector RPC.
+	SlackConnectorServiceVerifyConnectorProcedure = "/connector.v1.SlackConnectorService/VerifyConnector"
+	// SlackConnectorServiceDeleteConnectorProcedure is the fully-qualified name of the
+	// SlackConnectorService's DeleteConnector RPC.
+	SlackConnectorServiceDeleteConnectorProcedure = "/connector.v1.SlackConnectorService/DeleteConnector"
+	// SlackConnectorServiceSendMessageProcedure is the fully-qualified name of the
+	// SlackConnectorService's SendMessage RPC.
+	SlackConnectorServiceSendMessageProcedure = "/connector.v1.SlackConnectorService/SendMessage"
+	// SlackConnectorServiceCreateTemplateProcedure is the fully-qualified name of the
+	// SlackConnectorService's CreateTemplate RPC.
+	SlackConnectorServiceCreateTemplateProcedure = "/connector.v1.SlackConnectorService/CreateTemplate"
+	// SlackConnectorServiceGetTemplateProcedure is the fully-qualified name of the
+	// SlackConnectorService's GetTemplate RPC.
+	SlackConnectorServiceGetTemplateProcedure = "/connector.v1.SlackConnectorService/GetTemplate"
+	// SlackConnectorServiceListTemplatesProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListTemplates RPC.
+	SlackConnectorServiceListTemplatesProcedure = "/connector.v1.SlackConnectorService/ListTemplates"
+	// SlackConnectorServiceUpdateTemplateProcedure is the fully-qualified name of the
+	// SlackConnectorService's UpdateTemplate RPC.
+	SlackConnectorServiceUpdateTemplateProcedure = "/connector.v1.SlackConnectorService/UpdateTemplate"
+	// SlackConnectorServiceDeleteTemplateProcedure is the fully-qualified name of the
+	// SlackConnectorService's DeleteTemplate RPC.
+	SlackConnectorServiceDeleteTemplateProcedure = "/connector.v1.SlackConnectorService/DeleteTemplate"
+	// SlackConnectorServiceRenderTemplateProcedure is the fully-qualified name of the
+	// SlackConnectorService's RenderTemplate RPC.
+	SlackConnectorServiceRenderTemplateProcedure = "/connector.v1.SlackConnectorService/RenderTemplate"
+	// SlackConnectorServiceScheduleMessageProcedure is the fully-qualified name of the
+	// SlackConnectorService's ScheduleMessage RPC.
+	SlackConnectorServiceScheduleMessageProcedure = "/connector.v1.SlackConnectorService/ScheduleMessage"
+	// SlackConnectorServiceListScheduledMessagesProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListScheduledMessages RPC.
+	SlackConnectorServiceListScheduledMessagesProcedure = "/connector.v1.SlackConnectorService/ListScheduledMessages"
+	// SlackConnectorServiceCancelScheduledMessageProcedure is the fully-qualified name of the
+	// SlackConnectorService's CancelScheduledMessage RPC.
+	SlackConnectorServiceCancelScheduledMessageProcedure = "/connector.v1.SlackConnectorService/CancelScheduledMessage"
+	// SlackConnectorServiceCreateRoutingRuleProcedure is the fully-qualified name of the
+	// SlackConnectorService's CreateRoutingRule RPC.
+	SlackConnectorServiceCreateRoutingRuleProcedure = "/connector.v1.SlackConnectorService/CreateRoutingRule"
+	// SlackConnectorServiceGetRoutingRuleProcedure is the fully-qualified name of the
+	// SlackConnectorService's GetRoutingRule RPC.
+	SlackConnectorServiceGetRoutingRuleProcedure = "/connector.v1.SlackConnectorService/GetRoutingRule"
+	// SlackConnectorServiceListRoutingRulesProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListRoutingRules RPC.
+	SlackConnectorServiceListRoutingRulesProcedure = "/connector.v1.SlackConnectorService/ListRoutingRules"
+	// SlackConnectorServiceUpdateRoutingRuleProcedure is the fully-qualified name of the
+	// SlackConnectorService's UpdateRoutingRule RPC.
+	SlackConnectorServiceUpdateRoutingRuleProcedure = "/connector.v1.SlackConnectorService/UpdateRoutingRule"
+	// SlackConnectorServiceDeleteRoutingRuleProcedure is the fully-qualified name of the
+	// SlackConnectorService's DeleteRoutingRule RPC.
+	SlackConnectorServiceDeleteRoutingRuleProcedure = "/connector.v1.SlackConnectorService/DeleteRoutingRule"
+	// SlackConnectorServiceNotifyProcedure is the fully-qualified name of the SlackConnectorService's
+	// Notify RPC.
+	SlackConnectorServiceNotifyProcedure = "/connector.v1.SlackConnectorService/Notify"
+	// SlackConnectorServiceSetDeliveryPolicyProcedure is the fully-qualified name of the
+	// SlackConnectorService's SetDeliveryPolicy RPC.
+	SlackConnectorServiceSetDeliveryPolicyProcedure = "/connector.v1.SlackConnectorService/SetDeliveryPolicy"
+	// SlackConnectorServiceListMessagesProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListMessages RPC.
+	SlackConnectorServiceListMessagesProcedure = "/connector.v1.SlackConnectorService/ListMessages"
+	// SlackConnectorServiceBatchSendMessageProcedure is the fully-qualified name of the
+	// SlackConnectorService's BatchSendMessage RPC.
+	SlackConnectorServiceBatchSendMessageProcedure = "/connector.v1.SlackConnectorService/BatchSendMessage"
+	// SlackConnectorServiceWatchConnectorEventsProcedure is the fully-qualified name of the
+	// SlackConnectorService's WatchConnectorEvents RPC.
+	SlackConnectorServiceWatchConnectorEventsProcedure = "/connector.v1.SlackConnectorService/WatchConnectorEvents"
+	// SlackConnectorServiceCreateWebhookSubscriptionProcedure is the fully-qualified name of the
+	// SlackConnectorService's CreateWebhookSubscription RPC.
+	SlackConnectorServiceCreateWebhookSubscriptionProcedure = "/connector.v1.SlackConnectorService/CreateWebhookSubscription"
+	// SlackConnectorServiceGetWebhookSubscriptionProcedure is the fully-qualified name of the
+	// SlackConnectorService's GetWebhookSubscription RPC.
+	SlackConnectorServiceGetWebhookSubscriptionProcedure = "/connector.v1.SlackConnectorService/GetWebhookSubscription"
+	// SlackConnectorServiceListWebhookSubscriptionsProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListWebhookSubscriptions RPC.
+	SlackConnectorServiceListWebhookSubscriptionsProcedure = "/connector.v1.SlackConnectorService/ListWebhookSubscriptions"
+	// SlackConnectorServiceDeleteWebhookSubscriptionProcedure is the fully-qualified name of the
+	// SlackConnectorService's DeleteWebhookSubscription RPC.
+	SlackConnectorServiceDeleteWebhookSubscriptionProcedure = "/connector.v1.SlackConnectorService/DeleteWebhookSubscription"
+	// SlackConnectorServiceListWebhookDeliveriesProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListWebhookDeliveries RPC.
+	SlackConnectorServiceListWebhookDeliveriesProcedure = "/connector.v1.SlackConnectorService/ListWebhookDeliveries"
+	// SlackConnectorServiceTestWebhookProcedure is the fully-qualified name of the
+	// SlackConnectorService's TestWebhook RPC.
+	SlackConnectorServiceTestWebhookProcedure = "/connector.v1.SlackConnectorService/TestWebhook"
+	// SlackConnectorServiceListAuditEventsProcedure is the fully-qualified name of the
+	// SlackConnectorService's ListAuditEvents RPC.
+	SlackConnectorServiceListAuditEventsProcedure = "/connector.v1.SlackConnectorService/ListAuditEvents"
+	// SlackConnectorServiceGetTenantQuotaProcedure is the fully-qualified name of the
+	// SlackConnectorService's GetTenantQuota RPC.
+	SlackConnectorServiceGetTenantQuotaProcedure = "/connector.v1.SlackConnectorService/GetTenantQuota"
+	// SlackConnectorServiceSetTenantQuotaProcedure is the fully-qualified name of the
+	// SlackConnectorService's SetTenantQuota RPC.
+	SlackConnectorServiceSetTenantQuotaProcedure = "/connector.v1.SlackConnectorService/SetTenantQuota"
+)
+
+// SlackConnectorServiceClient is a client for the connector.v1.SlackConnectorService service.
+type SlackConnectorServiceClient interface {
+	// Creates a new connector.
+	CreateConnector(context.Context, *connect.Request[proto.CreateConnectorRequest]) (*connect.Response[proto.CreateConnectorResponse], error)
+	// Retrieves an existing connector by ID.
+	GetConnector(context.Context, *connect.Request[proto.GetConnectorRequest]) (*connect.Response[proto.GetConnectorResponse], error)
+	// Lists a tenant's connectors ordered by ID.
+	ListConnectors(context.Context, *connect.Request[proto.ListConnectorsRequest]) (*connect.Response[proto.ListConnectorsResponse], error)
+	// Changes a connector's default channel and/or rotates its credentials.
+	UpdateConnector(context.Context, *connect.Request[proto.UpdateConnectorRequest]) (*connect.Response[proto.UpdateConnectorResponse], error)
+	// Checks that a connector's stored credentials are valid and can post to its
+	// default channel, without posting a message.
+	VerifyConnector(context.Context, *connect.Request[proto.VerifyConnectorRequest]) (*connect.Response[proto.VerifyConnectorResponse], error)
+	// Deletes a connector by ID.
+	DeleteConnector(context.Context, *connect.Request[proto.DeleteConnectorRequest]) (*connect.Response[proto.DeleteConnectorResponse], error)
+	// Sends a message to the connector's default channel.
+	SendMessage(context.Context, *connect.Request[proto.SendMessageRequest]) (*connect.Response[proto.SendMessageResponse], error)
+	// Creates a named message template for a tenant.
+	CreateTemplate(context.Context, *connect.Request[proto.CreateTemplateRequest]) (*connect.Response[proto.CreateTemplateResponse], error)
+	// Retrieves a template by ID.
+	GetTemplate(context.Context, *connect.Request[proto.GetTemplateRequest]) (*connect.Response[proto.GetTemplateResponse], error)
+	// Lists the templates of a tenant.
+	ListTemplates(context.Context, *connect.Request[proto.ListTemplatesRequest]) (*connect.Response[proto.ListTemplatesResponse], error)
+	// Updates the name and/or body of a template.
+	UpdateTemplate(context.Context, *connect.Request[proto.UpdateTemplateRequest]) (*connect.Response[proto.UpdateTemplateResponse], error)
+	// Deletes a template by ID.
+	DeleteTemplate(context.Context, *connect.Request[proto.DeleteTemplateRequest]) (*connect.Response[proto.DeleteTemplateResponse], error)
+	// Renders a stored template or an inline body without sending it.
+	RenderTemplate(context.Context, *connect.Request[proto.RenderTemplateRequest]) (*connect.Response[proto.RenderTemplateResponse], error)
+	// Schedules a message for a future time, optionally recurring on a cron expression.
+	ScheduleMessage(context.Context, *connect.Request[proto.ScheduleMessageRequest]) (*connect.Response[proto.ScheduleMessageResponse], error)
+	// Lists the scheduled messages of a tenant.
+	ListScheduledMessages(context.Context, *connect.Request[proto.ListScheduledMessagesRequest]) (*connect.Response[proto.ListScheduledMessagesResponse], error)
+	// Cancels a pending scheduled message and its future recurrences.
+	CancelScheduledMessage(context.Context, *connect.Request[proto.CancelScheduledMessageRequest]) (*connect.Response[proto.CancelScheduledMessageResponse], error)
+	// Routing rules map a tenant's events to connectors and channels.
+	CreateRoutingRule(context.Context, *connect.Request[proto.CreateRoutingRuleRequest]) (*connect.Response[proto.CreateRoutingRuleResponse], error)
+	GetRoutingRule(context.Context, *connect.Request[proto.GetRoutingRuleRequest]) (*connect.Response[proto.GetRoutingRuleResponse], error)
+	ListRoutingRules(context.Context, *connect.Request[proto.ListRoutingRulesRequest]) (*connect.Response[proto.ListRoutingRulesResponse], error)
+	UpdateRoutingRule(context.Context, *connect.Request[proto.UpdateRoutingRuleRequest]) (*connect.Response[proto.UpdateRoutingRuleResponse], error)
+	DeleteRoutingRule(context.Context, *connect.Request[proto.DeleteRoutingRuleRequest]) (*connect.Response[proto.DeleteRoutingRuleResponse], error)
+	// Sends an event to every destination of the tenant's matching routing rules.
+	Notify(context.Context, *connect.Request[proto.NotifyRequest]) (*connect.Response[proto.NotifyResponse], error)
+	// Replaces a connector's delivery policy, or removes it when policy is unset.
+	SetDeliveryPolicy(context.Context, *connect.Request[proto.SetDeliveryPolicyRequest]) (*connect.Response[proto.SetDeliveryPolicyResponse], error)
+	// Lists a connector's most recent messages, newest first.
+	ListMessages(context.Context, *connect.Request[proto.ListMessagesRequest]) (*connect.Response[proto.ListMessagesResponse], error)
+	// Sends many messages concurrently. Items fail independently.
+	BatchSendMessage(context.Context, *connect.Request[proto.BatchSendMessageRequest]) (*connect.Response[proto.BatchSendMessageResponse], error)
+	// Streams the lifecycle and delivery events of a tenant or a connector,
+	// optionally resuming after the last event ID a client received.
+	WatchConnectorEvents(context.Context, *connect.Request[proto.WatchConnectorEventsRequest]) (*connect.ServerStreamForClient[proto.ConnectorEvent], error)
+	// Manages the callback URLs connector events are posted to.
+	CreateWebhookSubscription(context.Context, *connect.Request[proto.CreateWebhookSubscriptionRequest]) (*connect.Response[proto.CreateWebhookSubscriptionResponse], error)
+	GetWebhookSubscription(context.Context, *connect.Request[proto.GetWebhookSubscriptionRequest]) (*connect.Response[proto.GetWebhookSubscriptionResponse], error)
+	ListWebhookSubscriptions(context.Context, *connect.Request[proto.ListWebhookSubscriptionsRequest]) (*connect.Response[proto.ListWebhookSubscriptionsResponse], error)
+	DeleteWebhookSubscription(context.Context, *connect.Request[proto.DeleteWebhookSubscriptionRequest]) (*connect.Response[proto.DeleteWebhookSubscriptionResponse], error)
+	// Lists a subscription's most recent deliveries, newest first.
+	ListWebhookDeliveries(context.Context, *connect.Request[proto.ListWebhookDeliveriesRequest]) (*connect.Response[proto.ListWebhookDeliveriesResponse], error)
+	// Posts a sample webhook.test event to a subscription and reports the outcome.
+	TestWebhook(context.Context, *connect.Request[proto.TestWebhookRequest]) (*connect.Response[proto.TestWebhookResponse], error)
+	// Lists audit events of a tenant or a connector, newest first.
+	ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error)
+	// Admin: returns a tenant's quota and current usage.
+	GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error)
+	// Admin: replaces a tenant's quota.
+	SetTenantQuota(context.Context, *connect.Request[proto.SetTenantQuotaRequest]) (*connect.Response[proto.SetTenantQuotaResponse], error)
+}
+
+// NewSlackConnectorServiceClient constructs a client for the connector.v1.SlackConnectorService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSlackConnectorServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SlackConnectorServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	slackConnectorServiceMethods := proto.File_proto_connector_proto.Services().ByName("SlackConnectorService").Methods()
+	return &slackConnectorServiceClient{
+		createConnector: connect.NewClient[proto.CreateConnectorRequest, proto.CreateConnectorResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceCreateConnectorProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("CreateConnector")),
+			connect.WithClientOptions(opts...),
+		),
+		getConnector: connect.NewClient[proto.GetConnectorRequest, proto.GetConnectorResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceGetConnectorProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("GetConnector")),
+			connect.WithClientOptions(opts...),
+		),
+		listConnectors: connect.NewClient[proto.ListConnectorsRequest, proto.ListConnectorsResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListConnectorsProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListConnectors")),
+			connect.WithClientOptions(opts...),
+		),
+		updateConnector: connect.NewClient[proto.UpdateConnectorRequest, proto.UpdateConnectorResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceUpdateConnectorProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateConnector")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyConnector: connect.NewClient[proto.VerifyConnectorRequest, proto.VerifyConnectorResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceVerifyConnectorProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("VerifyConnector")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteConnector: connect.NewClient[proto.DeleteConnectorRequest, proto.DeleteConnectorResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceDeleteConnectorProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteConnector")),
+			connect.WithClientOptions(opts...),
+		),
+		sendMessage: connect.NewClient[proto.SendMessageRequest, proto.SendMessageResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceSendMessageProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("SendMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		createTemplate: connect.NewClient[proto.CreateTemplateRequest, proto.CreateTemplateResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceCreateTemplateProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("CreateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		getTemplate: connect.NewClient[proto.GetTemplateRequest, proto.GetTemplateResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceGetTemplateProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("GetTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		listTemplates: connect.NewClient[proto.ListTemplatesRequest, proto.ListTemplatesResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListTemplatesProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListTemplates")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTemplate: connect.NewClient[proto.UpdateTemplateRequest, proto.UpdateTemplateResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceUpdateTemplateProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTemplate: connect.NewClient[proto.DeleteTemplateRequest, proto.DeleteTemplateResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceDeleteTemplateProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		renderTemplate: connect.NewClient[proto.RenderTemplateRequest, proto.RenderTemplateResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceRenderTemplateProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("RenderTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		scheduleMessage: connect.NewClient[proto.ScheduleMessageRequest, proto.ScheduleMessageResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceScheduleMessageProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ScheduleMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		listScheduledMessages: connect.NewClient[proto.ListScheduledMessagesRequest, proto.ListScheduledMessagesResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListScheduledMessagesProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListScheduledMessages")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelScheduledMessage: connect.NewClient[proto.CancelScheduledMessageRequest, proto.CancelScheduledMessageResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceCancelScheduledMessageProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("CancelScheduledMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		createRoutingRule: connect.NewClient[proto.CreateRoutingRuleRequest, proto.CreateRoutingRuleResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceCreateRoutingRuleProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("CreateRoutingRule")),
+			connect.WithClientOptions(opts...),
+		),
+		getRoutingRule: connect.NewClient[proto.GetRoutingRuleRequest, proto.GetRoutingRuleResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceGetRoutingRuleProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("GetRoutingRule")),
+			connect.WithClientOptions(opts...),
+		),
+		listRoutingRules: connect.NewClient[proto.ListRoutingRulesRequest, proto.ListRoutingRulesResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListRoutingRulesProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListRoutingRules")),
+			connect.WithClientOptions(opts...),
+		),
+		updateRoutingRule: connect.NewClient[proto.UpdateRoutingRuleRequest, proto.UpdateRoutingRuleResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceUpdateRoutingRuleProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateRoutingRule")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteRoutingRule: connect.NewClient[proto.DeleteRoutingRuleRequest, proto.DeleteRoutingRuleResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceDeleteRoutingRuleProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteRoutingRule")),
+			connect.WithClientOptions(opts...),
+		),
+		notify: connect.NewClient[proto.NotifyRequest, proto.NotifyResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceNotifyProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("Notify")),
+			connect.WithClientOptions(opts...),
+		),
+		setDeliveryPolicy: connect.NewClient[proto.SetDeliveryPolicyRequest, proto.SetDeliveryPolicyResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceSetDeliveryPolicyProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("SetDeliveryPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		listMessages: connect.NewClient[proto.ListMessagesRequest, proto.ListMessagesResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListMessagesProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListMessages")),
+			connect.WithClientOptions(opts...),
+		),
+		batchSendMessage: connect.NewClient[proto.BatchSendMessageRequest, proto.BatchSendMessageResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceBatchSendMessageProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("BatchSendMessage")),
+			connect.WithClientOptions(opts...),
+		),
+		watchConnectorEvents: connect.NewClient[proto.WatchConnectorEventsRequest, proto.ConnectorEvent](
+			httpClient,
+			baseURL+SlackConnectorServiceWatchConnectorEventsProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("WatchConnectorEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		createWebhookSubscription: connect.NewClient[proto.CreateWebhookSubscriptionRequest, proto.CreateWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceCreateWebhookSubscriptionProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("CreateWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		getWebhookSubscription: connect.NewClient[proto.GetWebhookSubscriptionRequest, proto.GetWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceGetWebhookSubscriptionProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("GetWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookSubscriptions: connect.NewClient[proto.ListWebhookSubscriptionsRequest, proto.ListWebhookSubscriptionsResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListWebhookSubscriptionsProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListWebhookSubscriptions")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhookSubscription: connect.NewClient[proto.DeleteWebhookSubscriptionRequest, proto.DeleteWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceDeleteWebhookSubscriptionProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[proto.ListWebhookDeliveriesRequest, proto.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithClientOptions(opts...),
+		),
+		testWebhook: connect.NewClient[proto.TestWebhookRequest, proto.TestWebhookResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceTestWebhookProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("TestWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[proto.ListAuditEventsRequest, proto.ListAuditEventsResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceListAuditEventsProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		getTenantQuota: connect.NewClient[proto.GetTenantQuotaRequest, proto.GetTenantQuotaResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceGetTenantQuotaProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("GetTenantQuota")),
+			connect.WithClientOptions(opts...),
+		),
+		setTenantQuota: connect.NewClient[proto.SetTenantQuotaRequest, proto.SetTenantQuotaResponse](
+			httpClient,
+			baseURL+SlackConnectorServiceSetTenantQuotaProcedure,
+			connect.WithSchema(slackConnectorServiceMethods.ByName("SetTenantQuota")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// slackConnectorServiceClient implements SlackConnectorServiceClient.
+type slackConnectorServiceClient struct {
+	createConnector           *connect.Client[proto.CreateConnectorRequest, proto.CreateConnectorResponse]
+	getConnector              *connect.Client[proto.GetConnectorRequest, proto.GetConnectorResponse]
+	listConnectors            *connect.Client[proto.ListConnectorsRequest, proto.ListConnectorsResponse]
+	updateConnector           *connect.Client[proto.UpdateConnectorRequest, proto.UpdateConnectorResponse]
+	verifyConnector           *connect.Client[proto.VerifyConnectorRequest, proto.VerifyConnectorResponse]
+	deleteConnector           *connect.Client[proto.DeleteConnectorRequest, proto.DeleteConnectorResponse]
+	sendMessage               *connect.Client[proto.SendMessageRequest, proto.SendMessageResponse]
+	createTemplate            *connect.Client[proto.CreateTemplateRequest, proto.CreateTemplateResponse]
+	getTemplate               *connect.Client[proto.GetTemplateRequest, proto.GetTemplateResponse]
+	listTemplates             *connect.Client[proto.ListTemplatesRequest, proto.ListTemplatesResponse]
+	updateTemplate            *connect.Client[proto.UpdateTemplateRequest, proto.UpdateTemplateResponse]
+	deleteTemplate            *connect.Client[proto.DeleteTemplateRequest, proto.DeleteTemplateResponse]
+	renderTemplate            *connect.Client[proto.RenderTemplateRequest, proto.RenderTemplateResponse]
+	scheduleMessage           *connect.Client[proto.ScheduleMessageRequest, proto.ScheduleMessageResponse]
+	listScheduledMessages     *connect.Client[proto.ListScheduledMessagesRequest, proto.ListScheduledMessagesResponse]
+	cancelScheduledMessage    *connect.Client[proto.CancelScheduledMessageRequest, proto.CancelScheduledMessageResponse]
+	createRoutingRule         *connect.Client[proto.CreateRoutingRuleRequest, proto.CreateRoutingRuleResponse]
+	getRoutingRule            *connect.Client[proto.GetRoutingRuleRequest, proto.GetRoutingRuleResponse]
+	listRoutingRules          *connect.Client[proto.ListRoutingRulesRequest, proto.ListRoutingRulesResponse]
+	updateRoutingRule         *connect.Client[proto.UpdateRoutingRuleRequest, proto.UpdateRoutingRuleResponse]
+	deleteRoutingRule         *connect.Client[proto.DeleteRoutingRuleRequest, proto.DeleteRoutingRuleResponse]
+	notify                    *connect.Client[proto.NotifyRequest, proto.NotifyResponse]
+	setDeliveryPolicy         *connect.Client[proto.SetDeliveryPolicyRequest, proto.SetDeliveryPolicyResponse]
+	listMessages              *connect.Client[proto.ListMessagesRequest, proto.ListMessagesResponse]
+	batchSendMessage          *connect.Client[proto.BatchSendMessageRequest, proto.BatchSendMessageResponse]
+	watchConnectorEvents      *connect.Client[proto.WatchConnectorEventsRequest, proto.ConnectorEvent]
+	createWebhookSubscription *connect.Client[proto.CreateWebhookSubscriptionRequest, proto.CreateWebhookSubscriptionResponse]
+	getWebhookSubscription    *connect.Client[proto.GetWebhookSubscriptionRequest, proto.GetWebhookSubscriptionResponse]
+	listWebhookSubscriptions  *connect.Client[proto.ListWebhookSubscriptionsRequest, proto.ListWebhookSubscriptionsResponse]
+	deleteWebhookSubscription *connect.Client[proto.DeleteWebhookSubscriptionRequest, proto.DeleteWebhookSubscriptionResponse]
+	listWebhookDeliveries     *connect.Client[proto.ListWebhookDeliveriesRequest, proto.ListWebhookDeliveriesResponse]
+	testWebhook               *connect.Client[proto.TestWebhookRequest, proto.TestWebhookResponse]
+	listAuditEvents           *connect.Client[proto.ListAuditEventsRequest, proto.ListAuditEventsResponse]
+	getTenantQuota            *connect.Client[proto.GetTenantQuotaRequest, proto.GetTenantQuotaResponse]
+	setTenantQuota            *connect.Client[proto.SetTenantQuotaRequest, proto.SetTenantQuotaResponse]
+}
+
+// CreateConnector calls connector.v1.SlackConnectorService.CreateConnector.
+func (c *slackConnectorServiceClient) CreateConnector(ctx context.Context, req *connect.Request[proto.CreateConnectorRequest]) (*connect.Response[proto.CreateConnectorResponse], error) {
+	return c.createConnector.CallUnary(ctx, req)
+}
+
+// GetConnector calls connector.v1.SlackConnectorService.GetConnector.
+func (c *slackConnectorServiceClient) GetConnector(ctx context.Context, req *connect.Request[proto.GetConnectorRequest]) (*connect.Response[proto.GetConnectorResponse], error) {
+	return c.getConnector.CallUnary(ctx, req)
+}
+
+// ListConnectors calls connector.v1.SlackConnectorService.ListConnectors.
+func (c *slackConnectorServiceClient) ListConnectors(ctx context.Context, req *connect.Request[proto.ListConnectorsRequest]) (*connect.Response[proto.ListConnectorsResponse], error) {
+	return c.listConnectors.CallUnary(ctx, req)
+}
+
+// UpdateConnector calls connector.v1.SlackConnectorService.UpdateConnector.
+func (c *slackConnectorServiceClient) UpdateConnector(ctx context.Context, req *connect.Request[proto.UpdateConnectorRequest]) (*connect.Response[proto.UpdateConnectorResponse], error) {
+	return c.updateConnector.CallUnary(ctx, req)
+}
+
+// VerifyConnector calls connector.v1.SlackConnectorService.VerifyConnector.
+func (c *slackConnectorServiceClient) VerifyConnector(ctx context.Context, req *connect.Request[proto.VerifyConnectorRequest]) (*connect.Response[proto.VerifyConnectorResponse], error) {
+	return c.verifyConnector.CallUnary(ctx, req)
+}
+
+// DeleteConnector calls connector.v1.SlackConnectorService.DeleteConnector.
+func (c *slackConnectorServiceClient) DeleteConnector(ctx context.Context, req *connect.Request[proto.DeleteConnectorRequest]) (*connect.Response[proto.DeleteConnectorResponse], error) {
+	return c.deleteConnector.CallUnary(ctx, req)
+}
+
+// SendMessage calls connector.v1.SlackConnectorService.SendMessage.
+func (c *slackConnectorServiceClient) SendMessage(ctx context.Context, req *connect.Request[proto.SendMessageRequest]) (*connect.Response[proto.SendMessageResponse], error) {
+	return c.sendMessage.CallUnary(ctx, req)
+}
+
+// CreateTemplate calls connector.v1.SlackConnectorService.CreateTemplate.
+func (c *slackConnectorServiceClient) CreateTemplate(ctx context.Context, req *connect.Request[proto.CreateTemplateRequest]) (*connect.Response[proto.CreateTemplateResponse], error) {
+	return c.createTemplate.CallUnary(ctx, req)
+}
+
+// GetTemplate calls connector.v1.SlackConnectorService.GetTemplate.
+func (c *slackConnectorServiceClient) GetTemplate(ctx context.Context, req *connect.Request[proto.GetTemplateRequest]) (*connect.Response[proto.GetTemplateResponse], error) {
+	return c.getTemplate.CallUnary(ctx, req)
+}
+
+// ListTemplates calls connector.v1.SlackConnectorService.ListTemplates.
+func (c *slackConnectorServiceClient) ListTemplates(ctx context.Context, req *connect.Request[proto.ListTemplatesRequest]) (*connect.Response[proto.ListTemplatesResponse], error) {
+	return c.listTemplates.CallUnary(ctx, req)
+}
+
+// UpdateTemplate calls connector.v1.SlackConnectorService.UpdateTemplate.
+func (c *slackConnectorServiceClient) UpdateTemplate(ctx context.Context, req *connect.Request[proto.UpdateTemplateRequest]) (*connect.Response[proto.UpdateTemplateResponse], error) {
+	return c.updateTemplate.CallUnary(ctx, req)
+}
+
+// DeleteTemplate calls connector.v1.SlackConnectorService.DeleteTemplate.
+func (c *slackConnectorServiceClient) DeleteTemplate(ctx context.Context, req *connect.Request[proto.DeleteTemplateRequest]) (*connect.Response[proto.DeleteTemplateResponse], error) {
+	return c.deleteTemplate.CallUnary(ctx, req)
+}
+
+// RenderTemplate calls connector.v1.SlackConnectorService.RenderTemplate.
+func (c *slackConnectorServiceClient) RenderTemplate(ctx context.Context, req *connect.Request[proto.RenderTemplateRequest]) (*connect.Response[proto.RenderTemplateResponse], error) {
+	return c.renderTemplate.CallUnary(ctx, req)
+}
+
+// ScheduleMessage calls connector.v1.SlackConnectorService.ScheduleMessage.
+func (c *slackConnectorServiceClient) ScheduleMessage(ctx context.Context, req *connect.Request[proto.ScheduleMessageRequest]) (*connect.Response[proto.ScheduleMessageResponse], error) {
+	return c.scheduleMessage.CallUnary(ctx, req)
+}
+
+// ListScheduledMessages calls connector.v1.SlackConnectorService.ListScheduledMessages.
+func (c *slackConnectorServiceClient) ListScheduledMessages(ctx context.Context, req *connect.Request[proto.ListScheduledMessagesRequest]) (*connect.Response[proto.ListScheduledMessagesResponse], error) {
+	return c.listScheduledMessages.CallUnary(ctx, req)
+}
+
+// CancelScheduledMessage calls connector.v1.SlackConnectorService.CancelScheduledMessage.
+func (c *slackConnectorServiceClient) CancelScheduledMessage(ctx context.Context, req *connect.Request[proto.CancelScheduledMessageRequest]) (*connect.Response[proto.CancelScheduledMessageResponse], error) {
+	return c.cancelScheduledMessage.CallUnary(ctx, req)
+}
+
+// CreateRoutingRule calls connector.v1.SlackConnectorService.CreateRoutingRule.
+func (c *slackConnectorServiceClient) CreateRoutingRule(ctx context.Context, req *connect.Request[proto.CreateRoutingRuleRequest]) (*connect.Response[proto.CreateRoutingRuleResponse], error) {
+	return c.createRoutingRule.CallUnary(ctx, req)
+}
+
+// GetRoutingRule calls connector.v1.SlackConnectorService.GetRoutingRule.
+func (c *slackConnectorServiceClient) GetRoutingRule(ctx context.Context, req *connect.Request[proto.GetRoutingRuleRequest]) (*connect.Response[proto.GetRoutingRuleResponse], error) {
+	return c.getRoutingRule.CallUnary(ctx, req)
+}
+
+// ListRoutingRules calls connector.v1.SlackConnectorService.ListRoutingRules.
+func (c *slackConnectorServiceClient) ListRoutingRules(ctx context.Context, req *connect.Request[proto.ListRoutingRulesRequest]) (*connect.Response[proto.ListRoutingRulesResponse], error) {
+	return c.listRoutingRules.CallUnary(ctx, req)
+}
+
+// UpdateRoutingRule calls connector.v1.SlackConnectorService.UpdateRoutingRule.
+func (c *slackConnectorServiceClient) UpdateRoutingRule(ctx context.Context, req *connect.Request[proto.UpdateRoutingRuleRequest]) (*connect.Response[proto.UpdateRoutingRuleResponse], error) {
+	return c.updateRoutingRule.CallUnary(ctx, req)
+}
+
+// DeleteRoutingRule calls connector.v1.SlackConnectorService.DeleteRoutingRule.
+func (c *slackConnectorServiceClient) DeleteRoutingRule(ctx context.Context, req *connect.Request[proto.DeleteRoutingRuleRequest]) (*connect.Response[proto.DeleteRoutingRuleResponse], error) {
+	return c.deleteRoutingRule.CallUnary(ctx, req)
+}
+
+// Notify calls connector.v1.SlackConnectorService.Notify.
+func (c *slackConnectorServiceClient) Notify(ctx context.Context, req *connect.Request[proto.NotifyRequest]) (*connect.Response[proto.NotifyResponse], error) {
+	return c.notify.CallUnary(ctx, req)
+}
+
+// SetDeliveryPolicy calls connector.v1.SlackConnectorService.SetDeliveryPolicy.
+func (c *slackConnectorServiceClient) SetDeliveryPolicy(ctx context.Context, req *connect.Request[proto.SetDeliveryPolicyRequest]) (*connect.Response[proto.SetDeliveryPolicyResponse], error) {
+	return c.setDeliveryPolicy.CallUnary(ctx, req)
+}
+
+// ListMessages calls connector.v1.SlackConnectorService.ListMessages.
+func (c *slackConnectorServiceClient) ListMessages(ctx context.Context, req *connect.Request[proto.ListMessagesRequest]) (*connect.Response[proto.ListMessagesResponse], error) {
+	return c.listMessages.CallUnary(ctx, req)
+}
+
+// BatchSendMessage calls connector.v1.SlackConnectorService.BatchSendMessage.
+func (c *slackConnectorServiceClient) BatchSendMessage(ctx context.Context, req *connect.Request[proto.BatchSendMessageRequest]) (*connect.Response[proto.BatchSendMessageResponse], error) {
+	return c.batchSendMessage.CallUnary(ctx, req)
+}
+
+// WatchConnectorEvents calls connector.v1.SlackConnectorService.WatchConnectorEvents.
+func (c *slackConnectorServiceClient) WatchConnectorEvents(ctx context.Context, req *connect.Request[proto.WatchConnectorEventsRequest]) (*connect.ServerStreamForClient[proto.ConnectorEvent], error) {
+	return c.watchConnectorEvents.CallServerStream(ctx, req)
+}
+
+// CreateWebhookSubscription calls connector.v1.SlackConnectorService.CreateWebhookSubscription.
+func (c *slackConnectorServiceClient) CreateWebhookSubscription(ctx context.Context, req *connect.Request[proto.CreateWebhookSubscriptionRequest]) (*connect.Response[proto.CreateWebhookSubscriptionResponse], error) {
+	return c.createWebhookSubscription.CallUnary(ctx, req)
+}
+
+// GetWebhookSubscription calls connector.v1.SlackConnectorService.GetWebhookSubscription.
+func (c *slackConnectorServiceClient) GetWebhookSubscription(ctx context.Context, req *connect.Request[proto.GetWebhookSubscriptionRequest]) (*connect.Response[proto.GetWebhookSubscriptionResponse], error) {
+	return c.getWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookSubscriptions calls connector.v1.SlackConnectorService.ListWebhookSubscriptions.
+func (c *slackConnectorServiceClient) ListWebhookSubscriptions(ctx context.Context, req *connect.Request[proto.ListWebhookSubscriptionsRequest]) (*connect.Response[proto.ListWebhookSubscriptionsResponse], error) {
+	return c.listWebhookSubscriptions.CallUnary(ctx, req)
+}
+
+// DeleteWebhookSubscription calls connector.v1.SlackConnectorService.DeleteWebhookSubscription.
+func (c *slackConnectorServiceClient) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[proto.DeleteWebhookSubscriptionRequest]) (*connect.Response[proto.DeleteWebhookSubscriptionResponse], error) {
+	return c.deleteWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls connector.v1.SlackConnectorService.ListWebhookDeliveries.
+func (c *slackConnectorServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[proto.ListWebhookDeliveriesRequest]) (*connect.Response[proto.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// TestWebhook calls connector.v1.SlackConnectorService.TestWebhook.
+func (c *slackConnectorServiceClient) TestWebhook(ctx context.Context, req *connect.Request[proto.TestWebhookRequest]) (*connect.Response[proto.TestWebhookResponse], error) {
+	return c.testWebhook.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls connector.v1.SlackConnectorService.ListAuditEvents.
+func (c *slackConnectorServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// GetTenantQuota calls connector.v1.SlackConnectorService.GetTenantQuota.
+func (c *slackConnectorServiceClient) GetTenantQuota(ctx context.Context, req *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error) {
+	return c.getTenantQuota.CallUnary(ctx, req)
+}
+
+// SetTenantQuota calls connector.v1.SlackConnectorService.SetTenantQuota.
+func (c *slackConnectorServiceClient) SetTenantQuota(ctx context.Context, req *connect.Request[proto.SetTenantQuotaRequest]) (*connect.Response[proto.SetTenantQuotaResponse], error) {
+	return c.setTenantQuota.CallUnary(ctx, req)
+}
+
+// SlackConnectorServiceHandler is an implementation of the connector.v1.SlackConnectorService
+// service.
+type SlackConnectorServiceHandler interface {
+	// Creates a new connector.
+	CreateConnector(context.Context, *connect.Request[proto.CreateConnectorRequest]) (*connect.Response[proto.CreateConnectorResponse], error)
+	// Retrieves an existing connector by ID.
+	GetConnector(context.Context, *connect.Request[proto.GetConnectorRequest]) (*connect.Response[proto.GetConnectorResponse], error)
+	// Lists a tenant's connectors ordered by ID.
+	ListConnectors(context.Context, *connect.Request[proto.ListConnectorsRequest]) (*connect.Response[proto.ListConnectorsResponse], error)
+	// Changes a connector's default channel and/or rotates its credentials.
+	UpdateConnector(context.Context, *connect.Request[proto.UpdateConnectorRequest]) (*connect.Response[proto.UpdateConnectorResponse], error)
+	// Checks that a connector's stored credentials are valid and can post to its
+	// default channel, without posting a message.
+	VerifyConnector(context.Context, *connect.Request[proto.VerifyConnectorRequest]) (*connect.Response[proto.VerifyConnectorResponse], error)
+	// Deletes a connector by ID.
+	DeleteConnector(context.Context, *connect.Request[proto.DeleteConnectorRequest]) (*connect.Response[proto.DeleteConnectorResponse], error)
+	// Sends a message to the connector's default channel.
+	SendMessage(context.Context, *connect.Request[proto.SendMessageRequest]) (*connect.Response[proto.SendMessageResponse], error)
+	// Creates a named message template for a tenant.
+	CreateTemplate(context.Context, *connect.Request[proto.CreateTemplateRequest]) (*connect.Response[proto.CreateTemplateResponse], error)
+	// Retrieves a template by ID.
+	GetTemplate(context.Context, *connect.Request[proto.GetTemplateRequest]) (*connect.Response[proto.GetTemplateResponse], error)
+	// Lists the templates of a tenant.
+	ListTemplates(context.Context, *connect.Request[proto.ListTemplatesRequest]) (*connect.Response[proto.ListTemplatesResponse], error)
+	// Updates the name and/or body of a template.
+	UpdateTemplate(context.Context, *connect.Request[proto.UpdateTemplateRequest]) (*connect.Response[proto.UpdateTemplateResponse], error)
+	// Deletes a template by ID.
+	DeleteTemplate(context.Context, *connect.Request[proto.DeleteTemplateRequest]) (*connect.Response[proto.DeleteTemplateResponse], error)
+	// Renders a stored template or an inline body without sending it.
+	RenderTemplate(context.Context, *connect.Request[proto.RenderTemplateRequest]) (*connect.Response[proto.RenderTemplateResponse], error)
+	// Schedules a message for a future time, optionally recurring on a cron expression.
+	ScheduleMessage(context.Context, *connect.Request[proto.ScheduleMessageRequest]) (*connect.Response[proto.ScheduleMessageResponse], error)
+	// Lists the scheduled messages of a tenant.
+	ListScheduledMessages(context.Context, *connect.Request[proto.ListScheduledMessagesRequest]) (*connect.Response[proto.ListScheduledMessagesResponse], error)
+	// Cancels a pending scheduled message and its future recurrences.
+	CancelScheduledMessage(context.Context, *connect.Request[proto.CancelScheduledMessageRequest]) (*connect.Response[proto.CancelScheduledMessageResponse], error)
+	// Routing rules map a tenant's events to connectors and channels.
+	CreateRoutingRule(context.Context, *connect.Request[proto.CreateRoutingRuleRequest]) (*connect.Response[proto.CreateRoutingRuleResponse], error)
+	GetRoutingRule(context.Context, *connect.Request[proto.GetRoutingRuleRequest]) (*connect.Response[proto.GetRoutingRuleResponse], error)
+	ListRoutingRules(context.Context, *connect.Request[proto.ListRoutingRulesRequest]) (*connect.Response[proto.ListRoutingRulesResponse], error)
+	UpdateRoutingRule(context.Context, *connect.Request[proto.UpdateRoutingRuleRequest]) (*connect.Response[proto.UpdateRoutingRuleResponse], error)
+	DeleteRoutingRule(context.Context, *connect.Request[proto.DeleteRoutingRuleRequest]) (*connect.Response[proto.DeleteRoutingRuleResponse], error)
+	// Sends an event to every destination of the tenant's matching routing rules.
+	Notify(context.Context, *connect.Request[proto.NotifyRequest]) (*connect.Response[proto.NotifyResponse], error)
+	// Replaces a connector's delivery policy, or removes it when policy is unset.
+	SetDeliveryPolicy(context.Context, *connect.Request[proto.SetDeliveryPolicyRequest]) (*connect.Response[proto.SetDeliveryPolicyResponse], error)
+	// Lists a connector's most recent messages, newest first.
+	ListMessages(context.Context, *connect.Request[proto.ListMessagesRequest]) (*connect.Response[proto.ListMessagesResponse], error)
+	// Sends many messages concurrently. Items fail independently.
+	BatchSendMessage(context.Context, *connect.Request[proto.BatchSendMessageRequest]) (*connect.Response[proto.BatchSendMessageResponse], error)
+	// Streams the lifecycle and delivery events of a tenant or a connector,
+	// optionally resuming after the last event ID a client received.
+	WatchConnectorEvents(context.Context, *connect.Request[proto.WatchConnectorEventsRequest], *connect.ServerStream[proto.ConnectorEvent]) error
+	// Manages the callback URLs connector events are posted to.
+	CreateWebhookSubscription(context.Context, *connect.Request[proto.CreateWebhookSubscriptionRequest]) (*connect.Response[proto.CreateWebhookSubscriptionResponse], error)
+	GetWebhookSubscription(context.Context, *connect.Request[proto.GetWebhookSubscriptionRequest]) (*connect.Response[proto.GetWebhookSubscriptionResponse], error)
+	ListWebhookSubscriptions(context.Context, *connect.Request[proto.ListWebhookSubscriptionsRequest]) (*connect.Response[proto.ListWebhookSubscriptionsResponse], error)
+	DeleteWebhookSubscription(context.Context, *connect.Request[proto.DeleteWebhookSubscriptionRequest]) (*connect.Response[proto.DeleteWebhookSubscriptionResponse], error)
+	// Lists a subscription's most recent deliveries, newest first.
+	ListWebhookDeliveries(context.Context, *connect.Request[proto.ListWebhookDeliveriesRequest]) (*connect.Response[proto.ListWebhookDeliveriesResponse], error)
+	// Posts a sample webhook.test event to a subscription and reports the outcome.
+	TestWebhook(context.Context, *connect.Request[proto.TestWebhookRequest]) (*connect.Response[proto.TestWebhookResponse], error)
+	// Lists audit events of a tenant or a connector, newest first.
+	ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error)
+	// Admin: returns a tenant's quota and current usage.
+	GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error)
+	// Admin: replaces a tenant's quota.
+	SetTenantQuota(context.Context, *connect.Request[proto.SetTenantQuotaRequest]) (*connect.Response[proto.SetTenantQuotaResponse], error)
+}
+
+// NewSlackConnectorServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSlackConnectorServiceHandler(svc SlackConnectorServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	slackConnectorServiceMethods := proto.File_proto_connector_proto.Services().ByName("SlackConnectorService").Methods()
+	slackConnectorServiceCreateConnectorHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceCreateConnectorProcedure,
+		svc.CreateConnector,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("CreateConnector")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceGetConnectorHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceGetConnectorProcedure,
+		svc.GetConnector,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("GetConnector")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListConnectorsHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListConnectorsProcedure,
+		svc.ListConnectors,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListConnectors")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceUpdateConnectorHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceUpdateConnectorProcedure,
+		svc.UpdateConnector,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateConnector")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceVerifyConnectorHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceVerifyConnectorProcedure,
+		svc.VerifyConnector,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("VerifyConnector")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceDeleteConnectorHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceDeleteConnectorProcedure,
+		svc.DeleteConnector,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteConnector")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceSendMessageHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceSendMessageProcedure,
+		svc.SendMessage,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("SendMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceCreateTemplateHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceCreateTemplateProcedure,
+		svc.CreateTemplate,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("CreateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceGetTemplateHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceGetTemplateProcedure,
+		svc.GetTemplate,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("GetTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListTemplatesHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListTemplatesProcedure,
+		svc.ListTemplates,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListTemplates")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceUpdateTemplateHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceUpdateTemplateProcedure,
+		svc.UpdateTemplate,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceDeleteTemplateHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceDeleteTemplateProcedure,
+		svc.DeleteTemplate,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceRenderTemplateHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceRenderTemplateProcedure,
+		svc.RenderTemplate,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("RenderTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceScheduleMessageHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceScheduleMessageProcedure,
+		svc.ScheduleMessage,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ScheduleMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListScheduledMessagesHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListScheduledMessagesProcedure,
+		svc.ListScheduledMessages,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListScheduledMessages")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceCancelScheduledMessageHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceCancelScheduledMessageProcedure,
+		svc.CancelScheduledMessage,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("CancelScheduledMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceCreateRoutingRuleHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceCreateRoutingRuleProcedure,
+		svc.CreateRoutingRule,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("CreateRoutingRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceGetRoutingRuleHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceGetRoutingRuleProcedure,
+		svc.GetRoutingRule,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("GetRoutingRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListRoutingRulesHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListRoutingRulesProcedure,
+		svc.ListRoutingRules,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListRoutingRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceUpdateRoutingRuleHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceUpdateRoutingRuleProcedure,
+		svc.UpdateRoutingRule,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("UpdateRoutingRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceDeleteRoutingRuleHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceDeleteRoutingRuleProcedure,
+		svc.DeleteRoutingRule,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteRoutingRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceNotifyHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceNotifyProcedure,
+		svc.Notify,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("Notify")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceSetDeliveryPolicyHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceSetDeliveryPolicyProcedure,
+		svc.SetDeliveryPolicy,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("SetDeliveryPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListMessagesHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListMessagesProcedure,
+		svc.ListMessages,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListMessages")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceBatchSendMessageHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceBatchSendMessageProcedure,
+		svc.BatchSendMessage,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("BatchSendMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceWatchConnectorEventsHandler := connect.NewServerStreamHandler(
+		SlackConnectorServiceWatchConnectorEventsProcedure,
+		svc.WatchConnectorEvents,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("WatchConnectorEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceCreateWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceCreateWebhookSubscriptionProcedure,
+		svc.CreateWebhookSubscription,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("CreateWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceGetWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceGetWebhookSubscriptionProcedure,
+		svc.GetWebhookSubscription,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("GetWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListWebhookSubscriptionsHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListWebhookSubscriptionsProcedure,
+		svc.ListWebhookSubscriptions,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListWebhookSubscriptions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceDeleteWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceDeleteWebhookSubscriptionProcedure,
+		svc.DeleteWebhookSubscription,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("DeleteWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceTestWebhookHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceTestWebhookProcedure,
+		svc.TestWebhook,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("TestWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceGetTenantQuotaHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceGetTenantQuotaProcedure,
+		svc.GetTenantQuota,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("GetTenantQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	slackConnectorServiceSetTenantQuotaHandler := connect.NewUnaryHandler(
+		SlackConnectorServiceSetTenantQuotaProcedure,
+		svc.SetTenantQuota,
+		connect.WithSchema(slackConnectorServiceMethods.ByName("SetTenantQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/connector.v1.SlackConnectorService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SlackConnectorServiceCreateConnectorProcedure:
+			slackConnectorServiceCreateConnectorHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceGetConnectorProcedure:
+			slackConnectorServiceGetConnectorHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListConnectorsProcedure:
+			slackConnectorServiceListConnectorsHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceUpdateConnectorProcedure:
+			slackConnectorServiceUpdateConnectorHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceVerifyConnectorProcedure:
+			slackConnectorServiceVerifyConnectorHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceDeleteConnectorProcedure:
+			slackConnectorServiceDeleteConnectorHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceSendMessageProcedure:
+			slackConnectorServiceSendMessageHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceCreateTemplateProcedure:
+			slackConnectorServiceCreateTemplateHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceGetTemplateProcedure:
+			slackConnectorServiceGetTemplateHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListTemplatesProcedure:
+			slackConnectorServiceListTemplatesHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceUpdateTemplateProcedure:
+			slackConnectorServiceUpdateTemplateHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceDeleteTemplateProcedure:
+			slackConnectorServiceDeleteTemplateHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceRenderTemplateProcedure:
+			slackConnectorServiceRenderTemplateHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceScheduleMessageProcedure:
+			slackConnectorServiceScheduleMessageHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListScheduledMessagesProcedure:
+			slackConnectorServiceListScheduledMessagesHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceCancelScheduledMessageProcedure:
+			slackConnectorServiceCancelScheduledMessageHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceCreateRoutingRuleProcedure:
+			slackConnectorServiceCreateRoutingRuleHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceGetRoutingRuleProcedure:
+			slackConnectorServiceGetRoutingRuleHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListRoutingRulesProcedure:
+			slackConnectorServiceListRoutingRulesHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceUpdateRoutingRuleProcedure:
+			slackConnectorServiceUpdateRoutingRuleHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceDeleteRoutingRuleProcedure:
+			slackConnectorServiceDeleteRoutingRuleHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceNotifyProcedure:
+			slackConnectorServiceNotifyHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceSetDeliveryPolicyProcedure:
+			slackConnectorServiceSetDeliveryPolicyHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListMessagesProcedure:
+			slackConnectorServiceListMessagesHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceBatchSendMessageProcedure:
+			slackConnectorServiceBatchSendMessageHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceWatchConnectorEventsProcedure:
+			slackConnectorServiceWatchConnectorEventsHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceCreateWebhookSubscriptionProcedure:
+			slackConnectorServiceCreateWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceGetWebhookSubscriptionProcedure:
+			slackConnectorServiceGetWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListWebhookSubscriptionsProcedure:
+			slackConnectorServiceListWebhookSubscriptionsHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceDeleteWebhookSubscriptionProcedure:
+			slackConnectorServiceDeleteWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListWebhookDeliveriesProcedure:
+			slackConnectorServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceTestWebhookProcedure:
+			slackConnectorServiceTestWebhookHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceListAuditEventsProcedure:
+			slackConnectorServiceListAuditEventsHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceGetTenantQuotaProcedure:
+			slackConnectorServiceGetTenantQuotaHandler.ServeHTTP(w, r)
+		case SlackConnectorServiceSetTenantQuotaProcedure:
+			slackConnectorServiceSetTenantQuotaHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSlackConnectorServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSlackConnectorServiceHandler struct{}
+
+func (UnimplementedSlackConnectorServiceHandler) CreateConnector(context.Context, *connect.Request[proto.CreateConnectorRequest]) (*connect.Response[proto.CreateConnectorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.CreateConnector is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) GetConnector(context.Context, *connect.Request[proto.GetConnectorRequest]) (*connect.Response[proto.GetConnectorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.GetConnector is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListConnectors(context.Context, *connect.Request[proto.ListConnectorsRequest]) (*connect.Response[proto.ListConnectorsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListConnectors is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) UpdateConnector(context.Context, *connect.Request[proto.UpdateConnectorRequest]) (*connect.Response[proto.UpdateConnectorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.UpdateConnector is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) VerifyConnector(context.Context, *connect.Request[proto.VerifyConnectorRequest]) (*connect.Response[proto.VerifyConnectorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.VerifyConnector is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) DeleteConnector(context.Context, *connect.Request[proto.DeleteConnectorRequest]) (*connect.Response[proto.DeleteConnectorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.DeleteConnector is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) SendMessage(context.Context, *connect.Request[proto.SendMessageRequest]) (*connect.Response[proto.SendMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.SendMessage is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) CreateTemplate(context.Context, *connect.Request[proto.CreateTemplateRequest]) (*connect.Response[proto.CreateTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.CreateTemplate is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) GetTemplate(context.Context, *connect.Request[proto.GetTemplateRequest]) (*connect.Response[proto.GetTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.GetTemplate is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListTemplates(context.Context, *connect.Request[proto.ListTemplatesRequest]) (*connect.Response[proto.ListTemplatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListTemplates is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) UpdateTemplate(context.Context, *connect.Request[proto.UpdateTemplateRequest]) (*connect.Response[proto.UpdateTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.UpdateTemplate is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) DeleteTemplate(context.Context, *connect.Request[proto.DeleteTemplateRequest]) (*connect.Response[proto.DeleteTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.DeleteTemplate is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) RenderTemplate(context.Context, *connect.Request[proto.RenderTemplateRequest]) (*connect.Response[proto.RenderTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.RenderTemplate is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ScheduleMessage(context.Context, *connect.Request[proto.ScheduleMessageRequest]) (*connect.Response[proto.ScheduleMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ScheduleMessage is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListScheduledMessages(context.Context, *connect.Request[proto.ListScheduledMessagesRequest]) (*connect.Response[proto.ListScheduledMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListScheduledMessages is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) CancelScheduledMessage(context.Context, *connect.Request[proto.CancelScheduledMessageRequest]) (*connect.Response[proto.CancelScheduledMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.CancelScheduledMessage is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) CreateRoutingRule(context.Context, *connect.Request[proto.CreateRoutingRuleRequest]) (*connect.Response[proto.CreateRoutingRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.CreateRoutingRule is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) GetRoutingRule(context.Context, *connect.Request[proto.GetRoutingRuleRequest]) (*connect.Response[proto.GetRoutingRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.GetRoutingRule is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListRoutingRules(context.Context, *connect.Request[proto.ListRoutingRulesRequest]) (*connect.Response[proto.ListRoutingRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListRoutingRules is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) UpdateRoutingRule(context.Context, *connect.Request[proto.UpdateRoutingRuleRequest]) (*connect.Response[proto.UpdateRoutingRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.UpdateRoutingRule is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) DeleteRoutingRule(context.Context, *connect.Request[proto.DeleteRoutingRuleRequest]) (*connect.Response[proto.DeleteRoutingRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.DeleteRoutingRule is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) Notify(context.Context, *connect.Request[proto.NotifyRequest]) (*connect.Response[proto.NotifyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.Notify is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) SetDeliveryPolicy(context.Context, *connect.Request[proto.SetDeliveryPolicyRequest]) (*connect.Response[proto.SetDeliveryPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.SetDeliveryPolicy is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListMessages(context.Context, *connect.Request[proto.ListMessagesRequest]) (*connect.Response[proto.ListMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListMessages is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) BatchSendMessage(context.Context, *connect.Request[proto.BatchSendMessageRequest]) (*connect.Response[proto.BatchSendMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.BatchSendMessage is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) WatchConnectorEvents(context.Context, *connect.Request[proto.WatchConnectorEventsRequest], *connect.ServerStream[proto.ConnectorEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.WatchConnectorEvents is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) CreateWebhookSubscription(context.Context, *connect.Request[proto.CreateWebhookSubscriptionRequest]) (*connect.Response[proto.CreateWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.CreateWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) GetWebhookSubscription(context.Context, *connect.Request[proto.GetWebhookSubscriptionRequest]) (*connect.Response[proto.GetWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.GetWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListWebhookSubscriptions(context.Context, *connect.Request[proto.ListWebhookSubscriptionsRequest]) (*connect.Response[proto.ListWebhookSubscriptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListWebhookSubscriptions is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) DeleteWebhookSubscription(context.Context, *connect.Request[proto.DeleteWebhookSubscriptionRequest]) (*connect.Response[proto.DeleteWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.DeleteWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[proto.ListWebhookDeliveriesRequest]) (*connect.Response[proto.ListWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) TestWebhook(context.Context, *connect.Request[proto.TestWebhookRequest]) (*connect.Response[proto.TestWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.TestWebhook is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) ListAuditEvents(context.Context, *connect.Request[proto.ListAuditEventsRequest]) (*connect.Response[proto.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) GetTenantQuota(context.Context, *connect.Request[proto.GetTenantQuotaRequest]) (*connect.Response[proto.GetTenantQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.GetTenantQuota is not implemented"))
+}
+
+func (UnimplementedSlackConnectorServiceHandler) SetTenantQuota(context.Context, *connect.Request[proto.SetTenantQuotaRequest]) (*connect.Response[proto.SetTenantQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connector.v1.SlackConnectorService.SetTenantQuota is not implemented"))
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	stderrors "errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+var connectorHeader = []string{"ID", "PROVIDER", "TENANT", "WORKSPACE", "CHANNEL", "CREATED"}
+
+func connectorRow(c *connector_v1.Connector) []string {
+	return []string{c.GetId(), enumName(c.GetProvider().String(), "PROVIDER_"), c.GetTenantId(), c.GetWorkspaceId(), c.GetDefaultChannelId(), c.GetCreatedAt()}
+}
+
+func runCreate(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	tenant := fs.String("tenant", env.profile.TenantID, "tenant ID")
+	workspace := fs.String("workspace", "", "workspace ID")
+	channel := fs.String("channel", "", "default channel name")
+	provider := fs.String("provider", "slack", "slack, microsoft_teams, discord or mattermost")
+	credentialsFile := fs.String("credentials-file", "", "file holding the provider credentials (default $CONNECTORCTL_CREDENTIALS)")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	p, err := parseEnum(connector_v1.Provider_value, "PROVIDER_", *provider)
+	if err != nil {
+		return err
+	}
+	credentials, err := readCredentials(*credentialsFile)
+	if err != nil {
+		return err
+	}
+
+	resp, err := env.client.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		TenantId:           *tenant,
+		WorkspaceId:        *workspace,
+		DefaultChannelName: *channel,
+		Provider:           connector_v1.Provider(p),
+		Credentials:        credentials,
+	})
+	if err != nil {
+		return err
+	}
+	return env.out.print(resp.GetConnector(), table{connectorHeader, [][]string{connectorRow(resp.GetConnector())}})
+}
+
+func runGet(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := env.client.GetConnector(ctx, &connector_v1.GetConnectorRequest{ConnectorId: pos[0]})
+	if err != nil {
+		return err
+	}
+	return env.out.print(resp.GetConnector(), table{connectorHeader, [][]string{connectorRow(resp.GetConnector())}})
+}
+
+func runList(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	tenant := fs.String("tenant", env.profile.TenantID, "tenant ID")
+	pageSize := fs.Int("page-size", 0, "connectors per page (server default when 0)")
+	pageToken := fs.String("page-token", "", "next_page_token of the previous page")
+	all := fs.Bool("all", false, "fetch every page")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &connector_v1.ListConnectorsRequest{TenantId: *tenant, PageSize: int32(*pageSize), PageToken: *pageToken}
+	list := &connector_v1.ListConnectorsResponse{}
+	for {
+		resp, err := env.client.ListConnectors(ctx, req)
+		if err != nil {
+			return err
+		}
+		list.Connectors = append(list.Connectors, resp.GetConnectors()...)
+		list.NextPageToken = resp.GetNextPageToken()
+		if !*all || list.NextPageToken == "" {
+			break
+		}
+		req.PageToken = list.NextPageToken
+	}
+
+	t := table{header: connectorHeader}
+	for _, c := range list.GetConnectors() {
+		t.rows = append(t.rows, connectorRow(c))
+	}
+	return env.out.print(list, t)
+}
+
+func runUpdate(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	channel := fs.String("channel", "", "new default channel name")
+	credentialsFile := fs.String("credentials-file", "", "file holding the new provider credentials (default $CONNECTORCTL_CREDENTIALS)")
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	credentials, err := readCredentials(*credentialsFile)
+	if err != nil {
+		return err
+	}
+
+	resp, err := env.client.UpdateConnector(ctx, &connector_v1.UpdateConnectorRequest{
+		ConnectorId:        pos[0],
+		DefaultChannelName: *channel,
+		Credentials:        credentials,
+	})
+	if err != nil {
+		return err
+	}
+	return env.out.print(resp.GetConnector(), table{connectorHeader, [][]string{connectorRow(resp.GetConnector())}})
+}
+
+func runDelete(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := env.client.DeleteConnector(ctx, &connector_v1.DeleteConnectorRequest{ConnectorId: pos[0]})
+	if err != nil {
+		return err
+	}
+	return env.out.print(resp, table{[]string{"ID", "DELETED"}, [][]string{{pos[0], strconv.FormatBool(resp.GetSuccess())}}})
+}
+
+func runSend(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	text := fs.String("text", "", "message text; read from stdin when -")
+	channel := fs.String("channel", "", "channel name instead of the default channel")
+	severity := fs.String("severity", "", "info, warning, error or critical")
+	dedupKey := fs.String("dedup-key", "", "suppress later sends with the same key")
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	s, err := parseEnum(connector_v1.Severity_value, "SEVERITY_", *severity)
+	if err != nil {
+		return err
+	}
+	if *text == "-" {
+		b, err := io.ReadAll(env.stdin)
+		if err != nil {
+			return err
+		}
+		*text = string(b)
+	}
+
+	resp, err := env.client.SendMessage(ctx, &connector_v1.SendMessageRequest{
+		ConnectorId: pos[0],
+		Text:        *text,
+		Channel:     *channel,
+		Severity:    connector_v1.Severity(s),
+		DedupKey:    *dedupKey,
+	})
+	if err != nil {
+		return err
+	}
+	return env.out.print(resp, table{[]string{"MESSAGE ID", "STATUS"}, [][]string{{resp.GetMessageId(), resp.GetStatus()}}})
+}
+
+func runVerify(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	resp, err := env.client.VerifyConnector(ctx, &connector_v1.VerifyConnectorRequest{ConnectorId: pos[0]})
+	if err != nil {
+		return err
+	}
+	if err := env.out.print(resp, table{[]string{"ID", "OK", "REASON", "MESSAGE"}, [][]string{{pos[0], strconv.FormatBool(resp.GetOk()), resp.GetReason(), resp.GetMessage()}}}); err != nil {
+		return err
+	}
+	if !resp.GetOk() {
+		return fmt.Errorf("connector %s failed verification", pos[0])
+	}
+	return nil
+}
+
+func runTailEvents(ctx context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("tail-events", flag.ContinueOnError)
+	tenant := fs.String("tenant", env.profile.TenantID, "tenant ID")
+	connector := fs.String("connector", "", "connector ID instead of a tenant")
+	after := fs.Int64("after", 0, "replay the events after this event ID first")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	req := &connector_v1.WatchConnectorEventsRequest{TenantId: *tenant, ConnectorId: *connector, AfterEventId: *after}
+	if *connector != "" {
+		req.TenantId = ""
+	}
+
+	stream, err := env.client.WatchConnectorEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			if stderrors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		row := []string{strconv.FormatInt(e.GetId(), 10), e.GetCreatedAt(), e.GetType(), e.GetConnectorId(), formatData(e.GetData())}
+		if err := env.out.printStreamed(e, table{[]string{"ID", "TIME", "TYPE", "CONNECTOR", "DATA"}, [][]string{row}}); err != nil {
+			return err
+		}
+	}
+}
+
+// parseEnum looks up a lower case enum name without its prefix; empty is the
+// unspecified value.
+func parseEnum(values map[string]int32, prefix, name string) (int32, error) {
+	if name == "" {
+		return 0, nil
+	}
+	v, ok := values[prefix+strings.ToUpper(strings.ReplaceAll(name, "-", "_"))]
+	if !ok || v == 0 {
+		return 0, fmt.Errorf("unknown %s %q", strings.ToLower(strings.TrimSuffix(prefix, "_")), name)
+	}
+	return v, nil
+}
+
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+func formatData(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+data[k])
+	}
+	return strings.Join(pairs, " ")
+}
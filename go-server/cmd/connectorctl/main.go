@@ -0,0 +1,190 @@
+// Command connectorctl operates connectors on a connector-service server.
+//
+// Usage:
+//
+//	connectorctl [--profile name] [--config path] [-o table|json|yaml] <command> [flags] [args]
+//
+// Profiles are read from $CONNECTORCTL_CONFIG, or connectorctl/config.yaml in
+// the user's config directory:
+//
+//	current_profile: local
+//	profiles:
+//	  local:
+//	    address: localhost:50051
+//	    insecure: true
+//	  prod:
+//	    address: connectors.example.com:443
+//	    actor: alice@example.com
+//	    tenant_id: tenant-1
+//
+// CONNECTORCTL_PROFILE, CONNECTORCTL_ADDRESS, CONNECTORCTL_TOKEN,
+// CONNECTORCTL_ACTOR and CONNECTORCTL_TENANT_ID override the profile.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+// command runs one subcommand with its own flags.
+type command struct {
+	usage string
+	run   func(ctx context.Context, env *environment, args []string) error
+}
+
+var commands = map[string]command{
+	"create":      {"create --tenant ID --workspace ID --channel NAME [--provider slack] [--credentials-file PATH]", runCreate},
+	"get":         {"get CONNECTOR_ID", runGet},
+	"list":        {"list [--tenant ID] [--page-size N] [--page-token TOKEN] [--all]", runList},
+	"update":      {"update CONNECTOR_ID [--channel NAME] [--credentials-file PATH]", runUpdate},
+	"delete":      {"delete CONNECTOR_ID", runDelete},
+	"send":        {"send CONNECTOR_ID --text TEXT [--channel NAME] [--severity LEVEL] [--dedup-key KEY]", runSend},
+	"verify":      {"verify CONNECTOR_ID", runVerify},
+	"tail-events": {"tail-events [--tenant ID] [--connector ID] [--after EVENT_ID]", runTailEvents},
+}
+
+// environment is what every command runs with.
+type environment struct {
+	profile Profile
+	client  connector_v1.SlackConnectorServiceClient
+	out     *printer
+	stdin   io.Reader
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "connectorctl:", describeError(err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("connectorctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	profileName := fs.String("profile", "", "profile to use")
+	configPath := fs.String("config", defaultConfigPath(), "config file listing the profiles")
+	output := fs.String("o", "", "output format: table, json or yaml")
+	fs.Usage = func() { printUsage(stderr) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		printUsage(stderr)
+		return fmt.Errorf("no command given")
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		printUsage(stderr)
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	profile, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		profile.Output = *output
+	}
+	out, err := newPrinter(stdout, profile.Output)
+	if err != nil {
+		return err
+	}
+
+	c, err := profile.dial()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	env := &environment{
+		profile: profile,
+		client:  c,
+		out:     out,
+		stdin:   stdin,
+	}
+	return cmd.run(ctx, env, fs.Args()[1:])
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: connectorctl [--profile name] [--config path] [-o table|json|yaml] <command> [flags] [args]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+commands[name].usage)
+	}
+}
+
+// describeError adds the reason and invalid fields of a gRPC status to its message.
+func describeError(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+	msg := fmt.Sprintf("%s: %s", st.Code(), st.Message())
+	for _, d := range st.Details() {
+		switch v := d.(type) {
+		case *errdetails.ErrorInfo:
+			msg += " (" + v.GetReason() + ")"
+		case *errdetails.BadRequest:
+			for _, f := range v.GetFieldViolations() {
+				msg += fmt.Sprintf("\n  %s: %s", f.GetField(), f.GetDescription())
+			}
+		case *errdetails.RetryInfo:
+			msg += fmt.Sprintf("\n  retry after %s", v.GetRetryDelay().AsDuration())
+		}
+	}
+	return msg
+}
+
+// parseArgs parses the command's flags, which may come before or after its
+// positional arguments, and checks the number of positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string, positional int) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(rest) != positional {
+		return nil, fmt.Errorf("%s: expected %d argument(s), got %d", fs.Name(), positional, len(rest))
+	}
+	return rest, nil
+}
+
+// readCredentials returns the provider credentials from path, or from
+// $CONNECTORCTL_CREDENTIALS, so that they never appear on the command line.
+func readCredentials(path string) (string, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return os.Getenv("CONNECTORCTL_CREDENTIALS"), nil
+}
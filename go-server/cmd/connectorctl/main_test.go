@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+const testConfig = `
+current_profile: staging
+profiles:
+  staging:
+    address: staging.example.com:443
+    actor: alice@example.com
+    tenant_id: tenant-1
+  local:
+    address: localhost:50051
+    insecure: true
+    output: json
+`
+
+func writeConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0o600))
+	return path
+}
+
+func TestLoadProfile_UsesCurrentProfileWithEnvOverrides(t *testing.T) {
+	path := writeConfig(t)
+	t.Setenv(envProfile, "")
+	t.Setenv(envTenantID, "tenant-2")
+
+	p, err := loadProfile(path, "")
+	require.NoError(t, err)
+	require.Equal(t, Profile{Address: "staging.example.com:443", Actor: "alice@example.com", TenantID: "tenant-2", Output: outputTable}, p)
+}
+
+func TestLoadProfile_SelectsNamedProfile(t *testing.T) {
+	path := writeConfig(t)
+	t.Setenv(envProfile, "staging")
+
+	p, err := loadProfile(path, "local")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:50051", p.Address)
+	require.True(t, p.Insecure)
+	require.Equal(t, outputJSON, p.Output)
+
+	_, err = loadProfile(path, "prod")
+	require.ErrorContains(t, err, `profile "prod" not found`)
+}
+
+func TestLoadProfile_DefaultsWithoutConfigFile(t *testing.T) {
+	t.Setenv(envProfile, "")
+
+	p, err := loadProfile(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	require.NoError(t, err)
+	require.Equal(t, defaultProfile, p)
+}
+
+func TestPrinter_Formats(t *testing.T) {
+	c := &connector_v1.Connector{Id: "conn-1", Provider: connector_v1.Provider_PROVIDER_SLACK, TenantId: "tenant-1"}
+	tests := map[string]string{
+		outputTable: "ID      PROVIDER  TENANT    WORKSPACE  CHANNEL  CREATED\nconn-1  slack     tenant-1                      \n",
+		outputYAML:  "id: conn-1\n",
+		outputJSON:  "\"id\": \"conn-1\"",
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			p, err := newPrinter(&buf, format)
+			require.NoError(t, err)
+
+			require.NoError(t, p.print(c, table{connectorHeader, [][]string{connectorRow(c)}}))
+			require.Contains(t, buf.String(), want)
+		})
+	}
+
+	_, err := newPrinter(&bytes.Buffer{}, "xml")
+	require.Error(t, err)
+}
+
+func TestParseArgs_AcceptsFlagsAfterArguments(t *testing.T) {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	text := fs.String("text", "", "")
+
+	pos, err := parseArgs(fs, []string{"conn-1", "--text", "hello"}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"conn-1"}, pos)
+	require.Equal(t, "hello", *text)
+
+	_, err = parseArgs(flag.NewFlagSet("get", flag.ContinueOnError), nil, 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// table is the tabular form of a response.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// printer writes responses in one output format. Streamed responses are
+// written one after another: as rows of one table, JSON lines or YAML documents.
+type printer struct {
+	w        io.Writer
+	format   string
+	wroteHdr bool
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case outputTable, outputJSON, outputYAML:
+		return &printer{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q: use table, json or yaml", format)
+	}
+}
+
+// print writes msg, or t in table format.
+func (p *printer) print(msg proto.Message, t table) error {
+	switch p.format {
+	case outputJSON:
+		b, err := marshalJSON(msg, true)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", b)
+		return err
+	case outputYAML:
+		v, err := plain(msg)
+		if err != nil {
+			return err
+		}
+		return yaml.NewEncoder(p.w).Encode(v)
+	default:
+		return p.printTable(t)
+	}
+}
+
+// printStreamed writes one message of a stream. JSON is written one message per line.
+func (p *printer) printStreamed(msg proto.Message, t table) error {
+	switch p.format {
+	case outputJSON:
+		b, err := marshalJSON(msg, false)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", b)
+		return err
+	case outputYAML:
+		if _, err := io.WriteString(p.w, "---\n"); err != nil {
+			return err
+		}
+		return p.print(msg, t)
+	default:
+		return p.printTable(t)
+	}
+}
+
+func (p *printer) printTable(t table) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if !p.wroteHdr {
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		p.wroteHdr = true
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// marshalJSON marshals msg with the proto field names. The output is
+// reformatted because protojson varies its whitespace on purpose.
+func marshalJSON(msg proto.Message, indent bool) ([]byte, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: indent}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if indent {
+		err = json.Indent(&buf, b, "", "  ")
+	} else {
+		err = json.Compact(&buf, b)
+	}
+	return buf.Bytes(), err
+}
+
+// plain converts msg to maps and slices with the proto field names, for YAML.
+func plain(msg proto.Message) (any, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/iBoBoTi/connector-service/pkg/client"
+)
+
+// Profile holds the settings of one environment the CLI can talk to.
+type Profile struct {
+	Address string `yaml:"address"`
+	// Insecure disables TLS, e.g. for a local server.
+	Insecure bool `yaml:"insecure"`
+	// CAFile verifies the server with this CA instead of the system roots.
+	CAFile string `yaml:"ca_file"`
+	// Token is sent as a bearer token, for servers behind an authenticating proxy.
+	Token    string `yaml:"token"`
+	Actor    string `yaml:"actor"`
+	TenantID string `yaml:"tenant_id"`
+	Output   string `yaml:"output"`
+}
+
+// ConfigFile is the CLI config file listing the profiles.
+type ConfigFile struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// Environment variables overriding the selected profile.
+const (
+	envConfig   = "CONNECTORCTL_CONFIG"
+	envProfile  = "CONNECTORCTL_PROFILE"
+	envAddress  = "CONNECTORCTL_ADDRESS"
+	envToken    = "CONNECTORCTL_TOKEN"
+	envActor    = "CONNECTORCTL_ACTOR"
+	envTenantID = "CONNECTORCTL_TENANT_ID"
+)
+
+var defaultProfile = Profile{Address: "localhost:50051", Insecure: true, Output: "table"}
+
+// defaultConfigPath is $CONNECTORCTL_CONFIG, or connectorctl/config.yaml in
+// the user's config directory.
+func defaultConfigPath() string {
+	if p := os.Getenv(envConfig); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "connectorctl", "config.yaml")
+}
+
+// loadProfile returns the named profile, or $CONNECTORCTL_PROFILE, or the
+// config file's current profile, with the environment variables applied on
+// top. Without a config file the default local profile is used.
+func loadProfile(path, name string) (Profile, error) {
+	var file ConfigFile
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return Profile{}, err
+		default:
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return Profile{}, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		}
+	}
+
+	if name == "" {
+		name = os.Getenv(envProfile)
+	}
+	if name == "" {
+		name = file.CurrentProfile
+	}
+
+	profile := defaultProfile
+	if name != "" {
+		p, ok := file.Profiles[name]
+		if !ok {
+			return Profile{}, fmt.Errorf("profile %q not found in %s", name, path)
+		}
+		profile = p
+		if profile.Output == "" {
+			profile.Output = defaultProfile.Output
+		}
+	}
+
+	for env, field := range map[string]*string{
+		envAddress:  &profile.Address,
+		envToken:    &profile.Token,
+		envActor:    &profile.Actor,
+		envTenantID: &profile.TenantID,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*field = v
+		}
+	}
+	if profile.Address == "" {
+		return Profile{}, fmt.Errorf("profile %q has no address", name)
+	}
+	return profile, nil
+}
+
+// dial connects to the profile's server.
+func (p Profile) dial() (*client.Client, error) {
+	opts := []client.Option{client.WithToken(p.Token), client.WithActor(p.Actor)}
+	switch {
+	case p.Insecure:
+		opts = append(opts, client.WithInsecure())
+	case p.CAFile != "":
+		pem, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", p.CAFile)
+		}
+		opts = append(opts, client.WithTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots}))
+	}
+	return client.New(p.Address, opts...)
+}
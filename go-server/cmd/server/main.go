@@ -2,70 +2,103 @@ package main
 
 import (
 	"context"
-	"embed"
+	"flag"
+	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/pressly/goose/v3"
-
 	"github.com/iBoBoTi/connector-service/config"
 	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/go-server/cmd/server/migrations"
+	"github.com/iBoBoTi/connector-service/internal/domain"
 	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/scheduler"
 	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/internal/transport/gateway"
 	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/internal/transport/web"
 	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/awssession"
 	"github.com/iBoBoTi/connector-service/pkg/db"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
-//go:embed migrations/*.sql
-var embedMigrations embed.FS
+// configPollInterval is how often the config file is checked for changes.
+const configPollInterval = 5 * time.Second
 
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file; environment variables override it")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
 	// Load configuration
-	cfg := config.LoadConfig()
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+	if *printConfig {
+		if err := cfg.Redacted().WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
+	logLevel := new(slog.LevelVar)
+	level, _ := cfg.Log.SlogLevel()
+	logLevel.Set(level)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: true,
-		Level:     slog.LevelInfo,
+		Level:     logLevel,
 	}))
 	slog.SetDefault(logger)
 
+	if args := flag.Args(); len(args) > 0 {
+		if args[0] != "migrate" {
+			fmt.Fprintf(os.Stderr, "unknown command %q; the only command is migrate\n", args[0])
+			os.Exit(2)
+		}
+		if err := runMigrate(ctx, cfg.DB, args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	slog.Info("Starting application")
 
 	// Initialize DB connection
-	dbConn, err := db.NewPostgresDB(cfg.DB)
+	dbConn, err := db.NewPostgresDB(ctx, cfg.DB)
 	if err != nil {
 		slog.Error("Failed to open DB", "error", err)
 		os.Exit(1)
 	}
 	defer dbConn.Close()
 
-	goose.SetBaseFS(embedMigrations)
-	if err := goose.Up(dbConn, "migrations"); err != nil {
-		slog.Error("Failed to apply migrations", "error", err)
-		os.Exit(1)
+	if cfg.DB.AutoMigrate {
+		if err := autoMigrate(ctx, cfg.DB); err != nil {
+			slog.Error("Failed to apply migrations", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Migrations applied successfully")
+	} else {
+		slog.Info("Automatic migrations are disabled")
 	}
-	slog.Info("Migrations applied successfully")
 
-	awsConfig := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials("test", "test", ""),
-		Region:           aws.String(cfg.AWS.Region),
-		Endpoint:         aws.String(cfg.AWS.Endpoint),
-		S3ForcePathStyle: aws.Bool(true),
-	}
-	sess, err := session.NewSession(awsConfig)
+	sess, err := awssession.New(cfg.AWS)
 	if err != nil {
 		slog.Error("Failed to create AWS session", "error", err)
 		os.Exit(1)
@@ -73,13 +106,106 @@ func main() {
 
 	// Setup repository, clients, and usecase
 	connRepo := repository.NewConnectorRepository(dbConn)
-	secretsClient := services.NewSecretsManager(sess)
-	slackClient := services.NewSlackClient()
-	connUsecase := usecase.NewConnectorUsecase(connRepo, secretsClient, slackClient)
-	connHandler := handler.NewSlackConnectorHandler(connUsecase)
+	messageRepo := repository.NewMessageRepository(dbConn)
+	templateRepo := repository.NewTemplateRepository(dbConn)
+	scheduleRepo := repository.NewScheduledMessageRepository(dbConn)
+	routingRepo := repository.NewRoutingRuleRepository(dbConn)
+	aggregationRepo := repository.NewAggregationRepository(dbConn)
+	eventRepo := repository.NewEventRepository(dbConn)
+	webhookRepo := repository.NewWebhookRepository(dbConn)
+	quotaRepo := repository.NewQuotaRepository(dbConn)
+	defaultQuota := usecase.NewQuotaDefaults(tenantQuota(cfg.Quota))
+	var auditExport io.Writer
+	if cfg.Audit.ExportPath != "" {
+		f, err := os.OpenFile(cfg.Audit.ExportPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			slog.Error("Failed to open audit export file", "path", cfg.Audit.ExportPath, "error", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		auditExport = f
+	}
+	auditUsecase := usecase.NewAuditUsecase(repository.NewAuditRepository(dbConn), auditExport)
+
+	secretsClient := services.NewSecretsManager(sess,
+		services.WithSecretsManagerEndpoint(cfg.AWS.Endpoint),
+		services.WithKMSKeyID(cfg.AWS.KMSKeyID),
+	)
+	slackClient := services.NewSlackClient(services.WithSlackAPIURL(cfg.Slack.APIURL))
+	connUsecase := usecase.NewConnectorUsecase(connRepo, secretsClient, slackClient,
+		usecase.WithMessenger(domain.ProviderMicrosoftTeams, services.NewTeamsClient()),
+		usecase.WithMessenger(domain.ProviderDiscord, services.NewDiscordClient()),
+		usecase.WithMessenger(domain.ProviderMattermost, services.NewMattermostClient()),
+		usecase.WithMessenger(domain.ProviderSMTP, services.NewSMTPClient()),
+		usecase.WithMessenger(domain.ProviderWebhook, services.NewWebhookClient()),
+		usecase.WithMessageLog(messageRepo),
+		usecase.WithTemplates(templateRepo),
+		usecase.WithDeduplication(repository.NewDedupRepository(dbConn), cfg.Delivery.DedupWindow),
+		usecase.WithAggregation(aggregationRepo, cfg.Delivery.AggregationWindow),
+		usecase.WithDeferrals(scheduleRepo),
+		usecase.WithEvents(eventRepo),
+		usecase.WithAuditLog(auditUsecase),
+		usecase.WithQuotas(quotaRepo, defaultQuota),
+		usecase.WithRetryPolicy(usecase.RetryPolicy{
+			MaxAttempts: cfg.Delivery.MaxAttempts,
+			BaseDelay:   cfg.Delivery.RetryBaseDelay,
+			MaxDelay:    cfg.Delivery.RetryMaxDelay,
+		}),
+	)
+	templateUsecase := usecase.NewTemplateUsecase(templateRepo)
+	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, connRepo, connUsecase)
+	routingUsecase := usecase.NewRoutingUsecase(routingRepo, connRepo, connUsecase)
+	aggregationUsecase := usecase.NewAggregationUsecase(aggregationRepo, connUsecase)
+	batchUsecase := usecase.NewBatchUsecase(connRepo, connUsecase, usecase.BatchOptions{
+		MaxItems:       cfg.Batch.MaxItems,
+		Parallelism:    cfg.Batch.Parallelism,
+		WorkspaceRate:  cfg.Batch.WorkspaceRate,
+		WorkspaceBurst: cfg.Batch.WorkspaceBurst,
+	})
+	eventBroker := services.NewEventBroker(cfg.Events.Buffer)
+	eventUsecase := usecase.NewEventUsecase(eventRepo, eventBroker)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, secretsClient, services.NewCallbackClient(), usecase.RetryPolicy{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+		MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+	})
+	// Subscriptions created by older versions still sign with the secret in
+	// their row until it is moved
+	if err := webhookUsecase.MoveSigningSecrets(ctx); err != nil {
+		slog.Error("Failed to move webhook signing secrets to the secret store", "error", err)
+	}
+	connHandler := handler.NewSlackConnectorHandler(connUsecase,
+		handler.WithTemplateUsecase(templateUsecase),
+		handler.WithScheduleUsecase(scheduleUsecase),
+		handler.WithRoutingUsecase(routingUsecase),
+		handler.WithBatchUsecase(batchUsecase),
+		handler.WithEventUsecase(eventUsecase),
+		handler.WithWebhookUsecase(webhookUsecase),
+		handler.WithAuditUsecase(auditUsecase),
+		handler.WithQuotaUsecase(usecase.NewQuotaUsecase(quotaRepo, defaultQuota)),
+	)
+
+	// Apply the settings that can change without a restart when the
+	// configuration is reloaded
+	go config.Watch(ctx, *configPath, configPollInterval, func(next *config.Config) {
+		if keys := cfg.RestartRequired(next); len(keys) > 0 {
+			slog.Warn("Configuration changes take effect after a restart", "settings", keys)
+		}
+		level, _ := next.Log.SlogLevel()
+		logLevel.Set(level)
+		batchUsecase.SetWorkspaceRate(next.Batch.WorkspaceRate, next.Batch.WorkspaceBurst)
+		defaultQuota.Set(tenantQuota(next.Quota))
+		slog.Info("Configuration reloaded", "log_level", level.String())
+	})
+
+	go services.ListenForEvents(ctx, db.DSN(db.WithoutStatementTimeout(cfg.DB)), eventRepo, eventBroker)
 
 	// Create and register gRPC server
-	grpcServer := grpc.NewServer()
+	trustedProxies, _ := cfg.Audit.TrustedProxyPrefixes()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.UnaryRequestMetadataInterceptor(trustedProxies)),
+		grpc.ChainStreamInterceptor(handler.StreamRequestMetadataInterceptor(trustedProxies)),
+	)
 	connector_v1.RegisterSlackConnectorServiceServer(grpcServer, connHandler)
 	reflection.Register(grpcServer)
 
@@ -99,11 +225,121 @@ func main() {
 		}
 	}()
 
+	// Serve the REST gateway, Connect and gRPC-Web, calling the gRPC server over loopback
+	var httpServer *http.Server
+	if cfg.HTTPServer.Enabled {
+		loopbackConn, err := grpc.NewClient("localhost:"+grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			slog.Error("Failed to connect the HTTP server to the gRPC server", "error", err)
+			os.Exit(1)
+		}
+		defer loopbackConn.Close()
+
+		gatewayHandler, err := gateway.NewHandler(ctx, loopbackConn)
+		if err != nil {
+			slog.Error("Failed to register the REST gateway", "error", err)
+			os.Exit(1)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/", gatewayHandler)
+		mux.Handle(web.NewHandler(loopbackConn))
+
+		httpServer = &http.Server{
+			Addr:              ":" + cfg.HTTPServer.Port,
+			Handler:           web.WithCORS(mux, cfg.CORS.AllowedOrigins, cfg.CORS.MaxAge),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			slog.Info("HTTP server is running", "port", cfg.HTTPServer.Port)
+			if serveErr := httpServer.ListenAndServe(); serveErr != nil && serveErr != http.ErrServerClosed {
+				slog.Error("HTTP server encountered an error", "error", serveErr)
+				stop()
+			}
+		}()
+	}
+
+	if cfg.Scheduler.Enabled {
+		go scheduler.Run(ctx, cfg.Scheduler.Interval,
+			scheduler.JobFunc{JobName: "dispatch-scheduled-messages", Fn: func(ctx context.Context, now time.Time) error {
+				_, err := scheduleUsecase.DispatchDue(ctx, now)
+				return err
+			}},
+			scheduler.JobFunc{JobName: "flush-message-aggregates", Fn: func(ctx context.Context, now time.Time) error {
+				_, err := aggregationUsecase.FlushDue(ctx, now)
+				return err
+			}},
+			scheduler.JobFunc{JobName: "deliver-webhooks", Fn: func(ctx context.Context, now time.Time) error {
+				_, err := webhookUsecase.DeliverDue(ctx, now)
+				return err
+			}},
+			scheduler.JobFunc{JobName: "prune-quota-usage", Fn: func(ctx context.Context, now time.Time) error {
+				_, err := quotaRepo.DeleteUsageBefore(ctx, domain.QuotaWindowDay.Start(now).AddDate(0, 0, -1))
+				return err
+			}},
+			scheduler.JobFunc{JobName: "prune-connector-events", Fn: func(ctx context.Context, now time.Time) error {
+				_, err := eventRepo.DeleteBefore(ctx, now.Add(-cfg.Events.Retention))
+				return err
+			}},
+		)
+	}
+
 	<-ctx.Done()
 
 	slog.Info("Shutting down gracefully...")
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Failed to shut down the HTTP server", "error", err)
+		}
+		cancel()
+	}
 	grpcServer.GracefulStop()
 
 	time.Sleep(1 * time.Second)
 	slog.Info("Server stopped. Goodbye.")
 }
+
+// autoMigrate applies the pending migrations at startup. Like the migrate
+// subcommand it uses its own connection without a statement timeout, so that
+// a long migration is not cancelled halfway.
+func autoMigrate(ctx context.Context, cfg config.DBConfig) error {
+	dbConn, err := db.NewPostgresDB(ctx, db.WithoutStatementTimeout(cfg))
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	migrator, err := migrations.NewProvider(dbConn)
+	if err != nil {
+		return fmt.Errorf("loading migrations: %w", err)
+	}
+	results, err := migrator.Up(ctx)
+	for _, res := range results {
+		slog.Info("Applied migration", "migration", filepath.Base(res.Source.Path), "duration", res.Duration.String())
+	}
+	return err
+}
+
+// runMigrate runs the migrate subcommand against the configured database,
+// without a statement timeout.
+func runMigrate(ctx context.Context, cfg config.DBConfig, args []string) error {
+	cmd, err := migrations.ParseCommand(args)
+	if err != nil {
+		return err
+	}
+	dbConn, err := db.NewPostgresDB(ctx, db.WithoutStatementTimeout(cfg))
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+	return cmd.Run(ctx, dbConn, os.Stdout)
+}
+
+func tenantQuota(cfg config.QuotaConfig) domain.TenantQuota {
+	return domain.TenantQuota{
+		MaxConnectors:     cfg.MaxConnectors,
+		MessagesPerMinute: cfg.MessagesPerMinute,
+		MessagesPerDay:    cfg.MessagesPerDay,
+	}
+}
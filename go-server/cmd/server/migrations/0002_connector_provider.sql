@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE connectors ADD COLUMN IF NOT EXISTS provider TEXT NOT NULL DEFAULT 'slack';
+
+-- +goose Down
+ALTER TABLE connectors DROP COLUMN IF EXISTS provider;
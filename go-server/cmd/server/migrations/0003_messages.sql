@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS messages (
+    id TEXT PRIMARY KEY,
+    connector_id TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS messages_connector_created_idx ON messages (connector_id, created_at);
+CREATE INDEX IF NOT EXISTS messages_dead_lettered_idx ON messages (connector_id) WHERE status = 'dead_lettered';
+
+-- +goose Down
+DROP TABLE IF EXISTS messages;
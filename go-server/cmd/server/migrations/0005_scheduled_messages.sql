@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    connector_id TEXT NOT NULL,
+    text TEXT NOT NULL DEFAULT '',
+    template_id TEXT NOT NULL DEFAULT '',
+    variables JSONB NOT NULL DEFAULT '{}',
+    cron_expr TEXT NOT NULL DEFAULT '',
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    status TEXT NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_messages_due_idx ON scheduled_messages (next_run_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS scheduled_messages_tenant_idx ON scheduled_messages (tenant_id, connector_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS scheduled_messages;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS routing_rules (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    min_severity TEXT NOT NULL DEFAULT '',
+    match_labels JSONB NOT NULL DEFAULT '{}',
+    destinations JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (tenant_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS routing_rules;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS message_dedup (
+    connector_id TEXT NOT NULL,
+    dedup_key TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    suppressed INTEGER NOT NULL DEFAULT 0,
+    first_seen TIMESTAMP NOT NULL,
+    last_seen TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (connector_id, dedup_key)
+);
+
+CREATE TABLE IF NOT EXISTS message_aggregates (
+    id TEXT PRIMARY KEY,
+    connector_id TEXT NOT NULL,
+    channel TEXT NOT NULL DEFAULT '',
+    group_key TEXT NOT NULL,
+    first_text TEXT NOT NULL,
+    last_text TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    first_seen TIMESTAMP NOT NULL,
+    last_seen TIMESTAMP NOT NULL,
+    flush_at TIMESTAMP NOT NULL,
+    flushed_at TIMESTAMP,
+    message_id TEXT NOT NULL DEFAULT '',
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+-- At most one open aggregate per group; flushed ones are kept as history.
+CREATE UNIQUE INDEX IF NOT EXISTS message_aggregates_open_idx
+    ON message_aggregates (connector_id, channel, group_key) WHERE flushed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS message_aggregates_flush_idx
+    ON message_aggregates (flush_at) WHERE flushed_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS message_aggregates;
+DROP TABLE IF EXISTS message_dedup;
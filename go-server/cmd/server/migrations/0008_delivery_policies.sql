@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE connectors ADD COLUMN IF NOT EXISTS delivery_policy JSONB;
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS policy_action TEXT NOT NULL DEFAULT '';
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS policy_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS deferred_until TIMESTAMP;
+
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS channel TEXT NOT NULL DEFAULT '';
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS severity TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS severity;
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS channel;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS deferred_until;
+ALTER TABLE messages DROP COLUMN IF EXISTS policy_reason;
+ALTER TABLE messages DROP COLUMN IF EXISTS policy_action;
+
+ALTER TABLE connectors DROP COLUMN IF EXISTS delivery_policy;
@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS connector_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    connector_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS connector_events_tenant_idx ON connector_events (tenant_id, id);
+CREATE INDEX IF NOT EXISTS connector_events_connector_idx ON connector_events (connector_id, id);
+CREATE INDEX IF NOT EXISTS connector_events_created_idx ON connector_events (created_at);
+
+-- Every replica LISTENs on connector_events, so an event written by one is
+-- streamed by all of them.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_connector_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('connector_events', row_to_json(NEW)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER connector_events_notify
+    AFTER INSERT ON connector_events
+    FOR EACH ROW EXECUTE FUNCTION notify_connector_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS connector_events_notify ON connector_events;
+DROP FUNCTION IF EXISTS notify_connector_event();
+DROP TABLE IF EXISTS connector_events;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenant_quotas (
+    tenant_id TEXT PRIMARY KEY,
+    max_connectors INT NOT NULL DEFAULT 0,
+    messages_per_minute INT NOT NULL DEFAULT 0,
+    messages_per_day INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Message counts per tenant and window, incremented as messages are sent.
+CREATE TABLE IF NOT EXISTS tenant_message_usage (
+    tenant_id TEXT NOT NULL,
+    window_kind TEXT NOT NULL,
+    window_start TIMESTAMP NOT NULL,
+    count INT NOT NULL,
+    PRIMARY KEY (tenant_id, window_kind, window_start)
+);
+
+CREATE INDEX IF NOT EXISTS connectors_tenant_idx ON connectors (tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS connectors_tenant_idx;
+DROP TABLE IF EXISTS tenant_message_usage;
+DROP TABLE IF EXISTS tenant_quotas;
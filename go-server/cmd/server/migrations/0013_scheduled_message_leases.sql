@@ -0,0 +1,6 @@
+-- +goose Up
+-- Set while a replica dispatches the schedule; past values are free to claim.
+ALTER TABLE scheduled_messages ADD COLUMN IF NOT EXISTS leased_until TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS leased_until;
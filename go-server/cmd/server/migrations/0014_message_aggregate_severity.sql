@@ -0,0 +1,6 @@
+-- +goose Up
+-- The highest severity of the messages collected so far, posted with the digest.
+ALTER TABLE message_aggregates ADD COLUMN IF NOT EXISTS severity TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE message_aggregates DROP COLUMN IF EXISTS severity;
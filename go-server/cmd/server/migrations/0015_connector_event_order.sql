@@ -0,0 +1,43 @@
+-- +goose Up
+-- Watchers and webhook dispatch resume after the last event ID they saw, so IDs
+-- must become visible in order: an ID taken by a transaction that commits after
+-- a higher one would be skipped. Inserts take a transaction-level lock before
+-- drawing their ID, which makes IDs commit in order. Events are appended in
+-- their own short transactions, so the lock is held only briefly.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION order_connector_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('connector_events'));
+    NEW.id := nextval(pg_get_serial_sequence('connector_events', 'id'));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER connector_events_order
+    BEFORE INSERT ON connector_events
+    FOR EACH ROW EXECUTE FUNCTION order_connector_event();
+
+-- Notifications carry only the event ID, since payloads are limited to 8000
+-- bytes; listeners read the event itself.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_connector_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('connector_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_connector_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('connector_events', row_to_json(NEW)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS connector_events_order ON connector_events;
+DROP FUNCTION IF EXISTS order_connector_event();
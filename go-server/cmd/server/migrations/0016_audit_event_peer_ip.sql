@@ -0,0 +1,7 @@
+-- +goose Up
+-- The address the request arrived from, next to the client address reported
+-- by trusted proxies in source_ip.
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS peer_ip TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE audit_events DROP COLUMN IF EXISTS peer_ip;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Set while a replica posts the digest. A leased aggregate stops collecting
+-- messages: the next message for its group opens a new aggregate.
+ALTER TABLE message_aggregates ADD COLUMN IF NOT EXISTS leased_until TIMESTAMP;
+
+DROP INDEX IF EXISTS message_aggregates_open_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS message_aggregates_open_idx
+    ON message_aggregates (connector_id, channel, group_key) WHERE flushed_at IS NULL AND leased_until IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS message_aggregates_open_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS message_aggregates_open_idx
+    ON message_aggregates (connector_id, channel, group_key) WHERE flushed_at IS NULL;
+
+ALTER TABLE message_aggregates DROP COLUMN IF EXISTS leased_until;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Signing secrets are kept in the secret store under signing_secret_ref.
+-- signing_secret only holds the secrets of subscriptions created before, until
+-- the service moves them to the store on startup.
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS signing_secret_ref TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhook_subscriptions ALTER COLUMN signing_secret SET DEFAULT '';
+
+-- +goose Down
+-- Secrets already moved to the store are not copied back.
+ALTER TABLE webhook_subscriptions ALTER COLUMN signing_secret DROP DEFAULT;
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS signing_secret_ref;
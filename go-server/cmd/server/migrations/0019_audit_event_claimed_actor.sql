@@ -0,0 +1,7 @@
+-- +goose Up
+-- The actor is whatever the client sent in x-actor; requests are not
+-- authenticated, so it is a claim rather than a verified identity.
+ALTER TABLE audit_events RENAME COLUMN actor TO claimed_actor;
+
+-- +goose Down
+ALTER TABLE audit_events RENAME COLUMN claimed_actor TO actor;
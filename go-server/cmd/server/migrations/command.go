@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// Usage describes the commands accepted by Run.
+const Usage = `usage: migrate <command>
+
+commands:
+  up                  apply every pending migration
+  down                roll back the latest migration
+  status              list the migrations and whether they are applied
+  redo                roll back the latest migration and apply it again
+  to-version VERSION  migrate up or down to VERSION (0 rolls back everything)`
+
+// NewProvider returns a goose provider for the migrations in FS. Each
+// operation holds a Postgres advisory lock, waiting up to five minutes for
+// it, so replicas migrating at once take turns instead of racing.
+func NewProvider(db *sql.DB) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker(lock.WithLockTimeout(1, 300))
+	if err != nil {
+		return nil, err
+	}
+	return goose.NewProvider(goose.DialectPostgres, db, FS, goose.WithSessionLocker(locker))
+}
+
+// Command is a parsed migrate command.
+type Command struct {
+	name   string
+	target int64
+}
+
+// ParseCommand parses the arguments of the migrate command, described in Usage.
+func ParseCommand(args []string) (Command, error) {
+	if len(args) == 0 {
+		return Command{}, errors.New(Usage)
+	}
+	c := Command{name: args[0]}
+	args = args[1:]
+	switch c.name {
+	case "up", "down", "status", "redo":
+		if len(args) > 0 {
+			return Command{}, fmt.Errorf("%s takes no arguments\n\n%s", c.name, Usage)
+		}
+	case "to-version":
+		if len(args) != 1 {
+			return Command{}, fmt.Errorf("to-version takes one VERSION\n\n%s", Usage)
+		}
+		v, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || v < 0 {
+			return Command{}, fmt.Errorf("invalid version %q", args[0])
+		}
+		c.target = v
+	default:
+		return Command{}, fmt.Errorf("unknown migrate command %q\n\n%s", c.name, Usage)
+	}
+	return c, nil
+}
+
+// Run runs the command against db, writing what it did to out.
+func (c Command) Run(ctx context.Context, db *sql.DB, out io.Writer) error {
+	p, err := NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	var results []*goose.MigrationResult
+	switch c.name {
+	case "up":
+		results, err = p.Up(ctx)
+	case "down":
+		var res *goose.MigrationResult
+		if res, err = p.Down(ctx); res != nil {
+			results = append(results, res)
+		}
+	case "redo":
+		// The two steps take the lock separately; redo is meant for
+		// development databases, not ones other replicas migrate.
+		var res *goose.MigrationResult
+		if res, err = p.Down(ctx); res != nil {
+			results = append(results, res)
+		}
+		if err == nil {
+			if res, err = p.UpByOne(ctx); res != nil {
+				results = append(results, res)
+			}
+		}
+	case "to-version":
+		results, err = toVersion(ctx, p, c.target)
+	case "status":
+		return printStatus(ctx, p, out)
+	}
+
+	for _, res := range results {
+		if res.Error == nil {
+			fmt.Fprintln(out, res)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	version, err := p.GetDBVersion(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "database version: %d\n", version)
+	return nil
+}
+
+func toVersion(ctx context.Context, p *goose.Provider, target int64) ([]*goose.MigrationResult, error) {
+	if target != 0 && !slices.ContainsFunc(p.ListSources(), func(s *goose.Source) bool { return s.Version == target }) {
+		return nil, fmt.Errorf("version %d: %w", target, goose.ErrVersionNotFound)
+	}
+	current, err := p.GetDBVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case target > current:
+		return p.UpTo(ctx, target)
+	case target < current:
+		return p.DownTo(ctx, target)
+	}
+	return nil, nil
+}
+
+func printStatus(ctx context.Context, p *goose.Provider, out io.Writer) error {
+	statuses, err := p.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tFILE")
+	for _, s := range statuses {
+		appliedAt := "-"
+		if !s.AppliedAt.IsZero() {
+			appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Source.Version, s.State, appliedAt, filepath.Base(s.Source.Path))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,28 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/go-server/cmd/server/migrations"
+)
+
+func TestParseCommand(t *testing.T) {
+	for _, args := range [][]string{{"up"}, {"down"}, {"status"}, {"redo"}, {"to-version", "0"}, {"to-version", "12"}} {
+		_, err := migrations.ParseCommand(args)
+		require.NoError(t, err, "%v", args)
+	}
+
+	for want, args := range map[string][]string{
+		"usage: migrate":                     {},
+		`unknown migrate command "sideways"`: {"sideways"},
+		"up takes no arguments":              {"up", "3"},
+		"to-version takes one VERSION":       {"to-version"},
+		`invalid version "-1"`:               {"to-version", "-1"},
+		`invalid version "latest"`:           {"to-version", "latest"},
+	} {
+		_, err := migrations.ParseCommand(args)
+		require.ErrorContains(t, err, want)
+	}
+}
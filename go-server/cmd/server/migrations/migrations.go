@@ -0,0 +1,10 @@
+// Package migrations embeds the goose migrations of the connector service's
+// Postgres schema.
+package migrations
+
+import "embed"
+
+// FS holds the migrations at its root.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,195 @@
+// Package connectortest provides in-memory and fake implementations of the
+// connector service's dependencies for tests: a ConnectorRepository, a secret
+// store, a fake Slack Web API server the real Slack client can be pointed at
+// with services.WithSlackAPIURL, and a Secrets Manager stand-in for the real
+// AWS client.
+//
+//	slack := connectortest.NewSlackServer()
+//	defer slack.Close()
+//	general := slack.AddChannel("general")
+//
+//	uc := usecase.NewConnectorUsecase(
+//		connectortest.NewConnectorRepository(),
+//		connectortest.NewSecretStore(),
+//		services.NewSlackClient(services.WithSlackAPIURL(slack.URL())),
+//	)
+package connectortest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/services"
+)
+
+var (
+	_ repository.ConnectorRepository = (*ConnectorRepository)(nil)
+	_ services.AWSSecretsManager     = (*SecretStore)(nil)
+)
+
+// ConnectorRepository is an in-memory repository.ConnectorRepository that
+// behaves like the Postgres one, returning sql.ErrNoRows for unknown IDs.
+type ConnectorRepository struct {
+	mu         sync.Mutex
+	connectors map[string]domain.Connector
+}
+
+func NewConnectorRepository() *ConnectorRepository {
+	return &ConnectorRepository{connectors: make(map[string]domain.Connector)}
+}
+
+func (r *ConnectorRepository) Create(_ context.Context, c *domain.Connector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.connectors[c.ID]; ok {
+		return fmt.Errorf("connector %s already exists", c.ID)
+	}
+	r.connectors[c.ID] = copyConnector(c)
+	return nil
+}
+
+func (r *ConnectorRepository) GetByID(_ context.Context, id string) (*domain.Connector, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.connectors[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	out := copyConnector(&c)
+	return &out, nil
+}
+
+func (r *ConnectorRepository) ListByTenant(_ context.Context, tenantID, afterID string, limit int) ([]*domain.Connector, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var connectors []*domain.Connector
+	for _, c := range r.connectors {
+		if c.TenantID == tenantID && c.ID > afterID {
+			out := copyConnector(&c)
+			connectors = append(connectors, &out)
+		}
+	}
+	sort.Slice(connectors, func(i, j int) bool { return connectors[i].ID < connectors[j].ID })
+	if len(connectors) > limit {
+		connectors = connectors[:limit]
+	}
+	return connectors, nil
+}
+
+func (r *ConnectorRepository) UpdateDeliveryPolicy(_ context.Context, id string, policy *domain.DeliveryPolicy, updatedAt time.Time) error {
+	return r.update(id, func(c *domain.Connector) {
+		c.DeliveryPolicy = copyPolicy(policy)
+		c.UpdatedAt = updatedAt
+	})
+}
+
+func (r *ConnectorRepository) UpdateDefaultChannel(_ context.Context, id, channelID string, updatedAt time.Time) error {
+	return r.update(id, func(c *domain.Connector) {
+		c.DefaultChannelID = channelID
+		c.UpdatedAt = updatedAt
+	})
+}
+
+func (r *ConnectorRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.connectors, id)
+	return nil
+}
+
+// Connectors returns every stored connector ordered by ID.
+func (r *ConnectorRepository) Connectors() []*domain.Connector {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	connectors := make([]*domain.Connector, 0, len(r.connectors))
+	for _, c := range r.connectors {
+		out := copyConnector(&c)
+		connectors = append(connectors, &out)
+	}
+	sort.Slice(connectors, func(i, j int) bool { return connectors[i].ID < connectors[j].ID })
+	return connectors
+}
+
+func (r *ConnectorRepository) update(id string, fn func(c *domain.Connector)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.connectors[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	fn(&c)
+	r.connectors[id] = c
+	return nil
+}
+
+// copyConnector keeps callers from changing stored connectors through the
+// pointers they pass in or get back, as they could not with Postgres.
+func copyConnector(c *domain.Connector) domain.Connector {
+	out := *c
+	out.DeliveryPolicy = copyPolicy(c.DeliveryPolicy)
+	return out
+}
+
+func copyPolicy(p *domain.DeliveryPolicy) *domain.DeliveryPolicy {
+	if p == nil {
+		return nil
+	}
+	out := *p
+	out.QuietHours = append([]domain.QuietHours(nil), p.QuietHours...)
+	return &out
+}
+
+// ErrSecretNotFound is returned by SecretStore for connectors without credentials.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore is an in-memory services.AWSSecretsManager.
+type SecretStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func NewSecretStore() *SecretStore {
+	return &SecretStore{secrets: make(map[string]string)}
+}
+
+func (s *SecretStore) StoreCredentials(_ context.Context, connectorID, credentials string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[connectorID] = credentials
+	return nil
+}
+
+func (s *SecretStore) GetCredentials(_ context.Context, connectorID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	credentials, ok := s.secrets[connectorID]
+	if !ok {
+		return "", fmt.Errorf("failed to retrieve secret: %w", ErrSecretNotFound)
+	}
+	return credentials, nil
+}
+
+func (s *SecretStore) DeleteCredentials(_ context.Context, connectorID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.secrets[connectorID]; !ok {
+		return fmt.Errorf("failed to delete secret: %w", ErrSecretNotFound)
+	}
+	delete(s.secrets, connectorID)
+	return nil
+}
+
+// Credentials returns the stored credentials of the connector.
+func (s *SecretStore) Credentials(connectorID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	credentials, ok := s.secrets[connectorID]
+	return credentials, ok
+}
@@ -0,0 +1,125 @@
+package connectortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretsManagerServer is a local stand-in for AWS Secrets Manager serving
+// CreateSecret, UpdateSecret, GetSecretValue and DeleteSecret over the AWS
+// JSON protocol, so that the real client can be tested without AWS or
+// LocalStack. Requests are not authenticated and KMS keys are only recorded.
+type SecretsManagerServer struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	secrets map[string]string
+	kmsKeys map[string]string
+	calls   map[string]int
+}
+
+// NewSecretsManagerServer starts a Secrets Manager stand-in. Close it when done.
+func NewSecretsManagerServer() *SecretsManagerServer {
+	s := &SecretsManagerServer{secrets: make(map[string]string), kmsKeys: make(map[string]string), calls: make(map[string]int)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL is the endpoint to configure the AWS session with.
+func (s *SecretsManagerServer) URL() string {
+	return s.server.URL
+}
+
+func (s *SecretsManagerServer) Close() {
+	s.server.Close()
+}
+
+// Secret returns the secret string stored under name.
+func (s *SecretsManagerServer) Secret(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.secrets[name]
+	return v, ok
+}
+
+// KMSKeyID returns the KMS key the secret stored under name was last created or
+// updated with, empty for the AWS managed key.
+func (s *SecretsManagerServer) KMSKeyID(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.kmsKeys[name]
+}
+
+// Calls returns how many times the operation, e.g. "GetSecretValue", was called.
+func (s *SecretsManagerServer) Calls(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[operation]
+}
+
+func (s *SecretsManagerServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	operation, _ := strings.CutPrefix(r.Header.Get("X-Amz-Target"), "secretsmanager.")
+	switch operation {
+	case "CreateSecret", "UpdateSecret", "GetSecretValue", "DeleteSecret":
+	default:
+		writeAWSError(w, http.StatusBadRequest, "UnknownOperationException", "unsupported operation "+operation)
+		return
+	}
+	var in struct {
+		Name         string
+		SecretId     string
+		SecretString string
+		KmsKeyId     string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeAWSError(w, http.StatusBadRequest, "SerializationException", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[operation]++
+
+	name := in.SecretId
+	if operation == "CreateSecret" {
+		name = in.Name
+	}
+	_, exists := s.secrets[name]
+	switch {
+	case operation == "CreateSecret" && exists:
+		writeAWSError(w, http.StatusBadRequest, "ResourceExistsException", fmt.Sprintf("the secret %s already exists", name))
+		return
+	case operation != "CreateSecret" && !exists:
+		writeAWSError(w, http.StatusBadRequest, "ResourceNotFoundException", "Secrets Manager can't find the specified secret")
+		return
+	}
+
+	out := map[string]any{"ARN": "arn:aws:secretsmanager:us-east-1:000000000000:secret:" + name, "Name": name}
+	switch operation {
+	case "CreateSecret", "UpdateSecret":
+		s.secrets[name] = in.SecretString
+		if in.KmsKeyId != "" || operation == "CreateSecret" {
+			s.kmsKeys[name] = in.KmsKeyId
+		}
+		out["VersionId"] = fmt.Sprintf("v%d", s.calls["CreateSecret"]+s.calls["UpdateSecret"])
+	case "GetSecretValue":
+		out["SecretString"] = s.secrets[name]
+	case "DeleteSecret":
+		delete(s.secrets, name)
+		delete(s.kmsKeys, name)
+		out["DeletionDate"] = float64(time.Now().Unix())
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func writeAWSError(w http.ResponseWriter, status int, errType, message string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": errType, "message": message})
+}
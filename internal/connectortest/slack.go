@@ -0,0 +1,324 @@
+package connectortest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Team and bot user the fake Slack server authenticates every token as.
+const (
+	SlackTeamID    = "T00000001"
+	SlackBotUserID = "U00000001"
+)
+
+// SlackChannel is a channel of the fake Slack workspace.
+type SlackChannel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsChannel  bool   `json:"is_channel"`
+	IsPrivate  bool   `json:"is_private"`
+	IsArchived bool   `json:"is_archived"`
+}
+
+// SlackUser is a user of the fake Slack workspace.
+type SlackUser struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+	Name   string `json:"name"`
+	IsBot  bool   `json:"is_bot"`
+}
+
+// SlackMessage is a message posted to the fake Slack server.
+type SlackMessage struct {
+	Token   string
+	Channel string
+	Text    string
+	// ThreadTS is set for replies, e.g. the chunks after the first of a long message.
+	ThreadTS string
+	TS       string
+}
+
+// SlackServer is a fake Slack Web API serving auth.test, conversations.list,
+// conversations.info, chat.postMessage, users.list and users.info. Every token
+// is valid unless revoked, and posted messages are recorded.
+type SlackServer struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	channels   []*SlackChannel
+	users      []*SlackUser
+	revoked    map[string]bool
+	messages   []SlackMessage
+	rateLimits map[string]*rateLimit
+	failures   map[string][]string
+	calls      map[string]int
+	nextTS     int64
+}
+
+type rateLimit struct {
+	after      int
+	calls      int
+	retryAfter time.Duration
+}
+
+// NewSlackServer starts a fake Slack server. Close it when done.
+func NewSlackServer() *SlackServer {
+	s := &SlackServer{
+		users:      []*SlackUser{{ID: SlackBotUserID, TeamID: SlackTeamID, Name: "connector-bot", IsBot: true}},
+		revoked:    make(map[string]bool),
+		rateLimits: make(map[string]*rateLimit),
+		failures:   make(map[string][]string),
+		calls:      make(map[string]int),
+		nextTS:     1700000000,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL is the API base URL to pass to services.WithSlackAPIURL.
+func (s *SlackServer) URL() string {
+	return s.server.URL + "/api/"
+}
+
+func (s *SlackServer) Close() {
+	s.server.Close()
+}
+
+// AddChannel adds a public channel and returns its ID.
+func (s *SlackServer) AddChannel(name string) string {
+	return s.addChannel(name, false)
+}
+
+// AddPrivateChannel adds a private channel and returns its ID.
+func (s *SlackServer) AddPrivateChannel(name string) string {
+	return s.addChannel(name, true)
+}
+
+func (s *SlackServer) addChannel(name string, private bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("C%08d", len(s.channels)+1)
+	s.channels = append(s.channels, &SlackChannel{ID: id, Name: name, IsChannel: true, IsPrivate: private})
+	return id
+}
+
+// ArchiveChannel archives the channel: it is no longer listed with
+// exclude_archived and cannot be posted to.
+func (s *SlackServer) ArchiveChannel(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch := s.channel(id); ch != nil {
+		ch.IsArchived = true
+	}
+}
+
+// AddUser adds a user and returns its ID.
+func (s *SlackServer) AddUser(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("U%08d", len(s.users)+1)
+	s.users = append(s.users, &SlackUser{ID: id, TeamID: SlackTeamID, Name: name})
+	return id
+}
+
+// RevokeToken makes every later call with token fail with token_revoked.
+func (s *SlackServer) RevokeToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[token] = true
+}
+
+// RateLimit answers the next calls to method, e.g. "chat.postMessage", with
+// HTTP 429 and a Retry-After of retryAfter, rounded up to whole seconds.
+func (s *SlackServer) RateLimit(method string, calls int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimits[method] = &rateLimit{calls: calls, retryAfter: retryAfter}
+}
+
+// RateLimitAfter is RateLimit starting once method has served after more calls,
+// e.g. to fail the second chunk of a long message.
+func (s *SlackServer) RateLimitAfter(method string, after, calls int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimits[method] = &rateLimit{after: after, calls: calls, retryAfter: retryAfter}
+}
+
+// FailNext makes the next call to method fail with the Slack error code, e.g.
+// "not_in_channel". Repeated calls queue further failures.
+func (s *SlackServer) FailNext(method, slackError string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method] = append(s.failures[method], slackError)
+}
+
+// Messages returns the messages posted so far, in order.
+func (s *SlackServer) Messages() []SlackMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SlackMessage(nil), s.messages...)
+}
+
+// MessagesIn returns the messages posted to the channel ID so far, in order.
+func (s *SlackServer) MessagesIn(channelID string) []SlackMessage {
+	var messages []SlackMessage
+	for _, m := range s.Messages() {
+		if m.Channel == channelID {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// Calls returns how many times method was called, including failed calls.
+func (s *SlackServer) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *SlackServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/api/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+
+	if rl := s.rateLimits[method]; rl != nil && rl.after > 0 {
+		rl.after--
+	} else if rl != nil && rl.calls > 0 {
+		rl.calls--
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rl.retryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	if queued := s.failures[method]; len(queued) > 0 {
+		s.failures[method] = queued[1:]
+		writeSlackError(w, queued[0])
+		return
+	}
+
+	token := r.Form.Get("token")
+	if token == "" {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	switch {
+	case token == "":
+		writeSlackError(w, "not_authed")
+		return
+	case s.revoked[token]:
+		writeSlackError(w, "token_revoked")
+		return
+	}
+
+	switch method {
+	case "auth.test":
+		writeSlackOK(w, map[string]any{"url": "https://fake.slack.com/", "team": "fake", "user": "connector-bot", "team_id": SlackTeamID, "user_id": SlackBotUserID})
+	case "conversations.list":
+		s.listConversations(w, r)
+	case "conversations.info":
+		ch := s.channel(r.Form.Get("channel"))
+		if ch == nil {
+			writeSlackError(w, "channel_not_found")
+			return
+		}
+		writeSlackOK(w, map[string]any{"channel": ch})
+	case "chat.postMessage":
+		s.postMessage(w, r, token)
+	case "users.list":
+		writeSlackOK(w, map[string]any{"members": s.users, "response_metadata": map[string]string{"next_cursor": ""}})
+	case "users.info":
+		for _, u := range s.users {
+			if u.ID == r.Form.Get("user") {
+				writeSlackOK(w, map[string]any{"user": u})
+				return
+			}
+		}
+		writeSlackError(w, "user_not_found")
+	default:
+		writeSlackError(w, "unknown_method")
+	}
+}
+
+// listConversations pages through the channels; the cursor is the index of
+// the first channel of the page.
+func (s *SlackServer) listConversations(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.Form.Get("limit"))
+	if limit <= 0 {
+		limit = 100
+	}
+	start, _ := strconv.Atoi(r.Form.Get("cursor"))
+	excludeArchived := r.Form.Get("exclude_archived") == "true"
+
+	var page []*SlackChannel
+	next := ""
+	for i := start; i < len(s.channels); i++ {
+		if len(page) == limit {
+			next = strconv.Itoa(i)
+			break
+		}
+		if ch := s.channels[i]; !excludeArchived || !ch.IsArchived {
+			page = append(page, ch)
+		}
+	}
+	writeSlackOK(w, map[string]any{"channels": page, "response_metadata": map[string]string{"next_cursor": next}})
+}
+
+func (s *SlackServer) postMessage(w http.ResponseWriter, r *http.Request, token string) {
+	ch := s.channel(r.Form.Get("channel"))
+	switch {
+	case ch == nil:
+		writeSlackError(w, "channel_not_found")
+		return
+	case ch.IsArchived:
+		writeSlackError(w, "is_archived")
+		return
+	}
+
+	s.nextTS++
+	ts := fmt.Sprintf("%d.000100", s.nextTS)
+	s.messages = append(s.messages, SlackMessage{
+		Token:    token,
+		Channel:  ch.ID,
+		Text:     r.Form.Get("text"),
+		ThreadTS: r.Form.Get("thread_ts"),
+		TS:       ts,
+	})
+	writeSlackOK(w, map[string]any{"channel": ch.ID, "ts": ts})
+}
+
+// channel finds a channel by ID or name, as Slack does for chat.postMessage.
+func (s *SlackServer) channel(idOrName string) *SlackChannel {
+	for _, ch := range s.channels {
+		if ch.ID == idOrName || ch.Name == strings.TrimPrefix(idOrName, "#") {
+			return ch
+		}
+	}
+	return nil
+}
+
+func writeSlackOK(w http.ResponseWriter, body map[string]any) {
+	body["ok"] = true
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeSlackError(w http.ResponseWriter, slackError string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": slackError})
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+// MessageAggregate collects the messages sent to a connector channel with the
+// same group key until FlushAt, when a single digest is posted in their place.
+// Severity is the highest severity of the collected messages.
+type MessageAggregate struct {
+	ID          string
+	ConnectorID string
+	Channel     string
+	GroupKey    string
+	Severity    Severity
+	FirstText   string
+	LastText    string
+	Count       int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	FlushAt     time.Time
+	FlushedAt   *time.Time
+	MessageID   string
+	LastError   string
+}
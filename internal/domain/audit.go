@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// AuditAction names an audited operation.
+type AuditAction string
+
+const (
+	AuditConnectorCreate          AuditAction = "connector.create"
+	AuditConnectorDelete          AuditAction = "connector.delete"
+	AuditConnectorUpdate          AuditAction = "connector.update"
+	AuditConnectorSetPolicy       AuditAction = "connector.set_delivery_policy"
+	AuditConnectorCredentialsRead AuditAction = "connector.credentials.read"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditEvent records who performed an action on a connector, from where, and
+// whether it succeeded. Audit events are never updated or deleted.
+// ClaimedActor is the actor the client named; it is not verified.
+type AuditEvent struct {
+	ID           int64        `json:"id"`
+	TenantID     string       `json:"tenant_id"`
+	ClaimedActor string       `json:"claimed_actor"`
+	Action       AuditAction  `json:"action"`
+	ConnectorID  string       `json:"connector_id"`
+	RequestID    string       `json:"request_id"`
+	SourceIP     string       `json:"source_ip"`
+	PeerIP       string       `json:"peer_ip"`
+	Outcome      AuditOutcome `json:"outcome"`
+	Error        string       `json:"error,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// AuditFilter selects audit events. Empty fields match everything; BeforeID
+// pages backwards from the newest events.
+type AuditFilter struct {
+	TenantID    string
+	ConnectorID string
+	Action      AuditAction
+	BeforeID    int64
+}
@@ -4,11 +4,25 @@ import (
 	"time"
 )
 
+// Provider identifies the chat platform a connector posts to.
+type Provider string
+
+const (
+	ProviderSlack          Provider = "slack"
+	ProviderMicrosoftTeams Provider = "microsoft_teams"
+	ProviderDiscord        Provider = "discord"
+	ProviderMattermost     Provider = "mattermost"
+	ProviderSMTP           Provider = "smtp"
+	ProviderWebhook        Provider = "webhook"
+)
+
 type Connector struct {
 	ID               string
+	Provider         Provider
 	TenantID         string
 	WorkspaceID      string
 	DefaultChannelID string
+	DeliveryPolicy   *DeliveryPolicy
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
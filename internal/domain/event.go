@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+)
+
+// EventType names a connector lifecycle or delivery event.
+type EventType string
+
+const (
+	EventConnectorCreated       EventType = "connector.created"
+	EventConnectorUpdated       EventType = "connector.updated"
+	EventConnectorDeleted       EventType = "connector.deleted"
+	EventConnectorStatusChanged EventType = "connector.status_changed"
+	EventMessageDelivered       EventType = "message.delivered"
+	EventMessageFailed          EventType = "message.failed"
+)
+
+// Valid reports whether t is one of the known event types.
+func (t EventType) Valid() bool {
+	switch t {
+	case EventConnectorCreated, EventConnectorUpdated, EventConnectorDeleted,
+		EventConnectorStatusChanged, EventMessageDelivered, EventMessageFailed:
+		return true
+	}
+	return false
+}
+
+// ConnectorEvent is an entry in the event log. IDs increase monotonically, so
+// a watcher can resume after the last ID it received.
+type ConnectorEvent struct {
+	ID          int64             `json:"id"`
+	TenantID    string            `json:"tenant_id"`
+	ConnectorID string            `json:"connector_id"`
+	Type        EventType         `json:"type"`
+	Data        map[string]string `json:"data"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// EventFilter selects the events of a tenant, a connector, or both.
+type EventFilter struct {
+	TenantID    string
+	ConnectorID string
+}
+
+// Matches reports whether e passes the filter.
+func (f EventFilter) Matches(e *ConnectorEvent) bool {
+	if f.TenantID != "" && e.TenantID != f.TenantID {
+		return false
+	}
+	if f.ConnectorID != "" && e.ConnectorID != f.ConnectorID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+)
+
+// MessageStatus is the final outcome of a delivery attempt.
+type MessageStatus string
+
+const (
+	MessageStatusDelivered MessageStatus = "delivered"
+	// MessageStatusDeadLettered marks messages that failed after every retry.
+	MessageStatusDeadLettered MessageStatus = "dead_lettered"
+	// MessageStatusSuppressed marks sends dropped as duplicates within their dedup window.
+	MessageStatusSuppressed MessageStatus = "suppressed"
+	// MessageStatusAggregated marks sends collected into a digest posted later.
+	MessageStatusAggregated MessageStatus = "aggregated"
+	// MessageStatusDeferred marks messages held back by the connector's delivery policy.
+	MessageStatusDeferred MessageStatus = "deferred"
+	// MessageStatusDropped marks messages discarded by the connector's delivery policy.
+	MessageStatusDropped MessageStatus = "dropped"
+)
+
+// Message is an entry in the delivery log.
+type Message struct {
+	ID          string
+	ConnectorID string
+	TenantID    string
+	ChannelID   string
+	Text        string
+	Status      MessageStatus
+	Attempts    int
+	LastError   string
+	// Decision is the connector's delivery policy decision, when one applied.
+	Decision  *PolicyDecision
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PolicyAction is what a delivery policy does with a message.
+type PolicyAction string
+
+const (
+	PolicyActionDeliver PolicyAction = "deliver"
+	// PolicyActionDefer holds the message until the quiet hours or the hourly cap end.
+	PolicyActionDefer PolicyAction = "defer"
+	// PolicyActionDrop discards the message.
+	PolicyActionDrop PolicyAction = "drop"
+	// PolicyActionDowngrade delivers the message without @channel, @here or @everyone mentions.
+	PolicyActionDowngrade PolicyAction = "downgrade"
+)
+
+// QuietHours is a daily range of local wall-clock times ("22:00" to "07:00"),
+// which may cross midnight. Weekdays ("mon" ... "sun") restrict the days the
+// range starts on; empty means every day.
+type QuietHours struct {
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Weekdays []string `json:"weekdays,omitempty"`
+}
+
+// DeliveryPolicy controls when and how often a connector posts. Messages at or
+// above SeverityThreshold are always delivered. Others sent during QuietHours
+// are handled with QuietHoursAction, and those beyond MaxPerHour messages in
+// the current UTC hour with RateLimitAction.
+type DeliveryPolicy struct {
+	Timezone          string       `json:"timezone,omitempty"`
+	QuietHours        []QuietHours `json:"quiet_hours,omitempty"`
+	QuietHoursAction  PolicyAction `json:"quiet_hours_action,omitempty"`
+	SeverityThreshold Severity     `json:"severity_threshold,omitempty"`
+	MaxPerHour        int          `json:"max_per_hour,omitempty"`
+	RateLimitAction   PolicyAction `json:"rate_limit_action,omitempty"`
+}
+
+// PolicyDecision is the outcome of applying a delivery policy to a message.
+type PolicyDecision struct {
+	Action     PolicyAction
+	Reason     string
+	DeferUntil time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Validate reports the first invalid setting of the policy.
+func (p *DeliveryPolicy) Validate() error {
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("timezone: %w", err)
+	}
+	for i, q := range p.QuietHours {
+		if _, _, err := parseClock(q.Start); err != nil {
+			return fmt.Errorf("quiet_hours[%d].start: %w", i, err)
+		}
+		if _, _, err := parseClock(q.End); err != nil {
+			return fmt.Errorf("quiet_hours[%d].end: %w", i, err)
+		}
+		for _, d := range q.Weekdays {
+			if _, ok := weekdays[strings.ToLower(d)]; !ok {
+				return fmt.Errorf("quiet_hours[%d].weekdays: unknown weekday %q", i, d)
+			}
+		}
+	}
+	switch p.QuietHoursAction {
+	case "", PolicyActionDefer, PolicyActionDrop, PolicyActionDowngrade:
+	default:
+		return fmt.Errorf("quiet_hours_action: unsupported action %q", p.QuietHoursAction)
+	}
+	switch p.RateLimitAction {
+	case "", PolicyActionDefer, PolicyActionDrop:
+	default:
+		return fmt.Errorf("rate_limit_action: unsupported action %q", p.RateLimitAction)
+	}
+	if p.SeverityThreshold != "" && !p.SeverityThreshold.Valid() {
+		return fmt.Errorf("severity_threshold: unknown severity %q", p.SeverityThreshold)
+	}
+	if p.MaxPerHour < 0 {
+		return fmt.Errorf("max_per_hour: must not be negative")
+	}
+	return nil
+}
+
+// Evaluate decides what to do with a message of the given severity sent at
+// now, when sentThisHour messages were already delivered in the current clock
+// hour. A nil policy delivers everything.
+func (p *DeliveryPolicy) Evaluate(now time.Time, severity Severity, sentThisHour int) PolicyDecision {
+	if p == nil {
+		return PolicyDecision{Action: PolicyActionDeliver}
+	}
+	if p.SeverityThreshold != "" && severity.AtLeast(p.SeverityThreshold) {
+		return PolicyDecision{Action: PolicyActionDeliver, Reason: "severity at or above " + string(p.SeverityThreshold)}
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	if until, ok := p.quietUntil(now.In(loc)); ok {
+		action := p.QuietHoursAction
+		if action == "" {
+			action = PolicyActionDefer
+		}
+		return PolicyDecision{
+			Action:     action,
+			Reason:     "quiet hours until " + until.Format("15:04 MST"),
+			DeferUntil: until.UTC(),
+		}
+	}
+
+	if p.MaxPerHour > 0 && sentThisHour >= p.MaxPerHour {
+		action := p.RateLimitAction
+		if action == "" {
+			action = PolicyActionDefer
+		}
+		return PolicyDecision{
+			Action:     action,
+			Reason:     fmt.Sprintf("limit of %d messages per hour reached", p.MaxPerHour),
+			DeferUntil: now.UTC().Truncate(time.Hour).Add(time.Hour),
+		}
+	}
+	return PolicyDecision{Action: PolicyActionDeliver}
+}
+
+// quietUntil returns the end of the quiet hours now falls in. Ranges starting
+// the previous day are checked too, since they may cross midnight. Bounds are
+// wall-clock times on their day, so ranges keep their clock times across
+// daylight saving changes.
+func (p *DeliveryPolicy) quietUntil(now time.Time) (time.Time, bool) {
+	var until time.Time
+	for _, q := range p.QuietHours {
+		startHour, startMin, err := parseClock(q.Start)
+		if err != nil {
+			continue
+		}
+		endHour, endMin, err := parseClock(q.End)
+		if err != nil {
+			continue
+		}
+		for _, offset := range []int{-1, 0} {
+			day := now.AddDate(0, 0, offset)
+			if len(q.Weekdays) > 0 && !slices.ContainsFunc(q.Weekdays, func(d string) bool {
+				return weekdays[strings.ToLower(d)] == day.Weekday()
+			}) {
+				continue
+			}
+			y, m, d := day.Date()
+			from := time.Date(y, m, d, startHour, startMin, 0, 0, now.Location())
+			to := time.Date(y, m, d, endHour, endMin, 0, 0, now.Location())
+			if !to.After(from) {
+				to = time.Date(y, m, d+1, endHour, endMin, 0, 0, now.Location())
+			}
+			if !now.Before(from) && now.Before(to) && to.After(until) {
+				until = to
+			}
+		}
+	}
+	return until, !until.IsZero()
+}
+
+// parseClock parses "HH:MM" into its hour and minute.
+func parseClock(s string) (hour, min int, err error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
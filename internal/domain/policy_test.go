@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryPolicy_Evaluate(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	policy := &domain.DeliveryPolicy{
+		Timezone:          "Europe/Berlin",
+		QuietHours:        []domain.QuietHours{{Start: "22:00", End: "07:00"}},
+		SeverityThreshold: domain.SeverityCritical,
+		MaxPerHour:        10,
+		RateLimitAction:   domain.PolicyActionDrop,
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		severity   domain.Severity
+		sent       int
+		action     domain.PolicyAction
+		deferUntil time.Time
+	}{
+		{
+			name:   "daytime",
+			now:    time.Date(2024, 5, 6, 12, 0, 0, 0, berlin),
+			action: domain.PolicyActionDeliver,
+		},
+		{
+			name:       "late evening defers to morning",
+			now:        time.Date(2024, 5, 6, 23, 30, 0, 0, berlin),
+			severity:   domain.SeverityWarning,
+			action:     domain.PolicyActionDefer,
+			deferUntil: time.Date(2024, 5, 7, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:       "after midnight defers to same morning",
+			now:        time.Date(2024, 5, 7, 3, 0, 0, 0, berlin),
+			action:     domain.PolicyActionDefer,
+			deferUntil: time.Date(2024, 5, 7, 7, 0, 0, 0, berlin),
+		},
+		{
+			name:     "critical bypasses quiet hours",
+			now:      time.Date(2024, 5, 7, 3, 0, 0, 0, berlin),
+			severity: domain.SeverityCritical,
+			sent:     50,
+			action:   domain.PolicyActionDeliver,
+		},
+		{
+			name:   "hourly limit",
+			now:    time.Date(2024, 5, 6, 12, 15, 0, 0, berlin),
+			sent:   10,
+			action: domain.PolicyActionDrop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := policy.Evaluate(tt.now, tt.severity, tt.sent)
+			require.Equal(t, tt.action, d.Action)
+			if !tt.deferUntil.IsZero() {
+				require.True(t, tt.deferUntil.Equal(d.DeferUntil), "defer until %s, want %s", d.DeferUntil, tt.deferUntil)
+			}
+		})
+	}
+}
+
+func TestDeliveryPolicy_EvaluateAcrossDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		quiet      domain.QuietHours
+		now        time.Time
+		action     domain.PolicyAction
+		deferUntil time.Time
+	}{
+		{
+			// Clocks skip 02:00-03:00 on 31 March, so the day is 23 hours long.
+			name:   "spring forward ends at the wall-clock end",
+			quiet:  domain.QuietHours{Start: "01:00", End: "06:00"},
+			now:    time.Date(2024, 3, 31, 6, 30, 0, 0, berlin),
+			action: domain.PolicyActionDeliver,
+		},
+		{
+			name:       "spring forward defers to the wall-clock end",
+			quiet:      domain.QuietHours{Start: "01:00", End: "06:00"},
+			now:        time.Date(2024, 3, 31, 5, 30, 0, 0, berlin),
+			action:     domain.PolicyActionDefer,
+			deferUntil: time.Date(2024, 3, 31, 6, 0, 0, 0, berlin),
+		},
+		{
+			// Clocks repeat 02:00-03:00 on 27 October, so the night is an hour longer.
+			name:       "fall back across midnight",
+			quiet:      domain.QuietHours{Start: "22:00", End: "07:00"},
+			now:        time.Date(2024, 10, 27, 6, 30, 0, 0, berlin),
+			action:     domain.PolicyActionDefer,
+			deferUntil: time.Date(2024, 10, 27, 7, 0, 0, 0, berlin),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &domain.DeliveryPolicy{Timezone: "Europe/Berlin", QuietHours: []domain.QuietHours{tt.quiet}}
+			d := policy.Evaluate(tt.now, domain.SeverityInfo, 0)
+			require.Equal(t, tt.action, d.Action)
+			if !tt.deferUntil.IsZero() {
+				require.True(t, tt.deferUntil.Equal(d.DeferUntil), "defer until %s, want %s", d.DeferUntil, tt.deferUntil)
+			}
+		})
+	}
+}
+
+func TestDeliveryPolicy_EvaluateWeekdays(t *testing.T) {
+	policy := &domain.DeliveryPolicy{
+		QuietHours:       []domain.QuietHours{{Start: "00:00", End: "00:00", Weekdays: []string{"sat", "sun"}}},
+		QuietHoursAction: domain.PolicyActionDowngrade,
+	}
+
+	saturday := time.Date(2024, 5, 4, 15, 0, 0, 0, time.UTC)
+	require.Equal(t, domain.PolicyActionDowngrade, policy.Evaluate(saturday, domain.SeverityInfo, 0).Action)
+
+	monday := time.Date(2024, 5, 6, 15, 0, 0, 0, time.UTC)
+	require.Equal(t, domain.PolicyActionDeliver, policy.Evaluate(monday, domain.SeverityInfo, 0).Action)
+}
+
+func TestDeliveryPolicy_Validate(t *testing.T) {
+	require.NoError(t, (&domain.DeliveryPolicy{Timezone: "America/New_York"}).Validate())
+	require.Error(t, (&domain.DeliveryPolicy{Timezone: "Mars/Olympus"}).Validate())
+	require.Error(t, (&domain.DeliveryPolicy{QuietHours: []domain.QuietHours{{Start: "25:00", End: "07:00"}}}).Validate())
+	require.Error(t, (&domain.DeliveryPolicy{RateLimitAction: domain.PolicyActionDowngrade}).Validate())
+}
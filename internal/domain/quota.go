@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// TenantQuota bounds what a tenant may use. Zero limits are unlimited.
+type TenantQuota struct {
+	TenantID          string
+	MaxConnectors     int
+	MessagesPerMinute int
+	MessagesPerDay    int
+	UpdatedAt         time.Time
+}
+
+// QuotaUsage is a tenant's current usage of its quota. Message counts are for
+// the current UTC minute and day.
+type QuotaUsage struct {
+	Connectors         int
+	MessagesThisMinute int
+	MessagesToday      int
+}
+
+// QuotaWindow is a message volume window.
+type QuotaWindow string
+
+const (
+	QuotaWindowMinute QuotaWindow = "minute"
+	QuotaWindowDay    QuotaWindow = "day"
+)
+
+// Start returns the start of the window containing t.
+func (w QuotaWindow) Start(t time.Time) time.Time {
+	t = t.UTC()
+	if w == QuotaWindowDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Minute)
+}
+
+// End returns the end of the window containing t.
+func (w QuotaWindow) End(t time.Time) time.Time {
+	if w == QuotaWindowDay {
+		return w.Start(t).AddDate(0, 0, 1)
+	}
+	return w.Start(t).Add(time.Minute)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Severity ranks notifications so rules and policies can set thresholds.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRank = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityError:    3,
+	SeverityCritical: 4,
+}
+
+// Valid reports whether s is a known severity.
+func (s Severity) Valid() bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+// AtLeast reports whether s is as severe as min. An empty min matches everything.
+func (s Severity) AtLeast(min Severity) bool {
+	if min == "" {
+		return true
+	}
+	return severityRank[s] >= severityRank[min]
+}
+
+// Event is a notification raised by a tenant and routed to connectors by its rules.
+type Event struct {
+	TenantID   string
+	Type       string
+	Severity   Severity
+	Labels     map[string]string
+	Text       string
+	TemplateID string
+	Variables  map[string]string
+}
+
+// RouteDestination is a connector and an optional channel overriding the
+// connector's default channel.
+type RouteDestination struct {
+	ConnectorID string `json:"connector_id"`
+	Channel     string `json:"channel,omitempty"`
+}
+
+// RoutingRule sends matching events of a tenant to its destinations. Empty
+// EventTypes, MinSeverity and MatchLabels match every event.
+type RoutingRule struct {
+	ID           string
+	TenantID     string
+	Name         string
+	EventTypes   []string
+	MinSeverity  Severity
+	MatchLabels  map[string]string
+	Destinations []RouteDestination
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Matches reports whether the event satisfies every condition of the rule.
+func (r *RoutingRule) Matches(e Event) bool {
+	if len(r.EventTypes) > 0 && !slices.Contains(r.EventTypes, e.Type) {
+		return false
+	}
+	if !e.Severity.AtLeast(r.MinSeverity) {
+		return false
+	}
+	for k, v := range r.MatchLabels {
+		if e.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+)
+
+// ScheduleStatus is the lifecycle state of a scheduled message.
+type ScheduleStatus string
+
+const (
+	ScheduleStatusScheduled ScheduleStatus = "scheduled"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+	ScheduleStatusFailed    ScheduleStatus = "failed"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+)
+
+// ScheduledMessage is a message sent at NextRunAt, and again on every occurrence
+// of CronExpr when it is recurring.
+type ScheduledMessage struct {
+	ID          string
+	TenantID    string
+	ConnectorID string
+	Channel     string
+	Severity    Severity
+	Text        string
+	TemplateID  string
+	Variables   map[string]string
+	CronExpr    string
+	NextRunAt   time.Time
+	LastRunAt   *time.Time
+	Status      ScheduleStatus
+	LastError   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
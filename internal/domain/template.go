@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// Template is a named message template owned by a tenant.
+type Template struct {
+	ID        string
+	TenantID  string
+	Name      string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription is a tenant callback URL receiving the connector events
+// of the tenant. Events up to LastEventID were all considered for delivery;
+// later ones are matched against the deliveries already enqueued.
+type WebhookSubscription struct {
+	ID       string
+	TenantID string
+	URL      string
+	// SigningSecretRef names the signing secret in the secret store.
+	// SigningSecret is only set on a subscription just created, for the
+	// response, or on one stored before its secret was moved to the store.
+	SigningSecretRef string
+	SigningSecret    string
+	// EventTypes limits the events delivered; empty means every event.
+	EventTypes  []EventType
+	LastEventID int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event posted, or to be posted, to a subscription. Its
+// ID is sent with every attempt so that receivers can drop duplicates.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        int64
+	EventType      EventType
+	// Payload is the JSON encoded ConnectorEvent posted to the callback URL.
+	Payload       json.RawMessage
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
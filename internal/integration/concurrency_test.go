@@ -0,0 +1,127 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+const concurrentCallers = 20
+
+func TestCreateConnector_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	s := newStack(t)
+	s.slack.AddChannel("general")
+
+	ids := make([]string, concurrentCallers)
+	errs := make([]error, concurrentCallers)
+	var wg sync.WaitGroup
+	for i := range concurrentCallers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := s.connectors.CreateConnector(ctx, usecase.CreateConnectorInput{
+				WorkspaceID:    connectortest.SlackTeamID,
+				TenantID:       "tenant-1",
+				DefaultChannel: "general",
+				Credentials:    fmt.Sprintf("xoxb-%d", i),
+			})
+			errs[i] = err
+			if err == nil {
+				ids[i] = conn.ID
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i := range concurrentCallers {
+		require.NoError(t, errs[i])
+		require.False(t, seen[ids[i]], "duplicate connector ID %s", ids[i])
+		seen[ids[i]] = true
+
+		secret, ok := s.secrets.Secret("slack-connector/" + ids[i])
+		require.True(t, ok)
+		require.Equal(t, fmt.Sprintf("xoxb-%d", i), secret)
+	}
+	stored, err := s.repo.ListByTenant(ctx, "tenant-1", "", 2*concurrentCallers)
+	require.NoError(t, err)
+	require.Len(t, stored, concurrentCallers)
+}
+
+func TestCreateTemplate_ConcurrentSameName(t *testing.T) {
+	ctx := context.Background()
+	s := newStack(t)
+
+	errs := make(chan error, concurrentCallers)
+	var wg sync.WaitGroup
+	for range concurrentCallers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.templates.CreateTemplate(ctx, "tenant-1", "deploy", "Deployed {{ .service }}")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var created int
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		require.True(t, stderrors.Is(err, errors.ErrAlreadyExists), "unexpected error: %v", err)
+	}
+	require.Equal(t, 1, created)
+}
+
+func TestEventRepository_IDsCommitInOrder(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDatabase(t)
+	repo := repository.NewEventRepository(db)
+
+	// An event whose transaction is still open holds back later events, so a
+	// reader resuming after the later ID cannot skip it.
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	var first int64
+	require.NoError(t, tx.QueryRowContext(ctx, `
+        INSERT INTO connector_events (tenant_id, connector_id, type) VALUES ('tenant-1', 'conn-1', 'x') RETURNING id
+    `).Scan(&first))
+
+	appended := make(chan *domain.ConnectorEvent)
+	go func() {
+		e := &domain.ConnectorEvent{TenantID: "tenant-1", ConnectorID: "conn-1", Type: domain.EventMessageDelivered,
+			Data: map[string]string{"text": strings.Repeat("x", 10000)}}
+		require.NoError(t, repo.Append(ctx, e))
+		appended <- e
+	}()
+	select {
+	case <-appended:
+		t.Fatal("append did not wait for the open transaction")
+	case <-time.After(200 * time.Millisecond):
+	}
+	require.NoError(t, tx.Commit())
+
+	second := <-appended
+	require.Greater(t, second.ID, first)
+	stored, err := repo.GetByID(ctx, second.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Data["text"], 10000)
+}
@@ -0,0 +1,115 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/pkg/client"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func TestGRPC_ConnectorLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newStack(t)
+	general := s.slack.AddChannel("general")
+	alerts := s.slack.AddChannel("alerts")
+	c := s.serve(t, client.WithActor("alice@example.com"))
+
+	created, err := c.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		WorkspaceId:        connectortest.SlackTeamID,
+		TenantId:           "tenant-1",
+		DefaultChannelName: "general",
+		Credentials:        "xoxb-1",
+	})
+	require.NoError(t, err)
+	conn := created.GetConnector()
+	require.Equal(t, general, conn.GetDefaultChannelId())
+	secret, ok := s.secrets.Secret("slack-connector/" + conn.GetId())
+	require.True(t, ok)
+	require.Equal(t, "xoxb-1", secret)
+
+	got, err := c.GetConnector(ctx, &connector_v1.GetConnectorRequest{ConnectorId: conn.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, conn.GetId(), got.GetConnector().GetId())
+
+	list, err := c.ListAllConnectors(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	// The first post is rate limited; the server retries it.
+	s.slack.RateLimit("chat.postMessage", 1, 0)
+	sent, err := c.Send(ctx, conn.GetId(), "deploy finished")
+	require.NoError(t, err)
+	require.Equal(t, "delivered", sent.GetStatus())
+	require.Len(t, s.slack.MessagesIn(general), 1)
+
+	messages, err := c.ListMessages(ctx, &connector_v1.ListMessagesRequest{ConnectorId: conn.GetId()})
+	require.NoError(t, err)
+	require.Len(t, messages.GetMessages(), 1)
+	require.Equal(t, sent.GetMessageId(), messages.GetMessages()[0].GetId())
+
+	updated, err := c.UpdateConnector(ctx, &connector_v1.UpdateConnectorRequest{ConnectorId: conn.GetId(), DefaultChannelName: "alerts"})
+	require.NoError(t, err)
+	require.Equal(t, alerts, updated.GetConnector().GetDefaultChannelId())
+
+	verified, err := c.VerifyConnector(ctx, &connector_v1.VerifyConnectorRequest{ConnectorId: conn.GetId()})
+	require.NoError(t, err)
+	require.True(t, verified.GetOk())
+
+	s.slack.RevokeToken("xoxb-1")
+	_, err = c.Send(ctx, conn.GetId(), "after revocation")
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, errors.ReasonTokenRevoked, reasonOf(err))
+
+	audit, err := c.ListAuditEvents(ctx, &connector_v1.ListAuditEventsRequest{ConnectorId: conn.GetId(), Action: "connector.create"})
+	require.NoError(t, err)
+	require.Len(t, audit.GetEvents(), 1)
+	require.Equal(t, "alice@example.com", audit.GetEvents()[0].GetClaimedActor())
+	require.Equal(t, "success", audit.GetEvents()[0].GetOutcome())
+
+	_, err = c.DeleteConnector(ctx, &connector_v1.DeleteConnectorRequest{ConnectorId: conn.GetId()})
+	require.NoError(t, err)
+	_, err = c.GetConnector(ctx, &connector_v1.GetConnectorRequest{ConnectorId: conn.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, ok = s.secrets.Secret("slack-connector/" + conn.GetId())
+	require.False(t, ok)
+}
+
+func TestGRPC_InvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	s := newStack(t)
+	c := s.serve(t)
+
+	_, err := c.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{TenantId: "tenant-1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = c.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		WorkspaceId:        connectortest.SlackTeamID,
+		TenantId:           "tenant-1",
+		DefaultChannelName: "missing",
+		Credentials:        "xoxb-1",
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, errors.ReasonChannelNotFound, reasonOf(err))
+
+	_, err = c.Send(ctx, "no-such-connector", "hello")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func reasonOf(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/go-server/cmd/server/migrations"
+)
+
+var schemaTables = []string{
+	"connectors",
+	"messages",
+	"templates",
+	"scheduled_messages",
+	"routing_rules",
+	"message_dedup",
+	"message_aggregates",
+	"connector_events",
+	"webhook_subscriptions",
+	"webhook_deliveries",
+	"audit_events",
+	"tenant_quotas",
+	"tenant_message_usage",
+}
+
+func TestMigrations_UpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := newDatabase(t)
+	p := newMigrationProvider(t, db)
+	sources := p.ListSources()
+	latest := sources[len(sources)-1].Version
+
+	results, err := p.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, len(sources))
+	version, err := p.GetDBVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+	for _, table := range schemaTables {
+		require.True(t, tableExists(t, db, table), "table %s after up", table)
+	}
+
+	// Every down migration must undo its up migration.
+	_, err = p.DownTo(ctx, 0)
+	require.NoError(t, err)
+	version, err = p.GetDBVersion(ctx)
+	require.NoError(t, err)
+	require.Zero(t, version)
+	for _, table := range schemaTables {
+		require.False(t, tableExists(t, db, table), "table %s after down", table)
+	}
+
+	_, err = p.Up(ctx)
+	require.NoError(t, err)
+	require.True(t, tableExists(t, db, "connectors"))
+}
+
+func TestMigrations_UpOneByOne(t *testing.T) {
+	ctx := context.Background()
+	p := newMigrationProvider(t, newDatabase(t))
+
+	for _, source := range p.ListSources() {
+		result, err := p.UpByOne(ctx)
+		require.NoError(t, err)
+		require.Equal(t, source.Version, result.Source.Version)
+
+		// Rolling each migration back and forth catches down migrations that
+		// leave objects behind for the next up to trip over.
+		_, err = p.Down(ctx)
+		require.NoError(t, err)
+		_, err = p.UpByOne(ctx)
+		require.NoError(t, err)
+	}
+
+	statuses, err := p.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		require.Equal(t, "applied", string(s.State), "migration %d", s.Source.Version)
+	}
+}
+
+func TestMigrations_ConcurrentUp(t *testing.T) {
+	ctx := context.Background()
+	db := newDatabase(t)
+
+	// Replicas starting together take turns on the advisory lock: one applies
+	// every migration and the others find nothing pending.
+	const replicas = 4
+	applied := make(chan int, replicas)
+	errs := make(chan error, replicas)
+	var wg sync.WaitGroup
+	for range replicas {
+		p := newMigrationProvider(t, db)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := p.Up(ctx)
+			applied <- len(results)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(applied)
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	var total int
+	for n := range applied {
+		total += n
+	}
+	require.Equal(t, len(newMigrationProvider(t, db).ListSources()), total)
+}
+
+func TestMigrateCommand(t *testing.T) {
+	ctx := context.Background()
+	db := newDatabase(t)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd, err := migrations.ParseCommand(args)
+		require.NoError(t, err)
+		var out bytes.Buffer
+		require.NoError(t, cmd.Run(ctx, db, &out))
+		return out.String()
+	}
+	sources := newMigrationProvider(t, db).ListSources()
+	latest := sources[len(sources)-1].Version
+
+	out := run("to-version", "2")
+	require.Contains(t, out, "OK    up "+filepath.Base(sources[1].Path))
+	require.Contains(t, out, "database version: 2")
+
+	out = run("status")
+	require.Regexp(t, `(?m)^2\s+applied\s`, out)
+	require.Regexp(t, `(?m)^3\s+pending\s+-`, out)
+
+	out = run("up")
+	require.Contains(t, out, fmt.Sprintf("database version: %d", latest))
+
+	out = run("redo")
+	require.Contains(t, out, "OK    down "+filepath.Base(sources[len(sources)-1].Path))
+	require.Contains(t, out, fmt.Sprintf("database version: %d", latest))
+
+	out = run("down")
+	require.Contains(t, out, fmt.Sprintf("database version: %d", sources[len(sources)-2].Version))
+
+	out = run("to-version", "0")
+	require.Contains(t, out, "database version: 0")
+	require.False(t, tableExists(t, db, "connectors"))
+
+	cmd, err := migrations.ParseCommand([]string{"to-version", "999999"})
+	require.NoError(t, err)
+	require.ErrorIs(t, cmd.Run(ctx, db, io.Discard), goose.ErrVersionNotFound)
+}
+
+func tableExists(t *testing.T, db *sql.DB, table string) bool {
+	t.Helper()
+	var name sql.NullString
+	require.NoError(t, db.QueryRow(`SELECT to_regclass($1)::text`, "public."+table).Scan(&name))
+	return name.Valid
+}
@@ -0,0 +1,368 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+)
+
+func TestConnectorRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewConnectorRepository(newMigratedDatabase(t))
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, id := range []string{"conn-b", "conn-a", "conn-c"} {
+		require.NoError(t, repo.Create(ctx, &domain.Connector{
+			ID:               id,
+			Provider:         domain.ProviderSlack,
+			TenantID:         "tenant-1",
+			WorkspaceID:      "T123",
+			DefaultChannelID: "C123",
+			CreatedAt:        created,
+			UpdatedAt:        created,
+		}))
+	}
+	require.NoError(t, repo.Create(ctx, &domain.Connector{ID: "conn-other", Provider: domain.ProviderDiscord, TenantID: "tenant-2", CreatedAt: created, UpdatedAt: created}))
+	require.Error(t, repo.Create(ctx, &domain.Connector{ID: "conn-a", TenantID: "tenant-1", CreatedAt: created, UpdatedAt: created}))
+
+	got, err := repo.GetByID(ctx, "conn-a")
+	require.NoError(t, err)
+	require.Equal(t, domain.ProviderSlack, got.Provider)
+	require.Equal(t, "C123", got.DefaultChannelID)
+	require.Nil(t, got.DeliveryPolicy)
+	require.True(t, created.Equal(got.CreatedAt))
+
+	page, err := repo.ListByTenant(ctx, "tenant-1", "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"conn-a", "conn-b"}, connectorIDs(page))
+	page, err = repo.ListByTenant(ctx, "tenant-1", "conn-b", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"conn-c"}, connectorIDs(page))
+
+	updated := created.Add(time.Hour)
+	policy := &domain.DeliveryPolicy{
+		Timezone:          "Europe/Berlin",
+		QuietHours:        []domain.QuietHours{{Start: "22:00", End: "07:00", Weekdays: []string{"sat", "sun"}}},
+		QuietHoursAction:  domain.PolicyActionDefer,
+		SeverityThreshold: domain.SeverityWarning,
+		MaxPerHour:        10,
+	}
+	require.NoError(t, repo.UpdateDeliveryPolicy(ctx, "conn-a", policy, updated))
+	require.NoError(t, repo.UpdateDefaultChannel(ctx, "conn-a", "C999", updated))
+	got, err = repo.GetByID(ctx, "conn-a")
+	require.NoError(t, err)
+	require.Equal(t, policy, got.DeliveryPolicy)
+	require.Equal(t, "C999", got.DefaultChannelID)
+	require.True(t, updated.Equal(got.UpdatedAt))
+
+	require.NoError(t, repo.UpdateDeliveryPolicy(ctx, "conn-a", nil, updated))
+	got, err = repo.GetByID(ctx, "conn-a")
+	require.NoError(t, err)
+	require.Nil(t, got.DeliveryPolicy)
+
+	require.NoError(t, repo.Delete(ctx, "conn-a"))
+	_, err = repo.GetByID(ctx, "conn-a")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorIs(t, repo.UpdateDefaultChannel(ctx, "conn-a", "C1", updated), sql.ErrNoRows)
+	require.ErrorIs(t, repo.UpdateDeliveryPolicy(ctx, "conn-a", nil, updated), sql.ErrNoRows)
+}
+
+func TestTemplateRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTemplateRepository(newMigratedDatabase(t))
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tmpl := &domain.Template{ID: "tmpl-1", TenantID: "tenant-1", Name: "deploy", Body: "Deployed {{ .service }}", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(ctx, tmpl))
+	err := repo.Create(ctx, &domain.Template{ID: "tmpl-2", TenantID: "tenant-1", Name: "deploy", Body: "x", CreatedAt: now, UpdatedAt: now})
+	require.True(t, repository.IsUniqueViolation(err))
+
+	tmpl.Body = "Deployed {{ .service }} to {{ .env }}"
+	tmpl.UpdatedAt = now.Add(time.Minute)
+	require.NoError(t, repo.Update(ctx, tmpl))
+	got, err := repo.GetByID(ctx, "tmpl-1")
+	require.NoError(t, err)
+	require.Equal(t, tmpl.Body, got.Body)
+
+	list, err := repo.ListByTenant(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, repo.Delete(ctx, "tmpl-1"))
+	_, err = repo.GetByID(ctx, "tmpl-1")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorIs(t, repo.Update(ctx, tmpl), sql.ErrNoRows)
+}
+
+func TestScheduledMessageRepository_ProcessDueLeasesRows(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDatabase(t)
+	repo := repository.NewScheduledMessageRepository(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, id := range []string{"sm-1", "sm-2", "sm-3"} {
+		require.NoError(t, repo.Create(ctx, &domain.ScheduledMessage{
+			ID:          id,
+			TenantID:    "tenant-1",
+			ConnectorID: "conn-1",
+			Text:        "hello",
+			NextRunAt:   now.Add(time.Duration(i-3) * time.Minute),
+			Status:      domain.ScheduleStatusScheduled,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}))
+	}
+
+	// While sm-1 is being sent, a second dispatcher claims only the other rows,
+	// and a cancellation arriving meanwhile is kept.
+	var nested []string
+	n, err := repo.ProcessDue(ctx, now, 1, func(ctx context.Context, sm *domain.ScheduledMessage) error {
+		_, err := repo.ProcessDue(ctx, now, 10, func(ctx context.Context, other *domain.ScheduledMessage) error {
+			nested = append(nested, other.ID)
+			other.Status = domain.ScheduleStatusCompleted
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Cancel(ctx, sm.ID, now))
+		sm.Status = domain.ScheduleStatusCompleted
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.ElementsMatch(t, []string{"sm-2", "sm-3"}, nested)
+
+	got, err := repo.GetByID(ctx, "sm-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.ScheduleStatusCancelled, got.Status)
+
+	// A claim whose result was never saved is picked up again after its lease.
+	_, err = db.ExecContext(ctx, `UPDATE scheduled_messages SET status = 'scheduled', leased_until = $1 WHERE id = 'sm-2'`, now.Add(time.Minute))
+	require.NoError(t, err)
+	n, err = repo.ProcessDue(ctx, now, 10, func(context.Context, *domain.ScheduledMessage) error { return nil })
+	require.NoError(t, err)
+	require.Zero(t, n)
+	n, err = repo.ProcessDue(ctx, now.Add(2*time.Minute), 10, func(ctx context.Context, sm *domain.ScheduledMessage) error {
+		require.Equal(t, "sm-2", sm.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestScheduledMessageRepository_ProcessDueRenewsLeaseForEachSend(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDatabase(t)
+	repo := repository.NewScheduledMessageRepository(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for _, id := range []string{"sm-1", "sm-2"} {
+		require.NoError(t, repo.Create(ctx, &domain.ScheduledMessage{
+			ID: id, TenantID: "tenant-1", ConnectorID: "conn-1", Text: "hello",
+			NextRunAt: now.Add(-time.Minute), Status: domain.ScheduleStatusScheduled, CreatedAt: now, UpdatedAt: now,
+		}))
+	}
+
+	// Each send runs under a lease taken when it starts and ends with it.
+	var previous time.Time
+	n, err := repo.ProcessDue(ctx, now, 10, func(ctx context.Context, sm *domain.ScheduledMessage) error {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		var leasedUntil time.Time
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT leased_until FROM scheduled_messages WHERE id = $1`, sm.ID).Scan(&leasedUntil))
+		require.True(t, deadline.Equal(leasedUntil), "deadline %s, lease %s", deadline, leasedUntil)
+		require.True(t, deadline.After(previous))
+		previous = deadline
+		time.Sleep(5 * time.Millisecond)
+
+		sm.Status = domain.ScheduleStatusCompleted
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestAggregationRepository_AddKeepsHighestSeverity(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewAggregationRepository(newMigratedDatabase(t))
+	now := time.Now().UTC().Truncate(time.Second)
+
+	var a *domain.MessageAggregate
+	for i, severity := range []domain.Severity{domain.SeverityWarning, domain.SeverityCritical, domain.SeverityInfo, ""} {
+		var err error
+		a, err = repo.Add(ctx, &domain.MessageAggregate{
+			ID:          "agg-" + string(rune('a'+i)),
+			ConnectorID: "conn-1",
+			GroupKey:    "cpu-high",
+			Severity:    severity,
+			FirstText:   "cpu high",
+			FirstSeen:   now,
+			FlushAt:     now.Add(time.Minute),
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, "agg-a", a.ID)
+	require.Equal(t, 4, a.Count)
+	require.Equal(t, domain.SeverityCritical, a.Severity)
+}
+
+func TestAggregationRepository_ProcessDueSavesEachDigest(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewAggregationRepository(newMigratedDatabase(t))
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, group := range []string{"cpu-high", "disk-full"} {
+		_, err := repo.Add(ctx, &domain.MessageAggregate{
+			ID:          "agg-" + group,
+			ConnectorID: "conn-1",
+			GroupKey:    group,
+			FirstText:   group,
+			FirstSeen:   now.Add(-time.Hour),
+			FlushAt:     now.Add(time.Duration(i-2) * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	// The second digest fails. The first stays flushed, and a message for its
+	// group arriving while it was posted opens a new aggregate.
+	var sent []string
+	_, err := repo.ProcessDue(ctx, now, 10, func(ctx context.Context, a *domain.MessageAggregate) error {
+		if a.GroupKey == "disk-full" {
+			return errors.New("posting failed")
+		}
+		next, err := repo.Add(ctx, &domain.MessageAggregate{
+			ID: "agg-cpu-high-2", ConnectorID: "conn-1", GroupKey: "cpu-high", FirstText: "cpu-high", FirstSeen: now, FlushAt: now,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "agg-cpu-high-2", next.ID)
+
+		sent = append(sent, a.ID)
+		flushedAt := now
+		a.FlushedAt = &flushedAt
+		return nil
+	})
+	require.ErrorContains(t, err, "posting failed")
+	require.Equal(t, []string{"agg-cpu-high"}, sent)
+
+	// Only the new aggregate is due; the failed one waits for its lease.
+	sent = nil
+	flush := func(ctx context.Context, a *domain.MessageAggregate) error {
+		sent = append(sent, a.ID)
+		flushedAt := now
+		a.FlushedAt = &flushedAt
+		return nil
+	}
+	n, err := repo.ProcessDue(ctx, now, 10, flush)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"agg-cpu-high-2"}, sent)
+
+	sent = nil
+	n, err = repo.ProcessDue(ctx, now.Add(10*time.Minute), 10, flush)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"agg-disk-full"}, sent)
+}
+
+func TestWebhookRepository_EnqueueDeliveriesOncePerEvent(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDatabase(t)
+	events := repository.NewEventRepository(db)
+	repo := repository.NewWebhookRepository(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, repo.CreateSubscription(ctx, &domain.WebhookSubscription{
+		ID:         "sub-1",
+		TenantID:   "tenant-1",
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventMessageDelivered},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}))
+	for _, typ := range []domain.EventType{domain.EventMessageDelivered, domain.EventMessageFailed, domain.EventMessageDelivered} {
+		require.NoError(t, events.Append(ctx, &domain.ConnectorEvent{TenantID: "tenant-1", ConnectorID: "conn-1", Type: typ}))
+	}
+
+	n, err := repo.EnqueueDeliveries(ctx, now)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	// Recent events are checked again but not enqueued twice.
+	n, err = repo.EnqueueDeliveries(ctx, now)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	sub, err := repo.GetSubscription(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Zero(t, sub.LastEventID)
+
+	// Once they are past the lookback, the subscription moves past them.
+	n, err = repo.EnqueueDeliveries(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, n)
+	sub, err = repo.GetSubscription(ctx, "sub-1")
+	require.NoError(t, err)
+	require.NotZero(t, sub.LastEventID)
+
+	deliveries, err := repo.ListDeliveries(ctx, "sub-1", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+}
+
+func TestWebhookRepository_KeepsOnlySigningSecretRefs(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDatabase(t)
+	repo := repository.NewWebhookRepository(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, repo.CreateSubscription(ctx, &domain.WebhookSubscription{
+		ID:               "sub-1",
+		TenantID:         "tenant-1",
+		URL:              "https://example.com/hook",
+		SigningSecretRef: "webhook-subscription/sub-1",
+		SigningSecret:    "whsec_new",
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}))
+	sub, err := repo.GetSubscription(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Equal(t, "webhook-subscription/sub-1", sub.SigningSecretRef)
+	require.Empty(t, sub.SigningSecret)
+
+	// A subscription created before secrets were kept in the secret store.
+	_, err = db.ExecContext(ctx, `
+        INSERT INTO webhook_subscriptions (id, tenant_id, url, signing_secret, created_at, updated_at)
+        VALUES ('sub-2', 'tenant-1', 'https://example.com/hook', 'whsec_old', $1, $1)
+    `, now)
+	require.NoError(t, err)
+
+	subs, err := repo.ListUnreferencedSecrets(ctx)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	require.Equal(t, "whsec_old", subs[0].SigningSecret)
+
+	require.NoError(t, repo.SetSigningSecretRef(ctx, "sub-2", "webhook-subscription/sub-2"))
+	sub, err = repo.GetSubscription(ctx, "sub-2")
+	require.NoError(t, err)
+	require.Equal(t, "webhook-subscription/sub-2", sub.SigningSecretRef)
+	require.Empty(t, sub.SigningSecret)
+
+	subs, err = repo.ListUnreferencedSecrets(ctx)
+	require.NoError(t, err)
+	require.Empty(t, subs)
+}
+
+func connectorIDs(connectors []*domain.Connector) []string {
+	ids := make([]string, 0, len(connectors))
+	for _, c := range connectors {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/client"
+)
+
+// stack is the connector service wired like go-server/cmd/server, on a fresh
+// database, with Slack and Secrets Manager replaced by local stand-ins.
+type stack struct {
+	slack      *connectortest.SlackServer
+	secrets    *connectortest.SecretsManagerServer
+	connectors usecase.ConnectorUsecase
+	templates  usecase.TemplateUsecase
+	audit      usecase.AuditUsecase
+	repo       repository.ConnectorRepository
+}
+
+func newStack(t *testing.T) *stack {
+	t.Helper()
+	db := newMigratedDatabase(t)
+
+	slack := connectortest.NewSlackServer()
+	t.Cleanup(slack.Close)
+	secretsServer := connectortest.NewSecretsManagerServer()
+	t.Cleanup(secretsServer.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(secretsServer.URL()),
+	})
+	require.NoError(t, err)
+
+	connRepo := repository.NewConnectorRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+	quotaRepo := repository.NewQuotaRepository(db)
+	audit := usecase.NewAuditUsecase(repository.NewAuditRepository(db), nil)
+	connectors := usecase.NewConnectorUsecase(connRepo,
+		services.NewSecretsManager(sess),
+		services.NewSlackClient(services.WithSlackAPIURL(slack.URL())),
+		usecase.WithMessageLog(repository.NewMessageRepository(db)),
+		usecase.WithTemplates(templateRepo),
+		usecase.WithDeduplication(repository.NewDedupRepository(db), 5*time.Minute),
+		usecase.WithEvents(repository.NewEventRepository(db)),
+		usecase.WithAuditLog(audit),
+		usecase.WithQuotas(quotaRepo, nil),
+		usecase.WithRetryPolicy(usecase.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}),
+	)
+	return &stack{
+		slack:      slack,
+		secrets:    secretsServer,
+		connectors: connectors,
+		templates:  usecase.NewTemplateUsecase(templateRepo),
+		audit:      audit,
+		repo:       connRepo,
+	}
+}
+
+// serve starts the gRPC server of the stack on a bufconn listener and returns
+// a client connected to it.
+func (s *stack) serve(t *testing.T, opts ...client.Option) *client.Client {
+	t.Helper()
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.UnaryRequestMetadataInterceptor(nil)),
+		grpc.ChainStreamInterceptor(handler.StreamRequestMetadataInterceptor(nil)),
+	)
+	connector_v1.RegisterSlackConnectorServiceServer(srv, handler.NewSlackConnectorHandler(s.connectors,
+		handler.WithTemplateUsecase(s.templates),
+		handler.WithAuditUsecase(s.audit),
+	))
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	opts = append([]client.Option{
+		client.WithInsecure(),
+		client.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })),
+	}, opts...)
+	c, err := client.New("passthrough:///bufconn", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
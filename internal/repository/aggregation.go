@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type AggregationRepository interface {
+	Add(ctx context.Context, a *domain.MessageAggregate) (*domain.MessageAggregate, error)
+	ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, a *domain.MessageAggregate) error) (int, error)
+}
+
+type aggregationRepository struct {
+	db *sql.DB
+}
+
+func NewAggregationRepository(db *sql.DB) AggregationRepository {
+	return &aggregationRepository{db: db}
+}
+
+const aggregateColumns = `id, connector_id, channel, group_key, severity, first_text, last_text, count,
+        first_seen, last_seen, flush_at, flushed_at, message_id, last_error`
+
+// severityOrder ranks severities in SQL the way domain.Severity.AtLeast does,
+// with no severity lowest.
+const severityOrder = `ARRAY['', 'info', 'warning', 'error', 'critical']`
+
+// Add opens an aggregate for the group or, when one is already open, adds the
+// message to it, keeping the highest severity seen. An aggregate whose digest
+// is being posted is no longer open. It returns the aggregate as stored.
+func (ar *aggregationRepository) Add(ctx context.Context, a *domain.MessageAggregate) (*domain.MessageAggregate, error) {
+	row := ar.db.QueryRowContext(ctx, `
+        INSERT INTO message_aggregates (id, connector_id, channel, group_key, severity, first_text, last_text, count, first_seen, last_seen, flush_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $6, 1, $7, $7, $8)
+        ON CONFLICT (connector_id, channel, group_key) WHERE flushed_at IS NULL AND leased_until IS NULL DO UPDATE
+        SET count = message_aggregates.count + 1, last_text = EXCLUDED.last_text, last_seen = EXCLUDED.last_seen,
+            severity = CASE
+                WHEN array_position(`+severityOrder+`, EXCLUDED.severity) > array_position(`+severityOrder+`, message_aggregates.severity)
+                THEN EXCLUDED.severity ELSE message_aggregates.severity
+            END
+        RETURNING `+aggregateColumns,
+		a.ID, a.ConnectorID, a.Channel, a.GroupKey, a.Severity, a.FirstText, a.FirstSeen, a.FlushAt)
+	return scanAggregate(row)
+}
+
+// ProcessDue claims up to limit aggregates whose window has closed, calls fn
+// for each one and stores the flush outcome fn sets. It leases rows the way
+// the scheduled message repository does: the claim is one short statement, so
+// Add never waits for a digest to be posted; each lease is renewed just before
+// fn runs and fn's ctx ends with it; and each outcome is saved on its own, so
+// a failure leaves the digests already posted flushed.
+func (ar *aggregationRepository) ProcessDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	fn func(ctx context.Context, a *domain.MessageAggregate) error,
+) (int, error) {
+	claimedUntil := leaseFromNow()
+
+	rows, err := ar.db.QueryContext(ctx, `
+        UPDATE message_aggregates SET leased_until = $2
+        WHERE id IN (
+            SELECT id FROM message_aggregates
+            WHERE flushed_at IS NULL AND flush_at <= $1 AND (leased_until IS NULL OR leased_until <= $1)
+            ORDER BY flush_at
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+aggregateColumns+`
+    `, now, claimedUntil, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var due []*domain.MessageAggregate
+	for rows.Next() {
+		a, err := scanAggregate(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	slices.SortFunc(due, func(a, b *domain.MessageAggregate) int { return a.FlushAt.Compare(b.FlushAt) })
+
+	processed := 0
+	for _, a := range due {
+		leasedUntil := leaseFromNow()
+		res, err := ar.db.ExecContext(ctx, `
+            UPDATE message_aggregates SET leased_until = $3
+            WHERE id = $1 AND leased_until = $2 AND flushed_at IS NULL
+        `, a.ID, claimedUntil, leasedUntil)
+		if err != nil {
+			return processed, fmt.Errorf("renewing lease of message aggregate %s: %w", a.ID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return processed, fmt.Errorf("renewing lease of message aggregate %s: %w", a.ID, err)
+		} else if n == 0 {
+			// Claimed by another dispatcher after the lease ran out.
+			continue
+		}
+
+		flushCtx, cancel := context.WithDeadline(ctx, leasedUntil)
+		err = fn(flushCtx, a)
+		cancel()
+		if err != nil {
+			return processed, fmt.Errorf("flushing message aggregate %s: %w", a.ID, err)
+		}
+		if _, err := ar.db.ExecContext(ctx, `
+            UPDATE message_aggregates SET flushed_at = $2, message_id = $3, last_error = $4, leased_until = NULL
+            WHERE id = $1 AND leased_until = $5
+        `, a.ID, a.FlushedAt, a.MessageID, a.LastError, leasedUntil); err != nil {
+			return processed, fmt.Errorf("saving message aggregate %s: %w", a.ID, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func scanAggregate(row rowScanner) (*domain.MessageAggregate, error) {
+	var (
+		a         domain.MessageAggregate
+		flushedAt sql.NullTime
+	)
+	if err := row.Scan(&a.ID, &a.ConnectorID, &a.Channel, &a.GroupKey, &a.Severity, &a.FirstText, &a.LastText, &a.Count,
+		&a.FirstSeen, &a.LastSeen, &a.FlushAt, &flushedAt, &a.MessageID, &a.LastError); err != nil {
+		return nil, err
+	}
+	if flushedAt.Valid {
+		a.FlushedAt = &flushedAt.Time
+	}
+	return &a, nil
+}
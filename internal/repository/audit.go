@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type AuditRepository interface {
+	Append(ctx context.Context, e *domain.AuditEvent) error
+	List(ctx context.Context, filter domain.AuditFilter, limit int) ([]*domain.AuditEvent, error)
+}
+
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Append stores the event and sets its ID.
+func (ar *auditRepository) Append(ctx context.Context, e *domain.AuditEvent) error {
+	return ar.db.QueryRowContext(ctx, `
+        INSERT INTO audit_events (tenant_id, claimed_actor, action, connector_id, request_id, source_ip, peer_ip, outcome, error, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id
+    `, e.TenantID, e.ClaimedActor, e.Action, e.ConnectorID, e.RequestID, e.SourceIP, e.PeerIP, e.Outcome, e.Error, e.CreatedAt).Scan(&e.ID)
+}
+
+// List returns up to limit events matching filter, newest first.
+func (ar *auditRepository) List(ctx context.Context, filter domain.AuditFilter, limit int) ([]*domain.AuditEvent, error) {
+	rows, err := ar.db.QueryContext(ctx, `
+        SELECT id, tenant_id, claimed_actor, action, connector_id, request_id, source_ip, peer_ip, outcome, error, created_at
+        FROM audit_events
+        WHERE ($1 = '' OR tenant_id = $1)
+            AND ($2 = '' OR connector_id = $2)
+            AND ($3 = '' OR action = $3)
+            AND ($4 = 0 OR id < $4)
+        ORDER BY id DESC
+        LIMIT $5
+    `, filter.TenantID, filter.ConnectorID, filter.Action, filter.BeforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		var e domain.AuditEvent
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.ClaimedActor, &e.Action, &e.ConnectorID, &e.RequestID, &e.SourceIP, &e.PeerIP,
+			&e.Outcome, &e.Error, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	_ "github.com/lib/pq"
 
@@ -12,6 +14,9 @@ import (
 type ConnectorRepository interface {
 	Create(ctx context.Context, c *domain.Connector) error
 	GetByID(ctx context.Context, id string) (*domain.Connector, error)
+	ListByTenant(ctx context.Context, tenantID, afterID string, limit int) ([]*domain.Connector, error)
+	UpdateDeliveryPolicy(ctx context.Context, id string, policy *domain.DeliveryPolicy, updatedAt time.Time) error
+	UpdateDefaultChannel(ctx context.Context, id, channelID string, updatedAt time.Time) error
 	Delete(ctx context.Context, id string) error
 }
 
@@ -24,10 +29,14 @@ func NewConnectorRepository(db *sql.DB) ConnectorRepository {
 }
 
 func (cr *connectorRepository) Create(ctx context.Context, c *domain.Connector) error {
+	policy, err := marshalDeliveryPolicy(c.DeliveryPolicy)
+	if err != nil {
+		return err
+	}
 	if _, err := cr.db.ExecContext(ctx, `
-        INSERT INTO connectors (id, tenant_id, workspace_id, default_channel_id, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6)
-    `, c.ID, c.TenantID, c.WorkspaceID, c.DefaultChannelID, c.CreatedAt, c.UpdatedAt); err != nil {
+        INSERT INTO connectors (id, provider, tenant_id, workspace_id, default_channel_id, delivery_policy, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `, c.ID, c.Provider, c.TenantID, c.WorkspaceID, c.DefaultChannelID, policy, c.CreatedAt, c.UpdatedAt); err != nil {
 		return err
 	}
 
@@ -36,17 +45,90 @@ func (cr *connectorRepository) Create(ctx context.Context, c *domain.Connector)
 
 func (cr *connectorRepository) GetByID(ctx context.Context, id string) (*domain.Connector, error) {
 	row := cr.db.QueryRowContext(ctx, `
-        SELECT id, tenant_id, workspace_id, default_channel_id, created_at, updated_at
+        SELECT id, provider, tenant_id, workspace_id, default_channel_id, delivery_policy, created_at, updated_at
         FROM connectors WHERE id = $1
     `, id)
-	var c domain.Connector
-	if err := row.Scan(&c.ID, &c.TenantID, &c.WorkspaceID, &c.DefaultChannelID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	return scanConnector(row)
+}
+
+// ListByTenant returns up to limit of the tenant's connectors ordered by ID,
+// starting after afterID.
+func (cr *connectorRepository) ListByTenant(ctx context.Context, tenantID, afterID string, limit int) ([]*domain.Connector, error) {
+	rows, err := cr.db.QueryContext(ctx, `
+        SELECT id, provider, tenant_id, workspace_id, default_channel_id, delivery_policy, created_at, updated_at
+        FROM connectors WHERE tenant_id = $1 AND id > $2
+        ORDER BY id
+        LIMIT $3
+    `, tenantID, afterID, limit)
+	if err != nil {
 		return nil, err
 	}
-	return &c, nil
+	defer rows.Close()
+
+	var connectors []*domain.Connector
+	for rows.Next() {
+		c, err := scanConnector(rows)
+		if err != nil {
+			return nil, err
+		}
+		connectors = append(connectors, c)
+	}
+	return connectors, rows.Err()
+}
+
+// UpdateDeliveryPolicy replaces the connector's delivery policy; a nil policy
+// removes it. It returns sql.ErrNoRows when the connector does not exist.
+func (cr *connectorRepository) UpdateDeliveryPolicy(ctx context.Context, id string, policy *domain.DeliveryPolicy, updatedAt time.Time) error {
+	encoded, err := marshalDeliveryPolicy(policy)
+	if err != nil {
+		return err
+	}
+	res, err := cr.db.ExecContext(ctx, `
+        UPDATE connectors SET delivery_policy = $2, updated_at = $3 WHERE id = $1
+    `, id, encoded, updatedAt)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// UpdateDefaultChannel sets the channel messages are posted to by default. It
+// returns sql.ErrNoRows when the connector does not exist.
+func (cr *connectorRepository) UpdateDefaultChannel(ctx context.Context, id, channelID string, updatedAt time.Time) error {
+	res, err := cr.db.ExecContext(ctx, `
+        UPDATE connectors SET default_channel_id = $2, updated_at = $3 WHERE id = $1
+    `, id, channelID, updatedAt)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
 }
 
 func (cr *connectorRepository) Delete(ctx context.Context, id string) error {
 	_, err := cr.db.ExecContext(ctx, `DELETE FROM connectors WHERE id = $1`, id)
 	return err
 }
+
+func scanConnector(row rowScanner) (*domain.Connector, error) {
+	var (
+		c      domain.Connector
+		policy []byte
+	)
+	if err := row.Scan(&c.ID, &c.Provider, &c.TenantID, &c.WorkspaceID, &c.DefaultChannelID, &policy, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if len(policy) > 0 {
+		if err := json.Unmarshal(policy, &c.DeliveryPolicy); err != nil {
+			return nil, err
+		}
+	}
+	return &c, nil
+}
+
+// marshalDeliveryPolicy encodes the policy as JSON, or SQL NULL when there is none.
+func marshalDeliveryPolicy(p *domain.DeliveryPolicy) ([]byte, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DedupRepository interface {
+	Claim(ctx context.Context, connectorID, key, messageID string, now, expiresAt time.Time) (bool, string, error)
+	Release(ctx context.Context, connectorID, key, messageID string) error
+}
+
+type dedupRepository struct {
+	db *sql.DB
+}
+
+func NewDedupRepository(db *sql.DB) DedupRepository {
+	return &dedupRepository{db: db}
+}
+
+// Claim records messageID as the message sent for key until expiresAt. When
+// another message already holds an unexpired claim, Claim returns false with
+// that message's ID and counts the suppressed duplicate. Both statements are
+// atomic, so concurrent replicas agree on a single winner.
+func (dr *dedupRepository) Claim(ctx context.Context, connectorID, key, messageID string, now, expiresAt time.Time) (bool, string, error) {
+	var claimedBy string
+	err := dr.db.QueryRowContext(ctx, `
+        INSERT INTO message_dedup (connector_id, dedup_key, message_id, suppressed, first_seen, last_seen, expires_at)
+        VALUES ($1, $2, $3, 0, $4, $4, $5)
+        ON CONFLICT (connector_id, dedup_key) DO UPDATE
+        SET message_id = EXCLUDED.message_id, suppressed = 0, first_seen = EXCLUDED.first_seen,
+            last_seen = EXCLUDED.last_seen, expires_at = EXCLUDED.expires_at
+        WHERE message_dedup.expires_at <= EXCLUDED.first_seen
+        RETURNING message_id
+    `, connectorID, key, messageID, now, expiresAt).Scan(&claimedBy)
+	if err == nil {
+		return true, claimedBy, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, "", err
+	}
+
+	err = dr.db.QueryRowContext(ctx, `
+        UPDATE message_dedup SET suppressed = suppressed + 1, last_seen = $3
+        WHERE connector_id = $1 AND dedup_key = $2
+        RETURNING message_id
+    `, connectorID, key, now).Scan(&claimedBy)
+	if err != nil {
+		return false, "", err
+	}
+	return false, claimedBy, nil
+}
+
+// Release drops the claim held by messageID so that a failed delivery does not
+// suppress its retries.
+func (dr *dedupRepository) Release(ctx context.Context, connectorID, key, messageID string) error {
+	_, err := dr.db.ExecContext(ctx, `
+        DELETE FROM message_dedup WHERE connector_id = $1 AND dedup_key = $2 AND message_id = $3
+    `, connectorID, key, messageID)
+	return err
+}
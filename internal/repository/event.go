@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type EventRepository interface {
+	Append(ctx context.Context, e *domain.ConnectorEvent) error
+	GetByID(ctx context.Context, id int64) (*domain.ConnectorEvent, error)
+	ListAfter(ctx context.Context, filter domain.EventFilter, afterID int64, limit int) ([]*domain.ConnectorEvent, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type eventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(db *sql.DB) EventRepository {
+	return &eventRepository{db: db}
+}
+
+// Append stores the event and sets its ID and creation time. Triggers make IDs
+// commit in increasing order and publish the ID on the connector_events channel.
+// Append must not run inside a longer transaction, since other appends wait
+// for it to commit.
+func (er *eventRepository) Append(ctx context.Context, e *domain.ConnectorEvent) error {
+	if e.Data == nil {
+		e.Data = map[string]string{}
+	}
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	return er.db.QueryRowContext(ctx, `
+        INSERT INTO connector_events (tenant_id, connector_id, type, data)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `, e.TenantID, e.ConnectorID, e.Type, data).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (er *eventRepository) GetByID(ctx context.Context, id int64) (*domain.ConnectorEvent, error) {
+	row := er.db.QueryRowContext(ctx, `
+        SELECT id, tenant_id, connector_id, type, data, created_at
+        FROM connector_events WHERE id = $1
+    `, id)
+	return scanEvent(row)
+}
+
+// ListAfter returns up to limit events matching filter with an ID greater than afterID, oldest first.
+func (er *eventRepository) ListAfter(ctx context.Context, filter domain.EventFilter, afterID int64, limit int) ([]*domain.ConnectorEvent, error) {
+	rows, err := er.db.QueryContext(ctx, `
+        SELECT id, tenant_id, connector_id, type, data, created_at
+        FROM connector_events
+        WHERE id > $1 AND ($2 = '' OR tenant_id = $2) AND ($3 = '' OR connector_id = $3)
+        ORDER BY id
+        LIMIT $4
+    `, afterID, filter.TenantID, filter.ConnectorID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.ConnectorEvent
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// DeleteBefore removes events created before the given time and returns how many were removed.
+func (er *eventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := er.db.ExecContext(ctx, `DELETE FROM connector_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func scanEvent(row rowScanner) (*domain.ConnectorEvent, error) {
+	var (
+		e    domain.ConnectorEvent
+		data []byte
+	)
+	if err := row.Scan(&e.ID, &e.TenantID, &e.ConnectorID, &e.Type, &data, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &e.Data); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
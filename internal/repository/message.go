@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type MessageRepository interface {
+	Create(ctx context.Context, m *domain.Message) error
+	CountDeliveredSince(ctx context.Context, connectorID string, since time.Time) (int, error)
+	ListByConnector(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error)
+}
+
+type messageRepository struct {
+	db *sql.DB
+}
+
+func NewMessageRepository(db *sql.DB) MessageRepository {
+	return &messageRepository{db: db}
+}
+
+func (mr *messageRepository) Create(ctx context.Context, m *domain.Message) error {
+	var (
+		action        domain.PolicyAction
+		reason        string
+		deferredUntil sql.NullTime
+	)
+	if m.Decision != nil {
+		action, reason = m.Decision.Action, m.Decision.Reason
+		deferredUntil = sql.NullTime{Time: m.Decision.DeferUntil, Valid: !m.Decision.DeferUntil.IsZero()}
+	}
+	_, err := mr.db.ExecContext(ctx, `
+        INSERT INTO messages (id, connector_id, tenant_id, channel_id, text, status, attempts, last_error,
+            policy_action, policy_reason, deferred_until, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    `, m.ID, m.ConnectorID, m.TenantID, m.ChannelID, m.Text, m.Status, m.Attempts, m.LastError,
+		action, reason, deferredUntil, m.CreatedAt, m.UpdatedAt)
+	return err
+}
+
+// CountDeliveredSince counts the messages the connector delivered at or after since.
+func (mr *messageRepository) CountDeliveredSince(ctx context.Context, connectorID string, since time.Time) (int, error) {
+	var n int
+	err := mr.db.QueryRowContext(ctx, `
+        SELECT count(*) FROM messages
+        WHERE connector_id = $1 AND status = $2 AND created_at >= $3
+    `, connectorID, domain.MessageStatusDelivered, since).Scan(&n)
+	return n, err
+}
+
+// ListByConnector returns the connector's latest messages, newest first.
+func (mr *messageRepository) ListByConnector(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error) {
+	rows, err := mr.db.QueryContext(ctx, `
+        SELECT id, connector_id, tenant_id, channel_id, text, status, attempts, last_error,
+            policy_action, policy_reason, deferred_until, created_at, updated_at
+        FROM messages WHERE connector_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `, connectorID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var (
+			m             domain.Message
+			action        domain.PolicyAction
+			reason        string
+			deferredUntil sql.NullTime
+		)
+		if err := rows.Scan(&m.ID, &m.ConnectorID, &m.TenantID, &m.ChannelID, &m.Text, &m.Status, &m.Attempts, &m.LastError,
+			&action, &reason, &deferredUntil, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if action != "" {
+			m.Decision = &domain.PolicyDecision{Action: action, Reason: reason, DeferUntil: deferredUntil.Time}
+		}
+		messages = append(messages, &m)
+	}
+	return messages, rows.Err()
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// IsUniqueViolation reports whether err is a Postgres unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// expectRowsAffected turns an update that matched no rows into sql.ErrNoRows.
+func expectRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// dispatchLease is how long a dispatcher owns a row it claimed or is sending.
+// A row whose result was never saved, e.g. because the replica died while
+// sending it, is claimed again once its lease has run out.
+const dispatchLease = 5 * time.Minute
+
+// leaseFromNow returns the end of a lease taken now, at the precision Postgres
+// stores, so that it can be matched when the lease is renewed or released.
+func leaseFromNow() time.Time {
+	return time.Now().UTC().Add(dispatchLease).Truncate(time.Microsecond)
+}
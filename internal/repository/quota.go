@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type QuotaRepository interface {
+	Get(ctx context.Context, tenantID string) (*domain.TenantQuota, error)
+	Upsert(ctx context.Context, q *domain.TenantQuota) error
+	CountConnectors(ctx context.Context, tenantID string) (int, error)
+	// ConsumeMessage counts one message in the current minute and day unless
+	// that would exceed a non-zero limit. It returns the first exceeded
+	// window, or "" when the message was counted.
+	ConsumeMessage(ctx context.Context, tenantID string, now time.Time, perMinute, perDay int) (domain.QuotaWindow, error)
+	MessageUsage(ctx context.Context, tenantID string, now time.Time) (minute, day int, err error)
+	DeleteUsageBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type quotaRepository struct {
+	db *sql.DB
+}
+
+func NewQuotaRepository(db *sql.DB) QuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+// Get returns the tenant's quota, or sql.ErrNoRows when none was set.
+func (qr *quotaRepository) Get(ctx context.Context, tenantID string) (*domain.TenantQuota, error) {
+	var q domain.TenantQuota
+	err := qr.db.QueryRowContext(ctx, `
+        SELECT tenant_id, max_connectors, messages_per_minute, messages_per_day, updated_at
+        FROM tenant_quotas WHERE tenant_id = $1
+    `, tenantID).Scan(&q.TenantID, &q.MaxConnectors, &q.MessagesPerMinute, &q.MessagesPerDay, &q.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (qr *quotaRepository) Upsert(ctx context.Context, q *domain.TenantQuota) error {
+	_, err := qr.db.ExecContext(ctx, `
+        INSERT INTO tenant_quotas (tenant_id, max_connectors, messages_per_minute, messages_per_day, updated_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (tenant_id) DO UPDATE
+        SET max_connectors = EXCLUDED.max_connectors,
+            messages_per_minute = EXCLUDED.messages_per_minute,
+            messages_per_day = EXCLUDED.messages_per_day,
+            updated_at = EXCLUDED.updated_at
+    `, q.TenantID, q.MaxConnectors, q.MessagesPerMinute, q.MessagesPerDay, q.UpdatedAt)
+	return err
+}
+
+func (qr *quotaRepository) CountConnectors(ctx context.Context, tenantID string) (int, error) {
+	var n int
+	err := qr.db.QueryRowContext(ctx, `SELECT count(*) FROM connectors WHERE tenant_id = $1`, tenantID).Scan(&n)
+	return n, err
+}
+
+// ConsumeMessage increments both windows in one transaction, so a message
+// rejected by the daily limit does not count against the minute either.
+func (qr *quotaRepository) ConsumeMessage(ctx context.Context, tenantID string, now time.Time, perMinute, perDay int) (domain.QuotaWindow, error) {
+	tx, err := qr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	for _, w := range []struct {
+		window domain.QuotaWindow
+		limit  int
+	}{
+		{domain.QuotaWindowMinute, perMinute},
+		{domain.QuotaWindowDay, perDay},
+	} {
+		// The conditional update returns no row once the limit is reached. A
+		// zero limit counts without ever rejecting.
+		var count int
+		err := tx.QueryRowContext(ctx, `
+            INSERT INTO tenant_message_usage (tenant_id, window_kind, window_start, count)
+            VALUES ($1, $2, $3, 1)
+            ON CONFLICT (tenant_id, window_kind, window_start) DO UPDATE
+            SET count = tenant_message_usage.count + 1
+            WHERE $4 = 0 OR tenant_message_usage.count < $4
+            RETURNING count
+        `, tenantID, w.window, w.window.Start(now), w.limit).Scan(&count)
+		if err == sql.ErrNoRows {
+			return w.window, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", tx.Commit()
+}
+
+// MessageUsage returns the messages counted in the current minute and day.
+func (qr *quotaRepository) MessageUsage(ctx context.Context, tenantID string, now time.Time) (minute, day int, err error) {
+	err = qr.db.QueryRowContext(ctx, `
+        SELECT
+            COALESCE(SUM(count) FILTER (WHERE window_kind = $2 AND window_start = $3), 0),
+            COALESCE(SUM(count) FILTER (WHERE window_kind = $4 AND window_start = $5), 0)
+        FROM tenant_message_usage
+        WHERE tenant_id = $1
+    `, tenantID, domain.QuotaWindowMinute, domain.QuotaWindowMinute.Start(now),
+		domain.QuotaWindowDay, domain.QuotaWindowDay.Start(now)).Scan(&minute, &day)
+	return minute, day, err
+}
+
+// DeleteUsageBefore removes the counters of windows that started before the given time.
+func (qr *quotaRepository) DeleteUsageBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := qr.db.ExecContext(ctx, `DELETE FROM tenant_message_usage WHERE window_start < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type RoutingRuleRepository interface {
+	Create(ctx context.Context, r *domain.RoutingRule) error
+	GetByID(ctx context.Context, id string) (*domain.RoutingRule, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error)
+	Update(ctx context.Context, r *domain.RoutingRule) error
+	Delete(ctx context.Context, id string) error
+}
+
+type routingRuleRepository struct {
+	db *sql.DB
+}
+
+func NewRoutingRuleRepository(db *sql.DB) RoutingRuleRepository {
+	return &routingRuleRepository{db: db}
+}
+
+const routingRuleColumns = `id, tenant_id, name, event_types, min_severity, match_labels, destinations, created_at, updated_at`
+
+func (rr *routingRuleRepository) Create(ctx context.Context, r *domain.RoutingRule) error {
+	eventTypes, labels, destinations, err := marshalRoutingRule(r)
+	if err != nil {
+		return err
+	}
+	_, err = rr.db.ExecContext(ctx, `
+        INSERT INTO routing_rules (`+routingRuleColumns+`)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `, r.ID, r.TenantID, r.Name, eventTypes, r.MinSeverity, labels, destinations, r.CreatedAt, r.UpdatedAt)
+	return err
+}
+
+func (rr *routingRuleRepository) GetByID(ctx context.Context, id string) (*domain.RoutingRule, error) {
+	row := rr.db.QueryRowContext(ctx, `
+        SELECT `+routingRuleColumns+`
+        FROM routing_rules WHERE id = $1
+    `, id)
+	return scanRoutingRule(row)
+}
+
+func (rr *routingRuleRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error) {
+	rows, err := rr.db.QueryContext(ctx, `
+        SELECT `+routingRuleColumns+`
+        FROM routing_rules WHERE tenant_id = $1 ORDER BY name
+    `, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*domain.RoutingRule
+	for rows.Next() {
+		r, err := scanRoutingRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// Update stores every field of the rule except its tenant. It returns
+// sql.ErrNoRows when the rule does not exist.
+func (rr *routingRuleRepository) Update(ctx context.Context, r *domain.RoutingRule) error {
+	eventTypes, labels, destinations, err := marshalRoutingRule(r)
+	if err != nil {
+		return err
+	}
+	res, err := rr.db.ExecContext(ctx, `
+        UPDATE routing_rules
+        SET name = $2, event_types = $3, min_severity = $4, match_labels = $5, destinations = $6, updated_at = $7
+        WHERE id = $1
+    `, r.ID, r.Name, eventTypes, r.MinSeverity, labels, destinations, r.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+func (rr *routingRuleRepository) Delete(ctx context.Context, id string) error {
+	_, err := rr.db.ExecContext(ctx, `DELETE FROM routing_rules WHERE id = $1`, id)
+	return err
+}
+
+func marshalRoutingRule(r *domain.RoutingRule) (eventTypes, labels, destinations []byte, err error) {
+	if eventTypes, err = json.Marshal(r.EventTypes); err != nil {
+		return nil, nil, nil, err
+	}
+	if labels, err = json.Marshal(r.MatchLabels); err != nil {
+		return nil, nil, nil, err
+	}
+	if destinations, err = json.Marshal(r.Destinations); err != nil {
+		return nil, nil, nil, err
+	}
+	return eventTypes, labels, destinations, nil
+}
+
+func scanRoutingRule(row rowScanner) (*domain.RoutingRule, error) {
+	var (
+		r                                domain.RoutingRule
+		eventTypes, labels, destinations []byte
+	)
+	if err := row.Scan(&r.ID, &r.TenantID, &r.Name, &eventTypes, &r.MinSeverity, &labels, &destinations,
+		&r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventTypes, &r.EventTypes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(labels, &r.MatchLabels); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(destinations, &r.Destinations); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type ScheduledMessageRepository interface {
+	Create(ctx context.Context, sm *domain.ScheduledMessage) error
+	GetByID(ctx context.Context, id string) (*domain.ScheduledMessage, error)
+	List(ctx context.Context, tenantID, connectorID string) ([]*domain.ScheduledMessage, error)
+	Cancel(ctx context.Context, id string, at time.Time) error
+	ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, sm *domain.ScheduledMessage) error) (int, error)
+}
+
+type scheduledMessageRepository struct {
+	db *sql.DB
+}
+
+func NewScheduledMessageRepository(db *sql.DB) ScheduledMessageRepository {
+	return &scheduledMessageRepository{db: db}
+}
+
+const scheduledMessageColumns = `id, tenant_id, connector_id, channel, severity, text, template_id, variables, cron_expr,
+        next_run_at, last_run_at, status, last_error, created_at, updated_at`
+
+func (sr *scheduledMessageRepository) Create(ctx context.Context, sm *domain.ScheduledMessage) error {
+	vars, err := json.Marshal(sm.Variables)
+	if err != nil {
+		return err
+	}
+	_, err = sr.db.ExecContext(ctx, `
+        INSERT INTO scheduled_messages (`+scheduledMessageColumns+`)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+    `, sm.ID, sm.TenantID, sm.ConnectorID, sm.Channel, sm.Severity, sm.Text, sm.TemplateID, vars, sm.CronExpr,
+		sm.NextRunAt, sm.LastRunAt, sm.Status, sm.LastError, sm.CreatedAt, sm.UpdatedAt)
+	return err
+}
+
+func (sr *scheduledMessageRepository) GetByID(ctx context.Context, id string) (*domain.ScheduledMessage, error) {
+	row := sr.db.QueryRowContext(ctx, `
+        SELECT `+scheduledMessageColumns+`
+        FROM scheduled_messages WHERE id = $1
+    `, id)
+	return scanScheduledMessage(row)
+}
+
+// List returns the tenant's scheduled messages, optionally narrowed to one connector.
+func (sr *scheduledMessageRepository) List(ctx context.Context, tenantID, connectorID string) ([]*domain.ScheduledMessage, error) {
+	rows, err := sr.db.QueryContext(ctx, `
+        SELECT `+scheduledMessageColumns+`
+        FROM scheduled_messages
+        WHERE tenant_id = $1 AND ($2 = '' OR connector_id = $2)
+        ORDER BY next_run_at
+    `, tenantID, connectorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.ScheduledMessage
+	for rows.Next() {
+		sm, err := scanScheduledMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sm)
+	}
+	return out, rows.Err()
+}
+
+// Cancel stops a pending schedule. It returns sql.ErrNoRows when there is no
+// pending schedule with that ID.
+func (sr *scheduledMessageRepository) Cancel(ctx context.Context, id string, at time.Time) error {
+	res, err := sr.db.ExecContext(ctx, `
+        UPDATE scheduled_messages SET status = $2, updated_at = $3
+        WHERE id = $1 AND status = $4
+    `, id, domain.ScheduleStatusCancelled, at, domain.ScheduleStatusScheduled)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// ProcessDue claims up to limit due schedules and calls fn for each one,
+// saving whatever fn sets on it (next run, status, last error). Claiming leases
+// the rows in one short statement with FOR UPDATE SKIP LOCKED, so concurrent
+// replicas never claim the same row and no lock is held while fn sends. Each
+// lease is renewed from the current time just before its schedule is sent, and
+// fn's ctx ends with the lease, so a send never outlives the lease another
+// replica waits for. A schedule whose lease ran out and was claimed again is
+// skipped. Each result is saved on its own, so a failed save re-dispatches
+// only that schedule, and schedules cancelled while being dispatched stay
+// cancelled.
+func (sr *scheduledMessageRepository) ProcessDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	fn func(ctx context.Context, sm *domain.ScheduledMessage) error,
+) (int, error) {
+	claimedUntil := leaseFromNow()
+
+	rows, err := sr.db.QueryContext(ctx, `
+        UPDATE scheduled_messages SET leased_until = $3
+        WHERE id IN (
+            SELECT id FROM scheduled_messages
+            WHERE status = $1 AND next_run_at <= $2 AND (leased_until IS NULL OR leased_until <= $2)
+            ORDER BY next_run_at
+            LIMIT $4
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+scheduledMessageColumns+`
+    `, domain.ScheduleStatusScheduled, now, claimedUntil, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var due []*domain.ScheduledMessage
+	for rows.Next() {
+		sm, err := scanScheduledMessage(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, sm)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	slices.SortFunc(due, func(a, b *domain.ScheduledMessage) int { return a.NextRunAt.Compare(b.NextRunAt) })
+
+	processed := 0
+	for _, sm := range due {
+		leasedUntil := leaseFromNow()
+		res, err := sr.db.ExecContext(ctx, `
+            UPDATE scheduled_messages SET leased_until = $3
+            WHERE id = $1 AND leased_until = $2 AND status = $4
+        `, sm.ID, claimedUntil, leasedUntil, domain.ScheduleStatusScheduled)
+		if err != nil {
+			return processed, fmt.Errorf("renewing lease of scheduled message %s: %w", sm.ID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return processed, fmt.Errorf("renewing lease of scheduled message %s: %w", sm.ID, err)
+		} else if n == 0 {
+			// Cancelled, or claimed by another dispatcher after the lease ran out.
+			continue
+		}
+
+		sendCtx, cancel := context.WithDeadline(ctx, leasedUntil)
+		err = fn(sendCtx, sm)
+		cancel()
+		if err != nil {
+			return processed, fmt.Errorf("processing scheduled message %s: %w", sm.ID, err)
+		}
+		if _, err := sr.db.ExecContext(ctx, `
+            UPDATE scheduled_messages
+            SET next_run_at = $2, last_run_at = $3, status = $4, last_error = $5, updated_at = $6, leased_until = NULL
+            WHERE id = $1 AND leased_until = $7 AND status = $8
+        `, sm.ID, sm.NextRunAt, sm.LastRunAt, sm.Status, sm.LastError, sm.UpdatedAt, leasedUntil, domain.ScheduleStatusScheduled); err != nil {
+			return processed, fmt.Errorf("saving scheduled message %s: %w", sm.ID, err)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScheduledMessage(row rowScanner) (*domain.ScheduledMessage, error) {
+	var (
+		sm      domain.ScheduledMessage
+		vars    []byte
+		lastRun sql.NullTime
+	)
+	if err := row.Scan(&sm.ID, &sm.TenantID, &sm.ConnectorID, &sm.Channel, &sm.Severity, &sm.Text, &sm.TemplateID, &vars, &sm.CronExpr,
+		&sm.NextRunAt, &lastRun, &sm.Status, &sm.LastError, &sm.CreatedAt, &sm.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if len(vars) > 0 {
+		if err := json.Unmarshal(vars, &sm.Variables); err != nil {
+			return nil, err
+		}
+	}
+	if lastRun.Valid {
+		sm.LastRunAt = &lastRun.Time
+	}
+	return &sm, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type TemplateRepository interface {
+	Create(ctx context.Context, t *domain.Template) error
+	GetByID(ctx context.Context, id string) (*domain.Template, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.Template, error)
+	Update(ctx context.Context, t *domain.Template) error
+	Delete(ctx context.Context, id string) error
+}
+
+type templateRepository struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+func (tr *templateRepository) Create(ctx context.Context, t *domain.Template) error {
+	_, err := tr.db.ExecContext(ctx, `
+        INSERT INTO templates (id, tenant_id, name, body, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `, t.ID, t.TenantID, t.Name, t.Body, t.CreatedAt, t.UpdatedAt)
+	return err
+}
+
+func (tr *templateRepository) GetByID(ctx context.Context, id string) (*domain.Template, error) {
+	row := tr.db.QueryRowContext(ctx, `
+        SELECT id, tenant_id, name, body, created_at, updated_at
+        FROM templates WHERE id = $1
+    `, id)
+	var t domain.Template
+	if err := row.Scan(&t.ID, &t.TenantID, &t.Name, &t.Body, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (tr *templateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.Template, error) {
+	rows, err := tr.db.QueryContext(ctx, `
+        SELECT id, tenant_id, name, body, created_at, updated_at
+        FROM templates WHERE tenant_id = $1 ORDER BY name
+    `, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*domain.Template
+	for rows.Next() {
+		var t domain.Template
+		if err := rows.Scan(&t.ID, &t.TenantID, &t.Name, &t.Body, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, &t)
+	}
+	return templates, rows.Err()
+}
+
+// Update stores the template's name and body. It returns sql.ErrNoRows when the
+// template does not exist.
+func (tr *templateRepository) Update(ctx context.Context, t *domain.Template) error {
+	res, err := tr.db.ExecContext(ctx, `
+        UPDATE templates SET name = $2, body = $3, updated_at = $4 WHERE id = $1
+    `, t.ID, t.Name, t.Body, t.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+func (tr *templateRepository) Delete(ctx context.Context, id string) error {
+	_, err := tr.db.ExecContext(ctx, `DELETE FROM templates WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, s *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListUnreferencedSecrets(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	SetSigningSecretRef(ctx context.Context, id, ref string) error
+	EnqueueDeliveries(ctx context.Context, now time.Time) (int64, error)
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error)
+	ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, s *domain.WebhookSubscription, d *domain.WebhookDelivery) error) (int, error)
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+const webhookSubscriptionColumns = `id, tenant_id, url, signing_secret_ref, signing_secret, event_types, last_event_id, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, last_error,
+        next_attempt_at, delivered_at, created_at, updated_at`
+
+// CreateSubscription stores s. Its signing secret is not stored, only
+// SigningSecretRef. Only events appended after it was created are delivered, so
+// its LastEventID is set to the latest event.
+func (wr *webhookRepository) CreateSubscription(ctx context.Context, s *domain.WebhookSubscription) error {
+	types := s.EventTypes
+	if types == nil {
+		types = []domain.EventType{}
+	}
+	eventTypes, err := json.Marshal(types)
+	if err != nil {
+		return err
+	}
+	return wr.db.QueryRowContext(ctx, `
+        INSERT INTO webhook_subscriptions (id, tenant_id, url, signing_secret_ref, event_types, last_event_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, COALESCE((SELECT max(id) FROM connector_events), 0), $6, $7)
+        RETURNING last_event_id
+    `, s.ID, s.TenantID, s.URL, s.SigningSecretRef, eventTypes, s.CreatedAt, s.UpdatedAt).Scan(&s.LastEventID)
+}
+
+func (wr *webhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	row := wr.db.QueryRowContext(ctx, `
+        SELECT `+webhookSubscriptionColumns+`
+        FROM webhook_subscriptions WHERE id = $1
+    `, id)
+	return scanWebhookSubscription(row)
+}
+
+func (wr *webhookRepository) ListSubscriptions(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	rows, err := wr.db.QueryContext(ctx, `
+        SELECT `+webhookSubscriptionColumns+`
+        FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at
+    `, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// DeleteSubscription removes the subscription and its delivery log. It returns
+// sql.ErrNoRows when the subscription does not exist.
+func (wr *webhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	res, err := wr.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// ListUnreferencedSecrets returns the subscriptions whose signing secret is
+// still stored in their row rather than in the secret store.
+func (wr *webhookRepository) ListUnreferencedSecrets(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	rows, err := wr.db.QueryContext(ctx, `
+        SELECT `+webhookSubscriptionColumns+`
+        FROM webhook_subscriptions WHERE signing_secret_ref = '' ORDER BY created_at
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// SetSigningSecretRef records that the subscription's signing secret is kept
+// in the secret store under ref, and clears the secret from its row. It returns
+// sql.ErrNoRows when the subscription does not exist.
+func (wr *webhookRepository) SetSigningSecretRef(ctx context.Context, id, ref string) error {
+	res, err := wr.db.ExecContext(ctx, `
+        UPDATE webhook_subscriptions SET signing_secret_ref = $2, signing_secret = '' WHERE id = $1
+    `, id, ref)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// webhookEnqueueLookback is how long events stay candidates for delivery
+// after they were appended. Events are matched against the deliveries already
+// enqueued rather than a high-water mark, so one whose transaction commits
+// after a later event is still delivered.
+const webhookEnqueueLookback = 5 * time.Minute
+
+// EnqueueDeliveries creates a pending delivery, due at now, for every event of
+// each subscription's tenant that has none yet. LastEventID only moves past
+// events older than webhookEnqueueLookback, so recent events are checked again
+// on every call. Subscriptions are locked with FOR UPDATE SKIP LOCKED, and the
+// delivery ID is unique per subscription and event, so an event is never
+// enqueued twice.
+func (wr *webhookRepository) EnqueueDeliveries(ctx context.Context, now time.Time) (int64, error) {
+	res, err := wr.db.ExecContext(ctx, `
+        WITH subs AS (
+            SELECT id, tenant_id, event_types, last_event_id
+            FROM webhook_subscriptions
+            FOR UPDATE SKIP LOCKED
+        ), candidates AS (
+            SELECT s.id AS subscription_id, s.event_types, e.id AS event_id, e.type, e.created_at, to_jsonb(e) AS payload
+            FROM subs s
+            JOIN connector_events e ON e.tenant_id = s.tenant_id AND e.id > s.last_event_id
+        ), settled AS (
+            UPDATE webhook_subscriptions w
+            SET last_event_id = c.settled_id
+            FROM (
+                SELECT subscription_id,
+                    COALESCE(min(event_id) FILTER (WHERE created_at >= $3) - 1, max(event_id)) AS settled_id
+                FROM candidates GROUP BY subscription_id
+            ) c
+            WHERE w.id = c.subscription_id AND c.settled_id > w.last_event_id
+        )
+        INSERT INTO webhook_deliveries (`+webhookDeliveryColumns+`)
+        SELECT subscription_id || ':' || event_id, subscription_id, event_id, type, payload, $1, 0, '', $2, NULL, $2, $2
+        FROM candidates c
+        WHERE (jsonb_array_length(event_types) = 0 OR event_types ? type)
+            AND NOT EXISTS (SELECT 1 FROM webhook_deliveries d WHERE d.id = c.subscription_id || ':' || c.event_id)
+        ON CONFLICT (id) DO NOTHING
+    `, domain.WebhookDeliveryPending, now, now.Add(-webhookEnqueueLookback))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// ListDeliveries returns up to limit deliveries of the subscription, newest first.
+func (wr *webhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := wr.db.QueryContext(ctx, `
+        SELECT `+webhookDeliveryColumns+`
+        FROM webhook_deliveries
+        WHERE subscription_id = $1
+        ORDER BY created_at DESC, event_id DESC
+        LIMIT $2
+    `, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// ProcessDue locks up to limit pending deliveries due by now with FOR UPDATE
+// SKIP LOCKED and calls fn for each one with its subscription. Whatever fn sets
+// on the delivery (status, attempts, next attempt, error) is saved in the same
+// transaction.
+func (wr *webhookRepository) ProcessDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+	fn func(ctx context.Context, s *domain.WebhookSubscription, d *domain.WebhookDelivery) error,
+) (int, error) {
+	tx, err := wr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.last_error,
+            d.next_attempt_at, d.delivered_at, d.created_at, d.updated_at,
+            s.id, s.tenant_id, s.url, s.signing_secret_ref, s.signing_secret, s.event_types, s.last_event_id, s.created_at, s.updated_at
+        FROM webhook_deliveries d
+        JOIN webhook_subscriptions s ON s.id = d.subscription_id
+        WHERE d.status = $1 AND d.next_attempt_at <= $2
+        ORDER BY d.next_attempt_at
+        LIMIT $3
+        FOR UPDATE OF d SKIP LOCKED
+    `, domain.WebhookDeliveryPending, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	type due struct {
+		sub      *domain.WebhookSubscription
+		delivery *domain.WebhookDelivery
+	}
+	var batch []due
+	for rows.Next() {
+		var (
+			d, s        = &domain.WebhookDelivery{}, &domain.WebhookSubscription{}
+			deliveredAt sql.NullTime
+			eventTypes  []byte
+		)
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.LastError,
+			&d.NextAttemptAt, &deliveredAt, &d.CreatedAt, &d.UpdatedAt,
+			&s.ID, &s.TenantID, &s.URL, &s.SigningSecretRef, &s.SigningSecret, &eventTypes, &s.LastEventID, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		if err := json.Unmarshal(eventTypes, &s.EventTypes); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, due{sub: s, delivery: d})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, b := range batch {
+		d := b.delivery
+		if err := fn(ctx, b.sub, d); err != nil {
+			return 0, fmt.Errorf("processing webhook delivery %s: %w", d.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE webhook_deliveries
+            SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5, delivered_at = $6, updated_at = $7
+            WHERE id = $1
+        `, d.ID, d.Status, d.Attempts, d.LastError, d.NextAttemptAt, d.DeliveredAt, d.UpdatedAt); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(batch), nil
+}
+
+func scanWebhookSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var (
+		s          domain.WebhookSubscription
+		eventTypes []byte
+	)
+	if err := row.Scan(&s.ID, &s.TenantID, &s.URL, &s.SigningSecretRef, &s.SigningSecret, &eventTypes, &s.LastEventID, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventTypes, &s.EventTypes); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var (
+		d           domain.WebhookDelivery
+		deliveredAt sql.NullTime
+	)
+	if err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.LastError,
+		&d.NextAttemptAt, &deliveredAt, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
@@ -0,0 +1,46 @@
+// Package scheduler runs the service's periodic background jobs.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is a unit of periodic work. It must be safe to run on several replicas at once.
+type Job interface {
+	Name() string
+	Run(ctx context.Context, now time.Time) error
+}
+
+// JobFunc adapts a function to Job.
+type JobFunc struct {
+	JobName string
+	Fn      func(ctx context.Context, now time.Time) error
+}
+
+func (j JobFunc) Name() string { return j.JobName }
+
+func (j JobFunc) Run(ctx context.Context, now time.Time) error { return j.Fn(ctx, now) }
+
+// Run calls every job once per interval until ctx is cancelled. A failing job is
+// logged and retried on the next tick; it never stops the others.
+func Run(ctx context.Context, interval time.Duration, jobs ...Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, job := range jobs {
+			if err := job.Run(ctx, now); err != nil && ctx.Err() == nil {
+				slog.Error("scheduled job failed", "job", job.Name(), "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
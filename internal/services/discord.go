@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	apperrors "github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type discordClient struct {
+	http *http.Client
+}
+
+// NewDiscordClient returns a Messenger posting to Discord channel webhooks.
+func NewDiscordClient(opts ...WebhookOption) Messenger {
+	return &discordClient{http: newWebhookHTTPClient(opts)}
+}
+
+type discordWebhook struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+}
+
+// ResolveChannelID looks the webhook up and returns the ID of the channel it posts to.
+func (c *discordClient) ResolveChannelID(ctx context.Context, webhookURL, channelName string) (string, error) {
+	if err := validateWebhookURL(webhookURL); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, webhookURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build discord request: %w", withoutURL(err))
+	}
+
+	resp, err := do(c.http, req, "discord")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := checkWebhookResponse(resp, "discord"); err != nil {
+		return "", err
+	}
+
+	var hook discordWebhook
+	if err := json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return "", fmt.Errorf("failed to decode discord webhook: %w", err)
+	}
+	if hook.ChannelID == "" {
+		return "", apperrors.NotFound(apperrors.ReasonChannelNotFound, "discord_channel", channelName)
+	}
+	return hook.ChannelID, nil
+}
+
+// SendMessage posts the message as the webhook's content.
+func (c *discordClient) SendMessage(ctx context.Context, webhookURL, channelID, message string) error {
+	return postJSON(ctx, c.http, "discord", webhookURL, map[string]string{
+		"content": message,
+	})
+}
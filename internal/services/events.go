@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+)
+
+// EventsChannel is the Postgres notification channel connector events are published on.
+const EventsChannel = "connector_events"
+
+var (
+	// ErrSubscriberTooSlow closes a subscription whose buffer filled up.
+	ErrSubscriberTooSlow = errors.New("event subscriber fell behind")
+	// ErrEventsDisconnected closes every subscription when the listener lost
+	// its connection and notifications may have been missed.
+	ErrEventsDisconnected = errors.New("event listener disconnected")
+	// ErrEventsUnreadable closes every subscription when a notified event
+	// could not be read.
+	ErrEventsUnreadable = errors.New("notified event could not be read")
+)
+
+// EventLoader reads the stored event a notification refers to.
+type EventLoader interface {
+	GetByID(ctx context.Context, id int64) (*domain.ConnectorEvent, error)
+}
+
+// EventBroker fans connector events out to in-process subscribers.
+type EventBroker interface {
+	Subscribe() *EventSubscription
+	Publish(e *domain.ConnectorEvent)
+	// Interrupt closes every subscription with err.
+	Interrupt(err error)
+}
+
+// EventSubscription receives the events published after it was created until
+// it is closed. Err reports why the broker closed it.
+type EventSubscription struct {
+	broker *eventBroker
+	events chan *domain.ConnectorEvent
+	err    error
+	once   sync.Once
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends.
+func (s *EventSubscription) Events() <-chan *domain.ConnectorEvent {
+	return s.events
+}
+
+// Err returns the reason the broker closed the subscription, if it did.
+func (s *EventSubscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+// Close unsubscribes. It is safe to call more than once.
+func (s *EventSubscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s, nil)
+}
+
+type eventBroker struct {
+	mu     sync.Mutex
+	subs   map[*EventSubscription]struct{}
+	buffer int
+}
+
+// NewEventBroker creates an EventBroker buffering up to buffer events per
+// subscriber. Subscribers that fall further behind are closed with
+// ErrSubscriberTooSlow instead of blocking the others.
+func NewEventBroker(buffer int) EventBroker {
+	return &eventBroker{subs: map[*EventSubscription]struct{}{}, buffer: buffer}
+}
+
+func (b *eventBroker) Subscribe() *EventSubscription {
+	s := &EventSubscription{broker: b, events: make(chan *domain.ConnectorEvent, b.buffer)}
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+func (b *eventBroker) Publish(e *domain.ConnectorEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		select {
+		case s.events <- e:
+		default:
+			b.remove(s, ErrSubscriberTooSlow)
+		}
+	}
+}
+
+func (b *eventBroker) Interrupt(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		b.remove(s, err)
+	}
+}
+
+// remove closes s with err. b.mu must be held.
+func (b *eventBroker) remove(s *EventSubscription, err error) {
+	s.once.Do(func() {
+		delete(b.subs, s)
+		s.err = err
+		close(s.events)
+	})
+}
+
+// ListenForEvents publishes the connector events notified on EventsChannel to
+// broker until ctx is cancelled. Notifications carry the event ID, and the
+// event is read from events. Every replica runs a listener, so events written
+// by any of them reach all watchers. When the connection drops or an event
+// cannot be read, subscriptions are interrupted so that watchers resume from
+// the log. A LISTEN the server refuses is retried with backoff.
+func ListenForEvents(ctx context.Context, dsn string, events EventLoader, broker EventBroker) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Error("connector events listener", "event", ev, "error", err)
+		}
+	})
+	defer listener.Close()
+
+	for delay := time.Second; ; delay = min(2*delay, time.Minute) {
+		err := listener.Listen(EventsChannel)
+		if err == nil {
+			break
+		}
+		slog.Error("error listening for connector events", "retry_in", delay.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			if n == nil {
+				broker.Interrupt(ErrEventsDisconnected)
+				continue
+			}
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				slog.Error("error decoding connector event notification", "error", err)
+				continue
+			}
+			e, err := events.GetByID(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					// Removed by retention before it was read.
+					continue
+				}
+				slog.Error("error reading notified connector event", "event_id", id, "error", err)
+				broker.Interrupt(ErrEventsUnreadable)
+				continue
+			}
+			broker.Publish(e)
+		case <-time.After(90 * time.Second):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					slog.Error("connector events listener ping failed", "error", err)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type mattermostClient struct {
+	http *http.Client
+}
+
+// NewMattermostClient returns a Messenger posting to Mattermost incoming webhooks.
+func NewMattermostClient(opts ...WebhookOption) Messenger {
+	return &mattermostClient{http: newWebhookHTTPClient(opts)}
+}
+
+type mattermostPayload struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// ResolveChannelID validates the webhook URL. Mattermost webhooks accept a channel
+// name override on every post, so the name itself is used as the channel ID.
+func (c *mattermostClient) ResolveChannelID(ctx context.Context, webhookURL, channelName string) (string, error) {
+	if err := validateWebhookURL(webhookURL); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(channelName, "~"), nil
+}
+
+// SendMessage posts the message to the given channel through the webhook.
+func (c *mattermostClient) SendMessage(ctx context.Context, webhookURL, channelID, message string) error {
+	return postJSON(ctx, c.http, "mattermost", webhookURL, mattermostPayload{
+		Text:    message,
+		Channel: channelID,
+	})
+}
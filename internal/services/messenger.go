@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	apperrors "github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// Messenger is implemented by every chat platform a connector can post to.
+// The token is the provider credential kept in the secret store: a bot token
+// for Slack, the incoming webhook URL for the webhook based providers.
+type Messenger interface {
+	ResolveChannelID(ctx context.Context, token, channelName string) (string, error)
+	SendMessage(ctx context.Context, token, channelID, message string) error
+}
+
+// Verifier is implemented by the Messengers that can check a credential
+// without posting a message.
+type Verifier interface {
+	// VerifyChannel reports whether token is valid and can post to channelID.
+	VerifyChannel(ctx context.Context, token, channelID string) error
+}
+
+const webhookTimeout = 10 * time.Second
+
+// errBlockedAddress is returned when a tenant supplied URL resolves to an
+// address the service must not call.
+var errBlockedAddress = errors.New("address is not allowed")
+
+// blockedPrefixes are the non-public ranges not already covered by
+// netip.Addr's IsPrivate and IsGlobalUnicast.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+type webhookClientConfig struct {
+	allowPrivate bool
+}
+
+// WebhookOption configures the clients connecting to tenant supplied URLs and
+// hosts.
+type WebhookOption func(*webhookClientConfig)
+
+// WithPrivateNetworks lets the client reach loopback, link-local and private
+// addresses, e.g. a test server. Without it those are refused when dialing.
+func WithPrivateNetworks() WebhookOption {
+	return func(c *webhookClientConfig) {
+		c.allowPrivate = true
+	}
+}
+
+// newWebhookHTTPClient returns a client that checks every address it dials,
+// after DNS resolution and on every redirect, so a tenant cannot point it at
+// the service's own network. Proxies are not used, so the check always sees
+// the real destination.
+func newWebhookHTTPClient(opts []WebhookOption) *http.Client {
+	var cfg webhookClientConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dialer := &net.Dialer{Timeout: webhookTimeout, KeepAlive: 30 * time.Second}
+	if !cfg.allowPrivate {
+		dialer.Control = checkPublicAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: webhookTimeout, Transport: transport}
+}
+
+// checkPublicAddress is a net.Dialer Control function refusing connections to
+// non-public addresses.
+func checkPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, ip)
+	}
+	return nil
+}
+
+// PublicHost reports whether host, taken from a tenant supplied URL, can be a
+// public address. IP literals must be public and localhost names are refused;
+// other names are checked again when they are dialed, since they may resolve
+// anywhere.
+func PublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return publicAddress(ip)
+	}
+	return true
+}
+
+func publicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+type deliveryKey struct{}
+
+// delivery is attached to ctx by WithDeliveryID and shared by every attempt
+// of the delivery.
+type delivery struct {
+	id string
+
+	mu sync.Mutex
+	// chunksSent and threadTS record how far a Slack message split into
+	// chunks got, so a retry resumes after the last chunk posted.
+	chunksSent int
+	threadTS   string
+}
+
+// WithDeliveryID attaches the ID of the message being delivered to ctx so that
+// providers can send the same ID on every retry of a delivery, and resume a
+// message posted in several requests where the failed attempt stopped. Retry
+// every attempt with the returned ctx.
+func WithDeliveryID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, deliveryKey{}, &delivery{id: id})
+}
+
+// DeliveryIDFromContext returns the delivery ID attached with WithDeliveryID.
+func DeliveryIDFromContext(ctx context.Context) (string, bool) {
+	d, _ := ctx.Value(deliveryKey{}).(*delivery)
+	id := d.deliveryID()
+	return id, id != ""
+}
+
+// deliveryFromContext returns the delivery attached with WithDeliveryID, or a
+// new one tracking a single attempt.
+func deliveryFromContext(ctx context.Context) *delivery {
+	if d, ok := ctx.Value(deliveryKey{}).(*delivery); ok {
+		return d
+	}
+	return &delivery{}
+}
+
+func (d *delivery) deliveryID() string {
+	if d == nil {
+		return ""
+	}
+	return d.id
+}
+
+// progress returns the chunks posted by earlier attempts and their thread.
+func (d *delivery) progress() (int, string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.chunksSent, d.threadTS
+}
+
+func (d *delivery) chunkSent(threadTS string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.chunksSent++
+	d.threadTS = threadTS
+}
+
+// postJSON posts payload to url and turns the failures callers can act on into typed errors.
+func postJSON(ctx context.Context, client *http.Client, provider, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", provider, err)
+	}
+	return post(ctx, client, provider, url, body, nil)
+}
+
+// post sends a JSON body with the extra headers given.
+func post(ctx context.Context, client *http.Client, provider, url string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", provider, withoutURL(err))
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := do(client, req, provider)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkWebhookResponse(resp, provider)
+}
+
+// do sends req, turning transport failures into typed errors. Addresses
+// refused by the dial check are not retried: the URL has to be changed.
+func do(client *http.Client, req *http.Request, provider string) (*http.Response, error) {
+	resp, err := client.Do(req)
+	switch {
+	case errors.Is(err, errBlockedAddress):
+		// The address the URL resolved to stays in the log, not the error
+		// returned to the tenant.
+		slog.Warn("Webhook request refused", "provider", provider, "error", err)
+		return nil, apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonDestinationBlocked,
+			provider+" URL must resolve to a public address")
+	case err != nil:
+		return nil, apperrors.New(apperrors.ErrUnavailable, apperrors.ReasonProviderUnavailable, provider+" is unreachable").
+			WithCause(withoutURL(err))
+	}
+	return resp, nil
+}
+
+// withoutURL returns the error wrapped by a *url.Error, dropping its URL: for
+// the webhook providers the URL is the credential.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// checkWebhookResponse turns an error status into a typed error. The response
+// body is logged, not returned: it comes from a tenant supplied URL and may
+// hold anything that URL serves.
+func checkWebhookResponse(resp *http.Response, provider string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	slog.Warn("Webhook request failed", "provider", provider, "status", resp.StatusCode, "body", string(bytes.TrimSpace(respBody)))
+	cause := fmt.Errorf("%s responded with status %d", provider, resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return apperrors.New(apperrors.ErrResourceExhausted, apperrors.ReasonRateLimited, provider+" rate limit exceeded").
+			WithRetryAfter(parseRetryAfter(resp.Header.Get("Retry-After"))).
+			WithCause(cause)
+	case resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusGone:
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonInvalidAuth, provider+" webhook is not valid").
+			WithMetadata("http_status", strconv.Itoa(resp.StatusCode)).
+			WithCause(cause)
+	case resp.StatusCode >= 500:
+		return apperrors.New(apperrors.ErrUnavailable, apperrors.ReasonProviderUnavailable, provider+" is unavailable").
+			WithMetadata("http_status", strconv.Itoa(resp.StatusCode)).
+			WithCause(cause)
+	default:
+		return apperrors.New(apperrors.ErrInternal, "", cause.Error()).
+			WithMetadata("http_status", strconv.Itoa(resp.StatusCode))
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in (possibly fractional) seconds.
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return apperrors.InvalidArgument(apperrors.FieldViolation{
+			Field:       "credentials",
+			Description: "credentials must be an http(s) webhook URL",
+		})
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookStandIn records the JSON bodies posted to it and answers with status.
+func webhookStandIn(t *testing.T, status int, header http.Header, received *[]map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			*received = append(*received, body)
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTeamsClient_SendMessage(t *testing.T) {
+	ctx := context.Background()
+	var received []map[string]any
+	srv := webhookStandIn(t, http.StatusOK, nil, &received)
+
+	teams := services.NewTeamsClient(services.WithPrivateNetworks())
+
+	channelID, err := teams.ResolveChannelID(ctx, srv.URL, "General")
+	require.NoError(t, err)
+	require.Equal(t, "General", channelID)
+
+	require.NoError(t, teams.SendMessage(ctx, srv.URL, channelID, "deploy finished"))
+	require.Len(t, received, 1)
+	require.Equal(t, "MessageCard", received[0]["@type"])
+	require.Equal(t, "deploy finished", received[0]["text"])
+}
+
+func TestTeamsClient_InvalidWebhookURL(t *testing.T) {
+	_, err := services.NewTeamsClient(services.WithPrivateNetworks()).ResolveChannelID(context.Background(), "not a url", "General")
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestDiscordClient_ResolveAndSend(t *testing.T) {
+	ctx := context.Background()
+	var received []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "hook-1", "channel_id": "998877"})
+		case http.MethodPost:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received = append(received, body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	discord := services.NewDiscordClient(services.WithPrivateNetworks())
+
+	channelID, err := discord.ResolveChannelID(ctx, srv.URL, "alerts")
+	require.NoError(t, err)
+	require.Equal(t, "998877", channelID)
+
+	require.NoError(t, discord.SendMessage(ctx, srv.URL, channelID, "disk almost full"))
+	require.Len(t, received, 1)
+	require.Equal(t, "disk almost full", received[0]["content"])
+}
+
+func TestDiscordClient_RateLimited(t *testing.T) {
+	var received []map[string]any
+	srv := webhookStandIn(t, http.StatusTooManyRequests, http.Header{"Retry-After": {"1.5"}}, &received)
+
+	err := services.NewDiscordClient(services.WithPrivateNetworks()).SendMessage(context.Background(), srv.URL, "998877", "hello")
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	require.Equal(t, errors.ReasonRateLimited, typed.Reason)
+	require.Equal(t, 1500*time.Millisecond, typed.RetryAfter)
+}
+
+func TestMattermostClient_SendMessage(t *testing.T) {
+	ctx := context.Background()
+	var received []map[string]any
+	srv := webhookStandIn(t, http.StatusOK, nil, &received)
+
+	mattermost := services.NewMattermostClient(services.WithPrivateNetworks())
+
+	channelID, err := mattermost.ResolveChannelID(ctx, srv.URL, "town-square")
+	require.NoError(t, err)
+
+	require.NoError(t, mattermost.SendMessage(ctx, srv.URL, channelID, "build is green"))
+	require.Len(t, received, 1)
+	require.Equal(t, "build is green", received[0]["text"])
+	require.Equal(t, "town-square", received[0]["channel"])
+}
+
+func TestMattermostClient_RevokedWebhook(t *testing.T) {
+	var received []map[string]any
+	srv := webhookStandIn(t, http.StatusNotFound, nil, &received)
+
+	err := services.NewMattermostClient(services.WithPrivateNetworks()).SendMessage(context.Background(), srv.URL, "town-square", "hello")
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+}
+
+func TestWebhookClients_RefusePrivateAddresses(t *testing.T) {
+	ctx := context.Background()
+	var received []map[string]any
+	srv := webhookStandIn(t, http.StatusOK, nil, &received)
+	localhost := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	for _, url := range []string{srv.URL, localhost} {
+		err := services.NewTeamsClient().SendMessage(ctx, url, "General", "hello")
+		require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+		require.Equal(t, errors.ReasonDestinationBlocked, reasonOf(err))
+		require.NotContains(t, err.Error(), "127.0.0.1")
+
+		_, err = services.NewDiscordClient().ResolveChannelID(ctx, url, "alerts")
+		require.Equal(t, errors.ReasonDestinationBlocked, reasonOf(err))
+	}
+	require.Empty(t, received)
+
+	for _, url := range []string{"http://169.254.169.254/latest/meta-data", "http://10.0.0.8/hook", "http://[::1]:9/hook", "http://100.64.0.1/hook"} {
+		err := services.NewMattermostClient().SendMessage(ctx, url, "town-square", "hello")
+		require.Equal(t, errors.ReasonDestinationBlocked, reasonOf(err), url)
+	}
+}
+
+func TestPublicHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"hooks.example.com":  true,
+		"93.184.216.34":      true,
+		"localhost":          false,
+		"api.localhost.":     false,
+		"127.0.0.1":          false,
+		"10.0.0.8":           false,
+		"169.254.169.254":    false,
+		"::1":                false,
+		"[fd00::1]":          false,
+		"::ffff:192.168.1.1": false,
+	} {
+		require.Equal(t, want, services.PublicHost(host), host)
+	}
+}
+
+func TestWebhookClients_KeepResponseBodyOutOfErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal-admin-token=abc123", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	err := services.NewTeamsClient(services.WithPrivateNetworks()).SendMessage(context.Background(), srv.URL, "General", "hello")
+	require.ErrorContains(t, err, "status 400")
+	require.NotContains(t, err.Error(), "abc123")
+}
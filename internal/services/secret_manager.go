@@ -9,33 +9,65 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 )
 
-// SecretsManager defines the methods for storing and retrieving secrets (e.g., Slack tokens).
+// AWSSecretsManager defines the methods for storing and retrieving connector
+// credentials (e.g., Slack tokens or webhook URLs).
 type AWSSecretsManager interface {
-	StoreSlackToken(ctx context.Context, connectorID, token string) error
-	GetSlackToken(ctx context.Context, connectorID string) (string, error)
-	DeleteSlackToken(ctx context.Context, connectorID string) error
+	StoreCredentials(ctx context.Context, connectorID, credentials string) error
+	GetCredentials(ctx context.Context, connectorID string) (string, error)
+	DeleteCredentials(ctx context.Context, connectorID string) error
 }
 
 type awsSecretManager struct {
-	sm *secretsmanager.SecretsManager
+	sm       *secretsmanager.SecretsManager
+	endpoint string
+	kmsKeyID string
 }
 
-func NewSecretsManager(sess *session.Session) AWSSecretsManager {
-	return &awsSecretManager{
-		sm: secretsmanager.New(sess),
+// SecretsManagerOption configures the client created by NewSecretsManager.
+type SecretsManagerOption func(*awsSecretManager)
+
+// WithSecretsManagerEndpoint sends requests to endpoint instead of the
+// regional Secrets Manager endpoint, e.g. to use LocalStack. Empty keeps the
+// default.
+func WithSecretsManagerEndpoint(endpoint string) SecretsManagerOption {
+	return func(s *awsSecretManager) {
+		s.endpoint = endpoint
+	}
+}
+
+// WithKMSKeyID encrypts the secrets created or updated with the KMS key
+// (ID, ARN or alias) instead of the AWS managed key. Empty keeps the default.
+func WithKMSKeyID(keyID string) SecretsManagerOption {
+	return func(s *awsSecretManager) {
+		s.kmsKeyID = keyID
 	}
 }
 
-func (s *awsSecretManager) StoreSlackToken(ctx context.Context, connectorID, token string) error {
-	secretName := slackSecretName(connectorID)
+func NewSecretsManager(sess *session.Session, opts ...SecretsManagerOption) AWSSecretsManager {
+	s := &awsSecretManager{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	cfg := aws.NewConfig()
+	if s.endpoint != "" {
+		cfg = cfg.WithEndpoint(s.endpoint)
+	}
+	s.sm = secretsmanager.New(sess, cfg)
+	return s
+}
+
+func (s *awsSecretManager) StoreCredentials(ctx context.Context, connectorID, credentials string) error {
+	secretName := connectorSecretName(connectorID)
 	_, err := s.sm.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
 		Name:         aws.String(secretName),
-		SecretString: aws.String(token),
+		SecretString: aws.String(credentials),
+		KmsKeyId:     s.kmsKey(),
 	})
 	if err != nil {
 		_, updateErr := s.sm.UpdateSecretWithContext(ctx, &secretsmanager.UpdateSecretInput{
 			SecretId:     aws.String(secretName),
-			SecretString: aws.String(token),
+			SecretString: aws.String(credentials),
+			KmsKeyId:     s.kmsKey(),
 		})
 		if updateErr != nil {
 			return fmt.Errorf("failed to create or update secret: %w", updateErr)
@@ -44,8 +76,8 @@ func (s *awsSecretManager) StoreSlackToken(ctx context.Context, connectorID, tok
 	return nil
 }
 
-func (s *awsSecretManager) GetSlackToken(ctx context.Context, connectorID string) (string, error) {
-	secretName := slackSecretName(connectorID)
+func (s *awsSecretManager) GetCredentials(ctx context.Context, connectorID string) (string, error) {
+	secretName := connectorSecretName(connectorID)
 	out, err := s.sm.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretName),
 	})
@@ -55,8 +87,8 @@ func (s *awsSecretManager) GetSlackToken(ctx context.Context, connectorID string
 	return aws.StringValue(out.SecretString), nil
 }
 
-func (s *awsSecretManager) DeleteSlackToken(ctx context.Context, connectorID string) error {
-	secretName := slackSecretName(connectorID)
+func (s *awsSecretManager) DeleteCredentials(ctx context.Context, connectorID string) error {
+	secretName := connectorSecretName(connectorID)
 	_, err := s.sm.DeleteSecretWithContext(ctx, &secretsmanager.DeleteSecretInput{
 		SecretId:                   aws.String(secretName),
 		ForceDeleteWithoutRecovery: aws.Bool(true),
@@ -67,6 +99,15 @@ func (s *awsSecretManager) DeleteSlackToken(ctx context.Context, connectorID str
 	return nil
 }
 
-func slackSecretName(connectorID string) string {
+func (s *awsSecretManager) kmsKey() *string {
+	if s.kmsKeyID == "" {
+		return nil
+	}
+	return aws.String(s.kmsKeyID)
+}
+
+// connectorSecretName keeps the original Slack prefix so secrets created before
+// other providers were supported still resolve.
+func connectorSecretName(connectorID string) string {
 	return fmt.Sprintf("slack-connector/%s", connectorID)
 }
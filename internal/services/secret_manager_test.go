@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/internal/services"
+)
+
+func TestSecretsManager_StoreGetDelete(t *testing.T) {
+	ctx := context.Background()
+	standIn := connectortest.NewSecretsManagerServer()
+	defer standIn.Close()
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(standIn.URL()),
+	})
+	require.NoError(t, err)
+	secrets := services.NewSecretsManager(sess)
+
+	require.NoError(t, secrets.StoreCredentials(ctx, "conn-1", "xoxb-1"))
+	// Storing again updates the existing secret.
+	require.NoError(t, secrets.StoreCredentials(ctx, "conn-1", "xoxb-2"))
+	require.Equal(t, 1, standIn.Calls("UpdateSecret"))
+
+	got, err := secrets.GetCredentials(ctx, "conn-1")
+	require.NoError(t, err)
+	require.Equal(t, "xoxb-2", got)
+	stored, _ := standIn.Secret("slack-connector/conn-1")
+	require.Equal(t, "xoxb-2", stored)
+
+	require.NoError(t, secrets.DeleteCredentials(ctx, "conn-1"))
+	_, err = secrets.GetCredentials(ctx, "conn-1")
+	require.Error(t, err)
+}
+
+func TestSecretsManager_KMSKeyAndEndpoint(t *testing.T) {
+	ctx := context.Background()
+	standIn := connectortest.NewSecretsManagerServer()
+	defer standIn.Close()
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("test", "test", ""),
+		Region:      aws.String("us-east-1"),
+	})
+	require.NoError(t, err)
+	secrets := services.NewSecretsManager(sess,
+		services.WithSecretsManagerEndpoint(standIn.URL()),
+		services.WithKMSKeyID("alias/connector-secrets"),
+	)
+
+	require.NoError(t, secrets.StoreCredentials(ctx, "conn-1", "xoxb-1"))
+	require.Equal(t, "alias/connector-secrets", standIn.KMSKeyID("slack-connector/conn-1"))
+	require.NoError(t, secrets.StoreCredentials(ctx, "conn-1", "xoxb-2"))
+	require.Equal(t, 1, standIn.Calls("UpdateSecret"))
+	require.Equal(t, "alias/connector-secrets", standIn.KMSKeyID("slack-connector/conn-1"))
+}
@@ -2,28 +2,60 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/slack-go/slack"
 
-	"github.com/iBoBoTi/connector-service/internal/repository"
+	apperrors "github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/iBoBoTi/connector-service/pkg/mrkdwn"
 )
 
+// SlackClient is the Slack implementation of Messenger.
 type SlackClient interface {
-	ResolveChannelID(ctx context.Context, token, channelName string) (string, error)
-	SendMessage(ctx context.Context, token, channelID, message string) error
+	Messenger
+	Verifier
 }
 
-type slackClient struct{}
+type slackClient struct {
+	apiURL string
+}
+
+// SlackOption configures the Slack client.
+type SlackOption func(*slackClient)
+
+// WithSlackAPIURL replaces the base URL of the Slack Web API, e.g. to point the
+// client at a fake Slack server in tests.
+func WithSlackAPIURL(apiURL string) SlackOption {
+	return func(c *slackClient) {
+		if apiURL != "" && !strings.HasSuffix(apiURL, "/") {
+			apiURL += "/"
+		}
+		c.apiURL = apiURL
+	}
+}
 
-func NewSlackClient() SlackClient {
-	return &slackClient{}
+func NewSlackClient(opts ...SlackOption) SlackClient {
+	c := &slackClient{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// api returns a Slack Web API client authenticated with token.
+func (c *slackClient) api(token string) *slack.Client {
+	if c.apiURL == "" {
+		return slack.New(token)
+	}
+	return slack.New(token, slack.OptionAPIURL(c.apiURL))
 }
 
 // ResolveChannelID attempts to find a channel with the given name.
 // Returns its channel ID if found, otherwise an error.
 func (c *slackClient) ResolveChannelID(ctx context.Context, token, channelName string) (string, error) {
-	client := slack.New(token)
+	client := c.api(token)
 
 	params := &slack.GetConversationsParameters{
 		Limit:           200,
@@ -36,7 +68,7 @@ func (c *slackClient) ResolveChannelID(ctx context.Context, token, channelName s
 		channels, cursor, err := client.GetConversationsContext(ctx, params)
 
 		if err != nil {
-			return "", fmt.Errorf("failed to list slack channels: %w", err)
+			return "", fmt.Errorf("failed to list slack channels: %w", classifySlackError(err, channelName))
 		}
 
 		for _, ch := range channels {
@@ -51,43 +83,93 @@ func (c *slackClient) ResolveChannelID(ctx context.Context, token, channelName s
 		params.Cursor = cursor
 	}
 
-	return "", fmt.Errorf("channel '%s' not found", channelName)
+	return "", apperrors.NotFound(apperrors.ReasonChannelNotFound, "slack_channel", channelName)
 }
 
-// SendMessage posts a simple text message to the given Slack channel ID.
-func (c *slackClient) SendMessage(ctx context.Context, token, channelID, message string) error {
-	client := slack.New(token)
+// VerifyChannel checks the token with auth.test, then that the channel still
+// exists and is not archived.
+func (c *slackClient) VerifyChannel(ctx context.Context, token, channelID string) error {
+	client := c.api(token)
 
-	_, _, err := client.PostMessageContext(ctx, channelID,
-		slack.MsgOptionText(message, false),
-	)
+	if _, err := client.AuthTestContext(ctx); err != nil {
+		return fmt.Errorf("failed to verify slack token: %w", classifySlackError(err, channelID))
+	}
+
+	ch, err := client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: channelID})
 	if err != nil {
-		return fmt.Errorf("failed to send Slack message to channelID=%s: %w", channelID, err)
+		return fmt.Errorf("failed to look up slack channel: %w", classifySlackError(err, channelID))
+	}
+	if ch.IsArchived {
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonNotInChannel, "slack channel is archived").
+			WithResource("slack_channel", channelID)
 	}
 	return nil
 }
 
-func SendMessage(
-	ctx context.Context,
-	connectorID string,
-	message string,
-	secretsManager AWSSecretsManager,
-	slackClient SlackClient,
-	connectorRepository repository.ConnectorRepository,
-) error {
-	connector, err := connectorRepository.GetByID(ctx, connectorID)
-	if err != nil {
-		return fmt.Errorf("error getting connector: %w", err)
+// SendMessage converts message from Markdown to Slack mrkdwn and posts it to the
+// given Slack channel ID. Messages longer than Slack's limit are split into
+// ordered chunks; every chunk after the first is posted as a reply in its thread.
+// When a chunk fails, a retry with the same ctx (see WithDeliveryID) resumes
+// from that chunk in the same thread instead of posting the message again.
+func (c *slackClient) SendMessage(ctx context.Context, token, channelID, message string) error {
+	client := c.api(token)
+
+	chunks := mrkdwn.Split(mrkdwn.Convert(message), mrkdwn.MaxMessageLength)
+
+	d := deliveryFromContext(ctx)
+	sent, threadTS := d.progress()
+	for i := sent; i < len(chunks); i++ {
+		opts := []slack.MsgOption{slack.MsgOptionText(chunks[i], false)}
+		if threadTS != "" {
+			opts = append(opts, slack.MsgOptionTS(threadTS))
+		}
+
+		_, ts, err := client.PostMessageContext(ctx, channelID, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to send Slack message chunk %d/%d to channelID=%s: %w",
+				i+1, len(chunks), channelID, classifySlackError(err, channelID))
+		}
+		if threadTS == "" {
+			threadTS = ts
+		}
+		d.chunkSent(threadTS)
 	}
+	return nil
+}
 
-	token, err := secretsManager.GetSlackToken(ctx, "connector/"+connectorID+"/slack-token")
-	if err != nil {
-		return fmt.Errorf("error retrieving secret: %w", err)
+// classifySlackError turns the Slack API failures callers can act on into typed
+// errors. Anything else is returned unchanged.
+func classifySlackError(err error, channel string) error {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return apperrors.New(apperrors.ErrResourceExhausted, apperrors.ReasonRateLimited, "slack rate limit exceeded").
+			WithRetryAfter(rateLimited.RetryAfter).
+			WithCause(err)
 	}
 
-	if err := slackClient.SendMessage(ctx, token, connector.DefaultChannelID, message); err != nil {
-		return fmt.Errorf("error sending message to channel: %w", err)
+	var slackErr slack.SlackErrorResponse
+	if !errors.As(err, &slackErr) {
+		return err
+	}
+
+	switch slackErr.Err {
+	case "channel_not_found":
+		return apperrors.NotFound(apperrors.ReasonChannelNotFound, "slack_channel", channel).
+			WithCause(err)
+	case "token_revoked", "token_expired":
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonTokenRevoked, "slack token has been revoked").
+			WithMetadata("slack_error", slackErr.Err).
+			WithCause(err)
+	case "invalid_auth", "not_authed", "account_inactive":
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonInvalidAuth, "slack token is not valid").
+			WithMetadata("slack_error", slackErr.Err).
+			WithCause(err)
+	case "not_in_channel", "is_archived":
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonNotInChannel, "slack app cannot post to channel").
+			WithMetadata("slack_error", slackErr.Err).
+			WithResource("slack_channel", channel).
+			WithCause(err)
+	default:
+		return err
 	}
-	
-	return nil
 }
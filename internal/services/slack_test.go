@@ -0,0 +1,175 @@
+package services_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/iBoBoTi/connector-service/pkg/mrkdwn"
+)
+
+func newFakeSlack(t *testing.T) (*connectortest.SlackServer, services.SlackClient) {
+	t.Helper()
+	fake := connectortest.NewSlackServer()
+	t.Cleanup(fake.Close)
+	return fake, services.NewSlackClient(services.WithSlackAPIURL(fake.URL()))
+}
+
+func TestSlackClient_ResolveChannelIDAcrossPages(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	for i := 0; i < 250; i++ {
+		fake.AddChannel(fmt.Sprintf("team-%d", i))
+	}
+	archived := fake.AddChannel("old-alerts")
+	fake.ArchiveChannel(archived)
+	alerts := fake.AddPrivateChannel("alerts")
+
+	id, err := slack.ResolveChannelID(context.Background(), "xoxb-1", "alerts")
+	require.NoError(t, err)
+	require.Equal(t, alerts, id)
+	require.Equal(t, 2, fake.Calls("conversations.list"))
+
+	_, err = slack.ResolveChannelID(context.Background(), "xoxb-1", "old-alerts")
+	require.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestSlackClient_SendMessageSplitsIntoThread(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	general := fake.AddChannel("general")
+
+	text := strings.Repeat("word ", mrkdwn.MaxMessageLength/5+10)
+	require.NoError(t, slack.SendMessage(context.Background(), "xoxb-1", general, text))
+
+	messages := fake.MessagesIn(general)
+	require.Len(t, messages, 2)
+	require.Empty(t, messages[0].ThreadTS)
+	require.Equal(t, messages[0].TS, messages[1].ThreadTS)
+	require.Equal(t, "xoxb-1", messages[0].Token)
+}
+
+func TestSlackClient_RetryResumesFromFailedChunk(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	general := fake.AddChannel("general")
+	fake.RateLimitAfter("chat.postMessage", 1, 1, time.Second)
+
+	text := strings.Repeat("word ", 2*mrkdwn.MaxMessageLength/5+10)
+	ctx := services.WithDeliveryID(context.Background(), "delivery-1")
+	err := slack.SendMessage(ctx, "xoxb-1", general, text)
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	require.Len(t, fake.MessagesIn(general), 1)
+
+	require.NoError(t, slack.SendMessage(ctx, "xoxb-1", general, text))
+	messages := fake.MessagesIn(general)
+	require.Len(t, messages, 3)
+	require.Empty(t, messages[0].ThreadTS)
+	require.Equal(t, messages[0].TS, messages[1].ThreadTS)
+	require.Equal(t, messages[0].TS, messages[2].ThreadTS)
+}
+
+func TestSlackClient_RateLimited(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	general := fake.AddChannel("general")
+	fake.RateLimit("chat.postMessage", 1, 30*time.Second)
+
+	err := slack.SendMessage(context.Background(), "xoxb-1", general, "hello")
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	var typed *errors.Error
+	require.True(t, stderrors.As(err, &typed))
+	require.Equal(t, 30*time.Second, typed.RetryAfter)
+
+	require.NoError(t, slack.SendMessage(context.Background(), "xoxb-1", general, "hello"))
+	require.Len(t, fake.Messages(), 1)
+}
+
+func TestSlackClient_ClassifiesErrors(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	general := fake.AddChannel("general")
+	fake.RevokeToken("xoxb-revoked")
+	fake.FailNext("chat.postMessage", "not_in_channel")
+
+	err := slack.SendMessage(context.Background(), "xoxb-1", general, "hello")
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+	require.Equal(t, errors.ReasonNotInChannel, reasonOf(err))
+
+	err = slack.SendMessage(context.Background(), "xoxb-revoked", general, "hello")
+	require.Equal(t, errors.ReasonTokenRevoked, reasonOf(err))
+
+	err = slack.SendMessage(context.Background(), "xoxb-1", "C99999999", "hello")
+	require.ErrorIs(t, err, errors.ErrNotFound)
+	require.Empty(t, fake.Messages())
+}
+
+func TestSlackClient_VerifyChannel(t *testing.T) {
+	fake, slack := newFakeSlack(t)
+	general := fake.AddChannel("general")
+
+	require.NoError(t, slack.VerifyChannel(context.Background(), "xoxb-1", general))
+
+	fake.ArchiveChannel(general)
+	err := slack.VerifyChannel(context.Background(), "xoxb-1", general)
+	require.Equal(t, errors.ReasonNotInChannel, reasonOf(err))
+
+	fake.RevokeToken("xoxb-1")
+	err = slack.VerifyChannel(context.Background(), "xoxb-1", general)
+	require.Equal(t, errors.ReasonTokenRevoked, reasonOf(err))
+}
+
+func reasonOf(err error) string {
+	var typed *errors.Error
+	if stderrors.As(err, &typed) {
+		return typed.Reason
+	}
+	return ""
+}
+
+func TestSlackClient_ClassifySlackErrorCodes(t *testing.T) {
+	tests := []struct {
+		slackError string
+		kind       error
+		reason     string
+		resource   bool
+	}{
+		{"channel_not_found", errors.ErrNotFound, errors.ReasonChannelNotFound, true},
+		{"token_revoked", errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, false},
+		{"token_expired", errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, false},
+		{"invalid_auth", errors.ErrFailedPrecondition, errors.ReasonInvalidAuth, false},
+		{"not_authed", errors.ErrFailedPrecondition, errors.ReasonInvalidAuth, false},
+		{"account_inactive", errors.ErrFailedPrecondition, errors.ReasonInvalidAuth, false},
+		{"not_in_channel", errors.ErrFailedPrecondition, errors.ReasonNotInChannel, true},
+		{"is_archived", errors.ErrFailedPrecondition, errors.ReasonNotInChannel, true},
+		{"msg_too_long", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.slackError, func(t *testing.T) {
+			fake, slack := newFakeSlack(t)
+			general := fake.AddChannel("general")
+			fake.FailNext("chat.postMessage", tt.slackError)
+
+			err := slack.SendMessage(context.Background(), "xoxb-1", general, "hello")
+			require.ErrorContains(t, err, tt.slackError)
+
+			var typed *errors.Error
+			if tt.kind == nil {
+				require.False(t, stderrors.As(err, &typed))
+				return
+			}
+			require.True(t, stderrors.As(err, &typed))
+			require.ErrorIs(t, err, tt.kind)
+			require.Equal(t, tt.reason, typed.Reason)
+			if tt.kind != errors.ErrNotFound {
+				require.Equal(t, tt.slackError, typed.Metadata["slack_error"])
+			}
+			if tt.resource {
+				require.Equal(t, &errors.ResourceInfo{Type: "slack_channel", Name: general}, typed.Resource)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// SMTP TLS modes.
+const (
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLSImplicit = "implicit"
+	SMTPTLSNone     = "none"
+)
+
+const smtpTimeout = 30 * time.Second
+
+// SMTPCredentials is stored as JSON in the secret store for SMTP connectors.
+type SMTPCredentials struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from"`
+	// TLS is one of starttls (default), implicit or none.
+	TLS string `json:"tls,omitempty"`
+}
+
+// Encode serializes the credentials for the secret store.
+func (c SMTPCredentials) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func decodeSMTPCredentials(raw string) (*SMTPCredentials, error) {
+	var c SMTPCredentials
+	if err := json.Unmarshal([]byte(raw), &c); err != nil {
+		return nil, smtpCredentialsError("credentials must be a JSON encoded SMTP configuration")
+	}
+	if c.Host == "" || c.From == "" {
+		return nil, smtpCredentialsError("smtp host and from address are required")
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return nil, smtpCredentialsError("smtp from address is not valid")
+	}
+	switch c.TLS {
+	case "":
+		c.TLS = SMTPTLSStartTLS
+	case SMTPTLSStartTLS, SMTPTLSImplicit, SMTPTLSNone:
+	default:
+		return nil, smtpCredentialsError("smtp tls must be one of starttls, implicit or none")
+	}
+	if c.Port == 0 {
+		c.Port = 587
+		if c.TLS == SMTPTLSImplicit {
+			c.Port = 465
+		}
+	}
+	return &c, nil
+}
+
+func smtpCredentialsError(description string) error {
+	return apperrors.InvalidArgument(apperrors.FieldViolation{Field: "credentials", Description: description})
+}
+
+type smtpClient struct {
+	dialer *net.Dialer
+}
+
+// NewSMTPClient returns a Messenger delivering messages by email. Like the
+// webhook clients it refuses to connect to non-public addresses unless
+// WithPrivateNetworks is given.
+func NewSMTPClient(opts ...WebhookOption) Messenger {
+	var cfg webhookClientConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	if !cfg.allowPrivate {
+		dialer.Control = checkPublicAddress
+	}
+	return &smtpClient{dialer: dialer}
+}
+
+// ResolveChannelID validates the credentials and the comma separated recipient
+// list, returning the normalized list as the connector's channel.
+func (c *smtpClient) ResolveChannelID(ctx context.Context, credentials, recipients string) (string, error) {
+	if _, err := decodeSMTPCredentials(credentials); err != nil {
+		return "", err
+	}
+	addrs, err := parseRecipients(recipients)
+	if err != nil {
+		return "", err
+	}
+	list := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		list = append(list, a.Address)
+	}
+	return strings.Join(list, ", "), nil
+}
+
+// SendMessage emails message to the recipients as a multipart/alternative body
+// with plain text and HTML parts.
+func (c *smtpClient) SendMessage(ctx context.Context, credentials, recipients, message string) error {
+	creds, err := decodeSMTPCredentials(credentials)
+	if err != nil {
+		return err
+	}
+	to, err := parseRecipients(recipients)
+	if err != nil {
+		return err
+	}
+
+	// The address was validated with the credentials. The display name only
+	// belongs in the From header; MAIL FROM takes the bare address.
+	from, _ := mail.ParseAddress(creds.From)
+	body, err := buildEmail(from.String(), to, message)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	client, err := dialSMTP(ctx, c.dialer, creds)
+	switch {
+	case errors.Is(err, errBlockedAddress):
+		slog.Warn("SMTP connection refused", "error", err)
+		return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonDestinationBlocked,
+			"smtp host must resolve to a public address")
+	case err != nil:
+		return apperrors.New(apperrors.ErrUnavailable, apperrors.ReasonProviderUnavailable, "smtp server is unreachable").
+			WithCause(err)
+	}
+	defer client.Close()
+
+	if creds.Username != "" {
+		// Sending without the configured login could relay through an open
+		// server the tenant did not mean to use.
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonInvalidAuth, "smtp server does not support authentication")
+		}
+		if err := client.Auth(smtp.PlainAuth("", creds.Username, creds.Password, creds.Host)); err != nil {
+			return apperrors.New(apperrors.ErrFailedPrecondition, apperrors.ReasonInvalidAuth, "smtp authentication failed").
+				WithCause(err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM rejected: %w", err)
+	}
+	for _, addr := range to {
+		if err := client.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s rejected: %w", addr.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA rejected: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func dialSMTP(ctx context.Context, dialer *net.Dialer, creds *SMTPCredentials) (*smtp.Client, error) {
+	addr := net.JoinHostPort(creds.Host, strconv.Itoa(creds.Port))
+	tlsConfig := &tls.Config{ServerName: creds.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	var err error
+	if creds.TLS == SMTPTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, creds.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if creds.TLS == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func parseRecipients(recipients string) ([]*mail.Address, error) {
+	addrs, err := mail.ParseAddressList(recipients)
+	if err != nil || len(addrs) == 0 {
+		return nil, apperrors.InvalidArgument(apperrors.FieldViolation{
+			Field:       "default_channel_name",
+			Description: "default_channel_name must be a comma separated list of email addresses",
+		})
+	}
+	return addrs, nil
+}
+
+// buildEmail renders an RFC 5322 message with plain text and HTML alternatives.
+// The subject is the first line of the message.
+func buildEmail(from string, to []*mail.Address, message string) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	recipients := make([]string, 0, len(to))
+	for _, a := range to {
+		recipients = append(recipients, a.String())
+	}
+
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", strings.Join(recipients, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", emailSubject(message))},
+		{"Date", time.Now().UTC().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + uuid.NewString() + "@connector-service>"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `multipart/alternative; boundary="` + mw.Boundary() + `"`},
+	}
+	var head bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&head, "%s: %s\r\n", h.key, h.value)
+	}
+	head.WriteString("\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", message},
+		{"text/html; charset=utf-8", emailHTML(message)},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(p.body)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return append(head.Bytes(), buf.Bytes()...), nil
+}
+
+func emailSubject(message string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	subject = strings.TrimSpace(subject)
+	if r := []rune(subject); len(r) > 78 {
+		subject = string(r[:77]) + "…"
+	}
+	return subject
+}
+
+func emailHTML(message string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html><html><body>")
+	for _, para := range strings.Split(strings.TrimSpace(message), "\n\n") {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>"))
+		b.WriteString("</p>")
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
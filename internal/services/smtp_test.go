@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStandIn is a minimal in-process SMTP server recording the last delivery.
+type smtpStandIn struct {
+	ln net.Listener
+
+	// noAuth leaves AUTH out of the EHLO reply.
+	noAuth bool
+
+	mu    sync.Mutex
+	auth  string
+	from  string
+	rcpts []string
+	data  string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpStandIn{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStandIn) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP stand-in")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		s.mu.Lock()
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if s.noAuth {
+				_ = tp.PrintfLine("250 localhost")
+				break
+			}
+			_ = tp.PrintfLine("250-localhost")
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			s.auth = arg
+			_ = tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = arg
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.rcpts = append(s.rcpts, arg)
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, _ := io.ReadAll(tp.DotReader())
+			s.data = string(data)
+			_ = tp.PrintfLine("250 OK queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			_ = tp.PrintfLine("502 Command not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestSMTPClient_SendMessage(t *testing.T) {
+	ctx := context.Background()
+	srv := newSMTPStandIn(t)
+
+	creds, err := services.SMTPCredentials{
+		Host:     "127.0.0.1",
+		Port:     srv.port(),
+		Username: "alerts",
+		Password: "secret",
+		From:     "Alerts <alerts@example.com>",
+		TLS:      services.SMTPTLSNone,
+	}.Encode()
+	require.NoError(t, err)
+
+	client := services.NewSMTPClient(services.WithPrivateNetworks())
+
+	recipients, err := client.ResolveChannelID(ctx, creds, "Ops <ops@example.com>, oncall@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "ops@example.com, oncall@example.com", recipients)
+
+	require.NoError(t, client.SendMessage(ctx, creds, recipients, "Disk usage high\n\nNode <db-1> is at 91%"))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.NotEmpty(t, srv.auth)
+	require.Equal(t, "FROM:<alerts@example.com>", srv.from)
+	require.Equal(t, []string{"TO:<ops@example.com>", "TO:<oncall@example.com>"}, srv.rcpts)
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(srv.data)))
+	require.NoError(t, err)
+	require.Equal(t, "Disk usage high", msg.Header.Get("Subject"))
+	require.Equal(t, `"Alerts" <alerts@example.com>`, msg.Header.Get("From"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+	require.Equal(t, "Disk usage high\n\nNode <db-1> is at 91%", parts["text/plain"])
+	require.Contains(t, parts["text/html"], "<p>Node &lt;db-1&gt; is at 91%</p>")
+}
+
+func TestSMTPClient_StartTLSRequired(t *testing.T) {
+	srv := newSMTPStandIn(t)
+
+	creds, err := services.SMTPCredentials{
+		Host: "127.0.0.1",
+		Port: srv.port(),
+		From: "alerts@example.com",
+		TLS:  services.SMTPTLSStartTLS,
+	}.Encode()
+	require.NoError(t, err)
+
+	err = services.NewSMTPClient(services.WithPrivateNetworks()).SendMessage(context.Background(), creds, "ops@example.com", "hello")
+	require.ErrorIs(t, err, errors.ErrUnavailable)
+}
+
+func TestSMTPClient_RequiresAuthWhenUsernameIsSet(t *testing.T) {
+	srv := newSMTPStandIn(t)
+	srv.noAuth = true
+
+	creds, err := services.SMTPCredentials{
+		Host:     "127.0.0.1",
+		Port:     srv.port(),
+		Username: "alerts",
+		Password: "secret",
+		From:     "alerts@example.com",
+		TLS:      services.SMTPTLSNone,
+	}.Encode()
+	require.NoError(t, err)
+
+	err = services.NewSMTPClient(services.WithPrivateNetworks()).SendMessage(context.Background(), creds, "ops@example.com", "hello")
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Empty(t, srv.from)
+}
+
+func TestSMTPClient_RefusesNonPublicHosts(t *testing.T) {
+	srv := newSMTPStandIn(t)
+
+	for _, host := range []string{"127.0.0.1", "10.0.0.5"} {
+		t.Run(host, func(t *testing.T) {
+			creds, err := services.SMTPCredentials{Host: host, Port: srv.port(), From: "alerts@example.com", TLS: services.SMTPTLSNone}.Encode()
+			require.NoError(t, err)
+
+			err = services.NewSMTPClient().SendMessage(context.Background(), creds, "ops@example.com", "hello")
+			require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+			var typed *errors.Error
+			require.ErrorAs(t, err, &typed)
+			require.Equal(t, errors.ReasonDestinationBlocked, typed.Reason)
+			require.NotContains(t, err.Error(), host)
+		})
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Empty(t, srv.from)
+}
+
+func TestSMTPClient_InvalidRecipients(t *testing.T) {
+	creds, err := services.SMTPCredentials{Host: "127.0.0.1", Port: 2525, From: "alerts@example.com"}.Encode()
+	require.NoError(t, err)
+
+	_, err = services.NewSMTPClient(services.WithPrivateNetworks()).ResolveChannelID(context.Background(), creds, "not-an-address")
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	_, err = services.NewSMTPClient(services.WithPrivateNetworks()).ResolveChannelID(context.Background(), "{}", "ops@example.com")
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
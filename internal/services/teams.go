@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"net/http"
+)
+
+type teamsClient struct {
+	http *http.Client
+}
+
+// NewTeamsClient returns a Messenger posting to Microsoft Teams incoming webhooks.
+func NewTeamsClient(opts ...WebhookOption) Messenger {
+	return &teamsClient{http: newWebhookHTTPClient(opts)}
+}
+
+type teamsMessageCard struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Text    string `json:"text"`
+}
+
+// ResolveChannelID validates the webhook URL. Teams incoming webhooks are bound to a
+// single channel, so the channel name is kept as the connector's channel label.
+func (c *teamsClient) ResolveChannelID(ctx context.Context, webhookURL, channelName string) (string, error) {
+	if err := validateWebhookURL(webhookURL); err != nil {
+		return "", err
+	}
+	return channelName, nil
+}
+
+// SendMessage posts a simple MessageCard to the channel the webhook belongs to.
+func (c *teamsClient) SendMessage(ctx context.Context, webhookURL, channelID, message string) error {
+	return postJSON(ctx, c.http, "microsoft teams", webhookURL, teamsMessageCard{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Text:    message,
+	})
+}
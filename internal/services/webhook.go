@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/iBoBoTi/connector-service/pkg/webhook"
+)
+
+// WebhookCredentials is stored as JSON in the secret store for webhook connectors.
+type WebhookCredentials struct {
+	URL           string `json:"url"`
+	SigningSecret string `json:"signing_secret"`
+}
+
+// Encode serializes the credentials for the secret store.
+func (c WebhookCredentials) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func decodeWebhookCredentials(raw string) (*WebhookCredentials, error) {
+	var c WebhookCredentials
+	if err := json.Unmarshal([]byte(raw), &c); err != nil {
+		return nil, apperrors.InvalidArgument(apperrors.FieldViolation{
+			Field:       "credentials",
+			Description: "credentials must be a JSON encoded webhook configuration",
+		})
+	}
+	if err := validateWebhookURL(c.URL); err != nil {
+		return nil, err
+	}
+	if c.SigningSecret == "" {
+		return nil, apperrors.InvalidArgument(apperrors.FieldViolation{
+			Field:       "credentials",
+			Description: "webhook signing secret is required",
+		})
+	}
+	return &c, nil
+}
+
+// WebhookEnvelope is the JSON body posted to webhook connectors.
+type WebhookEnvelope struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Channel   string `json:"channel,omitempty"`
+	Text      string `json:"text"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type webhookClient struct {
+	http *http.Client
+	now  func() time.Time
+}
+
+// NewWebhookClient returns a Messenger posting signed JSON envelopes to a tenant's own endpoint.
+func NewWebhookClient(opts ...WebhookOption) Messenger {
+	return &webhookClient{http: newWebhookHTTPClient(opts), now: time.Now}
+}
+
+// ResolveChannelID validates the credentials. The channel name is passed through
+// as a free-form label in every envelope.
+func (c *webhookClient) ResolveChannelID(ctx context.Context, credentials, channelName string) (string, error) {
+	if _, err := decodeWebhookCredentials(credentials); err != nil {
+		return "", err
+	}
+	return channelName, nil
+}
+
+// SendMessage posts the envelope signed with the connector's secret. The delivery ID
+// from ctx is reused so receivers can drop replays and duplicate retries.
+func (c *webhookClient) SendMessage(ctx context.Context, credentials, channelID, message string) error {
+	creds, err := decodeWebhookCredentials(credentials)
+	if err != nil {
+		return err
+	}
+
+	deliveryID, ok := DeliveryIDFromContext(ctx)
+	if !ok {
+		deliveryID = uuid.NewString()
+	}
+	now := c.now()
+
+	body, err := json.Marshal(WebhookEnvelope{
+		ID:        deliveryID,
+		Type:      "message",
+		Channel:   channelID,
+		Text:      message,
+		Timestamp: now.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook envelope: %w", err)
+	}
+
+	return post(ctx, c.http, "webhook", creds.URL, body, webhook.Headers(creds.SigningSecret, deliveryID, now, body))
+}
+
+// CallbackClient posts signed connector event payloads to tenant callback URLs.
+type CallbackClient interface {
+	Post(ctx context.Context, url, secret, deliveryID string, body []byte) error
+}
+
+type callbackClient struct {
+	http *http.Client
+	now  func() time.Time
+}
+
+// NewCallbackClient returns a CallbackClient signing requests like webhook
+// connectors do, so receivers can check them with webhook.Verify.
+func NewCallbackClient(opts ...WebhookOption) CallbackClient {
+	return &callbackClient{http: newWebhookHTTPClient(opts), now: time.Now}
+}
+
+func (c *callbackClient) Post(ctx context.Context, url, secret, deliveryID string, body []byte) error {
+	return post(ctx, c.http, "callback", url, body, webhook.Headers(secret, deliveryID, c.now(), body))
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/iBoBoTi/connector-service/pkg/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookClient_SendsSignedEnvelope(t *testing.T) {
+	const secret = "s3cr3t"
+	var (
+		envelope services.WebhookEnvelope
+		header   http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, webhook.Verify(secret, r.Header, body, time.Now(), 5*time.Minute))
+		require.NoError(t, json.Unmarshal(body, &envelope))
+		header = r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	creds, err := services.WebhookCredentials{URL: srv.URL, SigningSecret: secret}.Encode()
+	require.NoError(t, err)
+
+	client := services.NewWebhookClient(services.WithPrivateNetworks())
+	channel, err := client.ResolveChannelID(context.Background(), creds, "deploys")
+	require.NoError(t, err)
+
+	ctx := services.WithDeliveryID(context.Background(), "delivery-1")
+	require.NoError(t, client.SendMessage(ctx, creds, channel, "v1.2.3 released"))
+
+	require.Equal(t, "delivery-1", header.Get(webhook.HeaderDeliveryID))
+	require.Equal(t, "delivery-1", envelope.ID)
+	require.Equal(t, "deploys", envelope.Channel)
+	require.Equal(t, "v1.2.3 released", envelope.Text)
+}
+
+func TestWebhookClient_RequiresSigningSecret(t *testing.T) {
+	creds, err := services.WebhookCredentials{URL: "https://hooks.example.com/notify"}.Encode()
+	require.NoError(t, err)
+
+	_, err = services.NewWebhookClient(services.WithPrivateNetworks()).ResolveChannelID(context.Background(), creds, "deploys")
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestVerify_RejectsTamperedBody(t *testing.T) {
+	now := time.Now()
+	header := webhook.Headers("s3cr3t", "delivery-1", now, []byte(`{"text":"hi"}`))
+
+	require.ErrorIs(t, webhook.Verify("s3cr3t", header, []byte(`{"text":"bye"}`), now, time.Minute), webhook.ErrSignatureMismatch)
+	require.ErrorIs(t, webhook.Verify("s3cr3t", header, []byte(`{"text":"hi"}`), now.Add(time.Hour), time.Minute), webhook.ErrTimestampExpired)
+}
+
+func TestCallbackClient_SignsPayload(t *testing.T) {
+	const secret = "whsec_test"
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, webhook.Verify(secret, r.Header, body, time.Now(), 5*time.Minute))
+		require.JSONEq(t, `{"id":7,"type":"message.delivered"}`, string(body))
+		header = r.Header
+	}))
+	defer srv.Close()
+
+	err := services.NewCallbackClient(services.WithPrivateNetworks()).Post(context.Background(), srv.URL, secret, "sub-1:7", []byte(`{"id":7,"type":"message.delivered"}`))
+	require.NoError(t, err)
+	require.Equal(t, "sub-1:7", header.Get(webhook.HeaderDeliveryID))
+}
@@ -0,0 +1,87 @@
+// Package gateway serves the SlackConnectorService as REST+JSON, translating
+// requests to gRPC calls with the routes declared in proto/connector.proto.
+package gateway
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/iBoBoTi/connector-service/gen/openapiv2"
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// OpenAPIPath is where the OpenAPI spec of the REST API is served.
+const OpenAPIPath = "/openapi.json"
+
+// NewHandler returns the REST gateway calling the gRPC server behind conn.
+// Calls go through the gRPC server so its interceptors apply to REST requests too.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	if err := connector_v1.RegisterSlackConnectorServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+
+	root := http.NewServeMux()
+	root.HandleFunc("GET "+OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapiv2.Spec)
+	})
+	root.Handle("/", mux)
+	return root, nil
+}
+
+// incomingHeader forwards the headers identifying the caller as gRPC metadata,
+// alongside the ones the gateway forwards by default. X-Forwarded-For is
+// handled by the gateway itself, which appends the caller's address to it.
+func incomingHeader(key string) (string, bool) {
+	switch k := strings.ToLower(key); k {
+	case handler.MetadataActor, handler.MetadataRequestID:
+		return k, true
+	default:
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+// outgoingHeader returns the request ID as a plain header; other response
+// metadata keeps the gateway's Grpc-Metadata- prefix.
+func outgoingHeader(key string) (string, bool) {
+	if key == handler.MetadataRequestID {
+		return "X-Request-Id", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// errorHandler writes the google.rpc.Status of err as the body with the HTTP
+// status mapped by errors.HTTPStatus. A RetryInfo detail becomes Retry-After.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+			secs := math.Ceil(ri.GetRetryDelay().AsDuration().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(int(max(secs, 1))))
+		}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, &runtime.HTTPStatusError{
+		HTTPStatus: errors.HTTPStatus(st.Err()),
+		Err:        st.Err(),
+	})
+}
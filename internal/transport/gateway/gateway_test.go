@@ -0,0 +1,127 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/transport/gateway"
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type stubServer struct {
+	connector_v1.UnimplementedSlackConnectorServiceServer
+	getConnector func(ctx context.Context, req *connector_v1.GetConnectorRequest) (*connector_v1.GetConnectorResponse, error)
+}
+
+func (s *stubServer) GetConnector(ctx context.Context, req *connector_v1.GetConnectorRequest) (*connector_v1.GetConnectorResponse, error) {
+	resp, err := s.getConnector(ctx, req)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return resp, nil
+}
+
+func newGateway(t *testing.T, srv connector_v1.SlackConnectorServiceServer) http.Handler {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(handler.UnaryRequestMetadataInterceptor(nil)))
+	connector_v1.RegisterSlackConnectorServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	h, err := gateway.NewHandler(context.Background(), conn)
+	require.NoError(t, err)
+	return h
+}
+
+func TestGateway_GetConnector(t *testing.T) {
+	var actor []string
+	h := newGateway(t, &stubServer{getConnector: func(ctx context.Context, req *connector_v1.GetConnectorRequest) (*connector_v1.GetConnectorResponse, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		actor = md.Get(handler.MetadataActor)
+		return &connector_v1.GetConnectorResponse{Connector: &connector_v1.Connector{Id: req.ConnectorId, WorkspaceId: "T123"}}, nil
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/connectors/conn-1", nil)
+	req.Header.Set("X-Actor", "alice@example.com")
+	req.Header.Set("X-Request-Id", "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "req-1", rec.Header().Get("X-Request-Id"))
+	require.Equal(t, []string{"alice@example.com"}, actor)
+
+	var body struct {
+		Connector struct {
+			ID          string `json:"id"`
+			WorkspaceID string `json:"workspace_id"`
+		} `json:"connector"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "conn-1", body.Connector.ID)
+	require.Equal(t, "T123", body.Connector.WorkspaceID)
+}
+
+func TestGateway_MapsErrorsToHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantRetry  string
+	}{
+		{"not found", errors.NotFound(errors.ReasonConnectorNotFound, "connector", "conn-1"), http.StatusNotFound, ""},
+		{"invalid argument", errors.InvalidArgument(errors.FieldViolation{Field: "connector_id", Description: "must be set"}), http.StatusBadRequest, ""},
+		{"already exists", errors.New(errors.ErrAlreadyExists, errors.ReasonTemplateExists, "exists"), http.StatusConflict, ""},
+		{"failed precondition", errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "revoked"), http.StatusUnprocessableEntity, ""},
+		{"quota exceeded", errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "quota").WithRetryAfter(1500 * time.Millisecond), http.StatusTooManyRequests, "2"},
+		{"unavailable", errors.ErrUnavailable, http.StatusServiceUnavailable, ""},
+		{"internal", errors.ErrInternal, http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newGateway(t, &stubServer{getConnector: func(context.Context, *connector_v1.GetConnectorRequest) (*connector_v1.GetConnectorResponse, error) {
+				return nil, tt.err
+			}})
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/connectors/conn-1", nil))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Equal(t, tt.wantRetry, rec.Header().Get("Retry-After"))
+			require.Contains(t, rec.Body.String(), `"code"`)
+		})
+	}
+}
+
+func TestGateway_ServesOpenAPISpec(t *testing.T) {
+	h := newGateway(t, &stubServer{})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, gateway.OpenAPIPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, strings.Contains(rec.Body.String(), `"/v1/connectors/{connector_id}"`))
+}
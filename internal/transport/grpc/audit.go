@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) ListAuditEvents(
+	ctx context.Context,
+	req *connector_v1.ListAuditEventsRequest,
+) (*connector_v1.ListAuditEventsResponse, error) {
+	if h.auditUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListAuditEvents(ctx, req)
+	}
+	events, err := h.auditUsecase.ListAuditEvents(ctx, domain.AuditFilter{
+		TenantID:    req.TenantId,
+		ConnectorID: req.ConnectorId,
+		Action:      domain.AuditAction(req.Action),
+		BeforeID:    req.BeforeId,
+	}, int(req.PageSize))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	resp := &connector_v1.ListAuditEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, toProtoAuditEvent(e))
+	}
+	if n := len(events); n > 0 {
+		resp.NextBeforeId = events[n-1].ID
+	}
+	return resp, nil
+}
+
+func toProtoAuditEvent(e *domain.AuditEvent) *connector_v1.AuditEvent {
+	return &connector_v1.AuditEvent{
+		Id:           e.ID,
+		TenantId:     e.TenantID,
+		ClaimedActor: e.ClaimedActor,
+		Action:       string(e.Action),
+		ConnectorId:  e.ConnectorID,
+		RequestId:    e.RequestID,
+		SourceIp:     e.SourceIP,
+		PeerIp:       e.PeerIP,
+		Outcome:      string(e.Outcome),
+		Error:        e.Error,
+		CreatedAt:    timestamppb.New(e.CreatedAt).String(),
+	}
+}
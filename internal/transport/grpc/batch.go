@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	stderrors "errors"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) BatchSendMessage(
+	ctx context.Context,
+	req *connector_v1.BatchSendMessageRequest,
+) (*connector_v1.BatchSendMessageResponse, error) {
+	if h.batchUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.BatchSendMessage(ctx, req)
+	}
+
+	items := make([]usecase.SendMessageInput, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		items = append(items, fromProtoSendMessageRequest(m))
+	}
+	results, err := h.batchUsecase.BatchSend(ctx, items)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	resp := &connector_v1.BatchSendMessageResponse{}
+	for _, res := range results {
+		out := &connector_v1.BatchSendResult{
+			Index:       int32(res.Index),
+			ConnectorId: items[res.Index].ConnectorID,
+			Success:     res.Err == nil,
+		}
+		if res.Message != nil {
+			out.MessageId = res.Message.ID
+			out.Status = string(res.Message.Status)
+		}
+		if res.Err != nil {
+			out.ErrorCode, out.ErrorReason, out.ErrorMessage = itemError(res.Err)
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, out)
+	}
+	return resp, nil
+}
+
+// itemError describes the failure of one item of a multi-item response with
+// the code, ErrorInfo reason and message a single call would have returned.
+func itemError(err error) (codeName, reason, message string) {
+	st := status.Convert(errors.WrapGRPCError(err))
+	if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+		st = status.FromContextError(err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			reason = info.Reason
+		}
+	}
+	return code.Code(st.Code()).String(), reason, st.Message()
+}
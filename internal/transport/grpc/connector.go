@@ -2,37 +2,126 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
 	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/services"
 	"github.com/iBoBoTi/connector-service/internal/usecase"
 	"github.com/iBoBoTi/connector-service/pkg/errors"
 )
 
 // SlackConnectorHandler implements connector.v1.SlackConnectorServiceServer.
 type SlackConnectorHandler struct {
-	connUsecase usecase.ConnectorUsecase
+	connUsecase     usecase.ConnectorUsecase
+	templateUsecase usecase.TemplateUsecase
+	scheduleUsecase usecase.ScheduleUsecase
+	routingUsecase  usecase.RoutingUsecase
+	batchUsecase    usecase.BatchUsecase
+	eventUsecase    usecase.EventUsecase
+	webhookUsecase  usecase.WebhookUsecase
+	auditUsecase    usecase.AuditUsecase
+	quotaUsecase    usecase.QuotaUsecase
 	connector_v1.UnimplementedSlackConnectorServiceServer
 }
 
+// Option wires the optional usecases. RPCs whose usecase is not configured
+// return Unimplemented.
+type Option func(*SlackConnectorHandler)
+
+// WithTemplateUsecase enables the template RPCs.
+func WithTemplateUsecase(tu usecase.TemplateUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.templateUsecase = tu
+	}
+}
+
+// WithScheduleUsecase enables the scheduled message RPCs.
+func WithScheduleUsecase(su usecase.ScheduleUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.scheduleUsecase = su
+	}
+}
+
+// WithRoutingUsecase enables the routing rule RPCs and Notify.
+func WithRoutingUsecase(ru usecase.RoutingUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.routingUsecase = ru
+	}
+}
+
+// WithBatchUsecase enables BatchSendMessage.
+func WithBatchUsecase(bu usecase.BatchUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.batchUsecase = bu
+	}
+}
+
+// WithEventUsecase enables WatchConnectorEvents.
+func WithEventUsecase(eu usecase.EventUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.eventUsecase = eu
+	}
+}
+
+// WithWebhookUsecase enables the webhook subscription RPCs.
+func WithWebhookUsecase(wu usecase.WebhookUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.webhookUsecase = wu
+	}
+}
+
+// WithAuditUsecase enables ListAuditEvents.
+func WithAuditUsecase(au usecase.AuditUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.auditUsecase = au
+	}
+}
+
+// WithQuotaUsecase enables the tenant quota RPCs.
+func WithQuotaUsecase(qu usecase.QuotaUsecase) Option {
+	return func(h *SlackConnectorHandler) {
+		h.quotaUsecase = qu
+	}
+}
+
 // NewSlackConnectorHandler constructs a new gRPC handler instance.
-func NewSlackConnectorHandler(connUC usecase.ConnectorUsecase) *SlackConnectorHandler {
-	return &SlackConnectorHandler{connUsecase: connUC}
+func NewSlackConnectorHandler(connUC usecase.ConnectorUsecase, opts ...Option) *SlackConnectorHandler {
+	h := &SlackConnectorHandler{connUsecase: connUC}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *SlackConnectorHandler) CreateConnector(
 	ctx context.Context,
 	req *connector_v1.CreateConnectorRequest,
 ) (*connector_v1.CreateConnectorResponse, error) {
-	conn, err := h.connUsecase.CreateConnector(
-		ctx,
-		req.WorkspaceId,
-		req.TenantId,
-		req.DefaultChannelName,
-		req.SlackToken,
-	)
+	credentials := req.Credentials
+	if credentials == "" {
+		credentials = req.SlackToken
+	}
+	credentials, err := encodeCredentials(credentials, req.Smtp, req.Webhook)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	provider, err := fromProtoProvider(req.Provider)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	conn, err := h.connUsecase.CreateConnector(ctx, usecase.CreateConnectorInput{
+		Provider:       provider,
+		WorkspaceID:    req.WorkspaceId,
+		TenantID:       req.TenantId,
+		DefaultChannel: req.DefaultChannelName,
+		Credentials:    credentials,
+		DeliveryPolicy: fromProtoDeliveryPolicy(req.DeliveryPolicy),
+	})
 	if err != nil {
 		return nil, errors.WrapGRPCError(err)
 	}
@@ -55,6 +144,53 @@ func (h *SlackConnectorHandler) GetConnector(
 	}, nil
 }
 
+func (h *SlackConnectorHandler) ListConnectors(
+	ctx context.Context,
+	req *connector_v1.ListConnectorsRequest,
+) (*connector_v1.ListConnectorsResponse, error) {
+	conns, next, err := h.connUsecase.ListConnectors(ctx, req.TenantId, req.PageToken, int(req.PageSize))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	resp := &connector_v1.ListConnectorsResponse{NextPageToken: next}
+	for _, c := range conns {
+		resp.Connectors = append(resp.Connectors, toProtoConnector(c))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) UpdateConnector(
+	ctx context.Context,
+	req *connector_v1.UpdateConnectorRequest,
+) (*connector_v1.UpdateConnectorResponse, error) {
+	credentials, err := encodeCredentials(req.Credentials, req.Smtp, req.Webhook)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	conn, err := h.connUsecase.UpdateConnector(ctx, usecase.UpdateConnectorInput{
+		ConnectorID:    req.ConnectorId,
+		DefaultChannel: req.DefaultChannelName,
+		Credentials:    credentials,
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.UpdateConnectorResponse{Connector: toProtoConnector(conn)}, nil
+}
+
+func (h *SlackConnectorHandler) VerifyConnector(
+	ctx context.Context,
+	req *connector_v1.VerifyConnectorRequest,
+) (*connector_v1.VerifyConnectorResponse, error) {
+	res, err := h.connUsecase.VerifyConnector(ctx, req.ConnectorId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.VerifyConnectorResponse{Ok: res.OK, Reason: res.Reason, Message: res.Message}, nil
+}
+
 func (h *SlackConnectorHandler) DeleteConnector(
 	ctx context.Context,
 	req *connector_v1.DeleteConnectorRequest,
@@ -67,13 +203,121 @@ func (h *SlackConnectorHandler) DeleteConnector(
 	}, nil
 }
 
+func (h *SlackConnectorHandler) SendMessage(
+	ctx context.Context,
+	req *connector_v1.SendMessageRequest,
+) (*connector_v1.SendMessageResponse, error) {
+	msg, err := h.connUsecase.Send(ctx, fromProtoSendMessageRequest(req))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.SendMessageResponse{
+		Success:        true,
+		MessageId:      msg.ID,
+		Status:         string(msg.Status),
+		PolicyDecision: toProtoPolicyDecision(msg.Decision),
+	}, nil
+}
+
+func fromProtoSendMessageRequest(req *connector_v1.SendMessageRequest) usecase.SendMessageInput {
+	return usecase.SendMessageInput{
+		ConnectorID:       req.ConnectorId,
+		Channel:           req.Channel,
+		Severity:          fromProtoSeverity(req.Severity),
+		Text:              req.Text,
+		TemplateID:        req.TemplateId,
+		Variables:         req.Variables,
+		DedupKey:          req.DedupKey,
+		DedupWindow:       time.Duration(req.DedupWindowSeconds) * time.Second,
+		GroupKey:          req.GroupKey,
+		AggregationWindow: time.Duration(req.AggregationWindowSeconds) * time.Second,
+	}
+}
+
+// encodeCredentials returns the SMTP or webhook credentials encoded as stored
+// in the secret store, or credentials when neither is set.
+func encodeCredentials(credentials string, smtp *connector_v1.SmtpCredentials, webhook *connector_v1.WebhookCredentials) (string, error) {
+	if webhook != nil {
+		return services.WebhookCredentials{
+			URL:           webhook.Url,
+			SigningSecret: webhook.SigningSecret,
+		}.Encode()
+	}
+	if smtp != nil {
+		return fromProtoSMTPCredentials(smtp).Encode()
+	}
+	return credentials, nil
+}
+
 func toProtoConnector(c *domain.Connector) *connector_v1.Connector {
 	return &connector_v1.Connector{
 		Id:               c.ID,
+		Provider:         toProtoProvider(c.Provider),
 		WorkspaceId:      c.WorkspaceID,
 		TenantId:         c.TenantID,
 		DefaultChannelId: c.DefaultChannelID,
+		DeliveryPolicy:   toProtoDeliveryPolicy(c.DeliveryPolicy),
 		CreatedAt:        timestamppb.New(c.CreatedAt).String(),
 		UpdatedAt:        timestamppb.New(c.UpdatedAt).String(),
 	}
 }
+
+// fromProtoProvider maps the request's provider, keeping PROVIDER_UNSPECIFIED
+// as Slack for clients predating the provider field.
+func fromProtoProvider(p connector_v1.Provider) (domain.Provider, error) {
+	switch p {
+	case connector_v1.Provider_PROVIDER_UNSPECIFIED, connector_v1.Provider_PROVIDER_SLACK:
+		return domain.ProviderSlack, nil
+	case connector_v1.Provider_PROVIDER_MICROSOFT_TEAMS:
+		return domain.ProviderMicrosoftTeams, nil
+	case connector_v1.Provider_PROVIDER_DISCORD:
+		return domain.ProviderDiscord, nil
+	case connector_v1.Provider_PROVIDER_MATTERMOST:
+		return domain.ProviderMattermost, nil
+	case connector_v1.Provider_PROVIDER_SMTP:
+		return domain.ProviderSMTP, nil
+	case connector_v1.Provider_PROVIDER_WEBHOOK:
+		return domain.ProviderWebhook, nil
+	default:
+		return "", errors.InvalidArgument(errors.FieldViolation{
+			Field:       "provider",
+			Description: fmt.Sprintf("unknown provider %d", p),
+		})
+	}
+}
+
+func toProtoProvider(p domain.Provider) connector_v1.Provider {
+	switch p {
+	case domain.ProviderMicrosoftTeams:
+		return connector_v1.Provider_PROVIDER_MICROSOFT_TEAMS
+	case domain.ProviderDiscord:
+		return connector_v1.Provider_PROVIDER_DISCORD
+	case domain.ProviderMattermost:
+		return connector_v1.Provider_PROVIDER_MATTERMOST
+	case domain.ProviderSMTP:
+		return connector_v1.Provider_PROVIDER_SMTP
+	case domain.ProviderWebhook:
+		return connector_v1.Provider_PROVIDER_WEBHOOK
+	default:
+		return connector_v1.Provider_PROVIDER_SLACK
+	}
+}
+
+func fromProtoSMTPCredentials(c *connector_v1.SmtpCredentials) services.SMTPCredentials {
+	creds := services.SMTPCredentials{
+		Host:     c.Host,
+		Port:     int(c.Port),
+		Username: c.Username,
+		Password: c.Password,
+		From:     c.From,
+	}
+	switch c.Tls {
+	case connector_v1.SmtpTls_SMTP_TLS_IMPLICIT:
+		creds.TLS = services.SMTPTLSImplicit
+	case connector_v1.SmtpTls_SMTP_TLS_NONE:
+		creds.TLS = services.SMTPTLSNone
+	default:
+		creds.TLS = services.SMTPTLSStartTLS
+	}
+	return creds
+}
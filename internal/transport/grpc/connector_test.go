@@ -2,15 +2,20 @@ package handler_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
 	"github.com/iBoBoTi/connector-service/internal/domain"
 	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
 	"github.com/iBoBoTi/connector-service/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,8 +23,8 @@ type mockConnectorUsecase struct {
 	mock.Mock
 }
 
-func (m *mockConnectorUsecase) CreateConnector(ctx context.Context, workspaceID, tenantID, defaultChannel, slackToken string) (*domain.Connector, error) {
-	args := m.Called(ctx, workspaceID, tenantID, defaultChannel, slackToken)
+func (m *mockConnectorUsecase) CreateConnector(ctx context.Context, in usecase.CreateConnectorInput) (*domain.Connector, error) {
+	args := m.Called(ctx, in)
 	conn := args.Get(0)
 	if conn == nil {
 		return nil, args.Error(1)
@@ -34,21 +39,65 @@ func (m *mockConnectorUsecase) GetConnector(ctx context.Context, connectorID str
 	}
 	return conn.(*domain.Connector), args.Error(1)
 }
+func (m *mockConnectorUsecase) ListConnectors(ctx context.Context, tenantID, pageToken string, limit int) ([]*domain.Connector, string, error) {
+	args := m.Called(ctx, tenantID, pageToken, limit)
+	conns, _ := args.Get(0).([]*domain.Connector)
+	return conns, args.String(1), args.Error(2)
+}
+func (m *mockConnectorUsecase) UpdateConnector(ctx context.Context, in usecase.UpdateConnectorInput) (*domain.Connector, error) {
+	args := m.Called(ctx, in)
+	conn, _ := args.Get(0).(*domain.Connector)
+	return conn, args.Error(1)
+}
+func (m *mockConnectorUsecase) VerifyConnector(ctx context.Context, connectorID string) (*usecase.VerifyResult, error) {
+	args := m.Called(ctx, connectorID)
+	res, _ := args.Get(0).(*usecase.VerifyResult)
+	return res, args.Error(1)
+}
 func (m *mockConnectorUsecase) DeleteConnector(ctx context.Context, connectorID string) error {
 	args := m.Called(ctx, connectorID)
 	return args.Error(0)
 }
 
+func (m *mockConnectorUsecase) SetDeliveryPolicy(ctx context.Context, connectorID string, policy *domain.DeliveryPolicy) (*domain.Connector, error) {
+	args := m.Called(ctx, connectorID, policy)
+	conn := args.Get(0)
+	if conn == nil {
+		return nil, args.Error(1)
+	}
+	return conn.(*domain.Connector), args.Error(1)
+}
+
+func (m *mockConnectorUsecase) ListMessages(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, connectorID, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
 func (m *mockConnectorUsecase) SendMessage(ctx context.Context, connectorID, msg string) error {
 	args := m.Called(ctx, connectorID, msg)
 	return args.Error(0)
 }
 
+func (m *mockConnectorUsecase) Send(ctx context.Context, in usecase.SendMessageInput) (*domain.Message, error) {
+	args := m.Called(ctx, in)
+	msg := args.Get(0)
+	if msg == nil {
+		return nil, args.Error(1)
+	}
+	return msg.(*domain.Message), args.Error(1)
+}
+
 func TestCreateConnector_Success(t *testing.T) {
 	ctx := context.Background()
 	mockUC := new(mockConnectorUsecase)
 
-	mockUC.On("CreateConnector", ctx, "ws-1", "tenant-1", "#channel", "token-123").Return(&domain.Connector{
+	mockUC.On("CreateConnector", ctx, usecase.CreateConnectorInput{
+		Provider:       domain.ProviderSlack,
+		WorkspaceID:    "ws-1",
+		TenantID:       "tenant-1",
+		DefaultChannel: "#channel",
+		Credentials:    "token-123",
+	}).Return(&domain.Connector{
 		ID: "conn-123",
 	}, nil).Once()
 
@@ -75,7 +124,7 @@ func TestCreateConnector_ErrInvalidArgumentError(t *testing.T) {
 	mockUC := new(mockConnectorUsecase)
 
 	mockUC.
-		On("CreateConnector", ctx, "", "", "", "").
+		On("CreateConnector", ctx, usecase.CreateConnectorInput{Provider: domain.ProviderSlack}).
 		Return(nil, errors.ErrInvalidArgument).
 		Once()
 
@@ -138,6 +187,43 @@ func TestGetConnector_NotFound(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestListConnectors_Success(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("ListConnectors", ctx, "tenant-1", "conn-1", 2).
+		Return([]*domain.Connector{{ID: "conn-2", TenantID: "tenant-1"}, {ID: "conn-3", TenantID: "tenant-1"}}, "conn-3", nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	resp, err := handler.ListConnectors(ctx, &connector_v1.ListConnectorsRequest{TenantId: "tenant-1", PageSize: 2, PageToken: "conn-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetConnectors(), 2)
+	require.Equal(t, "conn-2", resp.GetConnectors()[0].GetId())
+	require.Equal(t, "conn-3", resp.GetNextPageToken())
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestVerifyConnector_ReportsFailure(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("VerifyConnector", ctx, "conn-123").
+		Return(&usecase.VerifyResult{Reason: errors.ReasonTokenRevoked, Message: "slack token has been revoked"}, nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	resp, err := handler.VerifyConnector(ctx, &connector_v1.VerifyConnectorRequest{ConnectorId: "conn-123"})
+	require.NoError(t, err)
+	require.False(t, resp.GetOk())
+	require.Equal(t, errors.ReasonTokenRevoked, resp.GetReason())
+
+	mockUC.AssertExpectations(t)
+}
+
 func TestDeleteConnector_Success(t *testing.T) {
 	ctx := context.Background()
 	mockUC := new(mockConnectorUsecase)
@@ -156,3 +242,304 @@ func TestDeleteConnector_Success(t *testing.T) {
 
 	mockUC.AssertExpectations(t)
 }
+
+func TestGetConnector_NotFoundDetails(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("GetConnector", ctx, "does-not-exist").
+		Return(nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", "does-not-exist")).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	_, err := handler.GetConnector(ctx, &connector_v1.GetConnectorRequest{ConnectorId: "does-not-exist"})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+
+	var info *errdetails.ErrorInfo
+	var resource *errdetails.ResourceInfo
+	for _, d := range st.Details() {
+		switch v := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = v
+		case *errdetails.ResourceInfo:
+			resource = v
+		}
+	}
+	require.NotNil(t, info)
+	require.Equal(t, errors.ReasonConnectorNotFound, info.GetReason())
+	require.Equal(t, errors.Domain, info.GetDomain())
+	require.NotNil(t, resource)
+	require.Equal(t, "does-not-exist", resource.GetResourceName())
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestCreateConnector_RateLimitedDetails(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("CreateConnector", ctx, usecase.CreateConnectorInput{
+			Provider:       domain.ProviderSlack,
+			WorkspaceID:    "ws-1",
+			TenantID:       "tenant-1",
+			DefaultChannel: "#channel",
+			Credentials:    "token-123",
+		}).
+		Return(nil, errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "slack rate limit exceeded").
+			WithRetryAfter(30*time.Second)).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	_, err := handler.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		WorkspaceId:        "ws-1",
+		TenantId:           "tenant-1",
+		DefaultChannelName: "#channel",
+		SlackToken:         "token-123",
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+
+	var retry *errdetails.RetryInfo
+	for _, d := range st.Details() {
+		if v, ok := d.(*errdetails.RetryInfo); ok {
+			retry = v
+		}
+	}
+	require.NotNil(t, retry)
+	require.Equal(t, 30*time.Second, retry.GetRetryDelay().AsDuration())
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestCreateConnector_DiscordProvider(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("CreateConnector", ctx, usecase.CreateConnectorInput{
+			Provider:       domain.ProviderDiscord,
+			WorkspaceID:    "guild-1",
+			TenantID:       "tenant-1",
+			DefaultChannel: "alerts",
+			Credentials:    "https://discord.example/api/webhooks/1/abc",
+		}).
+		Return(&domain.Connector{ID: "conn-123", Provider: domain.ProviderDiscord}, nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	resp, err := handler.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		WorkspaceId:        "guild-1",
+		TenantId:           "tenant-1",
+		DefaultChannelName: "alerts",
+		Provider:           connector_v1.Provider_PROVIDER_DISCORD,
+		Credentials:        "https://discord.example/api/webhooks/1/abc",
+	})
+	require.NoError(t, err)
+	require.Equal(t, connector_v1.Provider_PROVIDER_DISCORD, resp.GetConnector().GetProvider())
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestCreateConnector_UnknownProvider(t *testing.T) {
+	mockUC := new(mockConnectorUsecase)
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	_, err := handler.CreateConnector(context.Background(), &connector_v1.CreateConnectorRequest{
+		WorkspaceId: "ws-1",
+		TenantId:    "tenant-1",
+		Provider:    connector_v1.Provider(99),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mockUC.AssertNotCalled(t, "CreateConnector", mock.Anything, mock.Anything)
+}
+
+func TestSendMessage_Success(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("Send", ctx, usecase.SendMessageInput{ConnectorID: "conn-123", Text: "hello"}).
+		Return(&domain.Message{ID: "msg-1"}, nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	resp, err := handler.SendMessage(ctx, &connector_v1.SendMessageRequest{ConnectorId: "conn-123", Text: "hello"})
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+	require.Equal(t, "msg-1", resp.GetMessageId())
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestCreateConnector_SMTPCredentialsEncoded(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("CreateConnector", ctx, mock.MatchedBy(func(in usecase.CreateConnectorInput) bool {
+			return in.Provider == domain.ProviderSMTP &&
+				in.DefaultChannel == "ops@example.com" &&
+				strings.Contains(in.Credentials, `"host":"smtp.example.com"`) &&
+				strings.Contains(in.Credentials, `"tls":"implicit"`)
+		})).
+		Return(&domain.Connector{ID: "conn-123", Provider: domain.ProviderSMTP}, nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	_, err := handler.CreateConnector(ctx, &connector_v1.CreateConnectorRequest{
+		WorkspaceId:        "example.com",
+		TenantId:           "tenant-1",
+		DefaultChannelName: "ops@example.com",
+		Provider:           connector_v1.Provider_PROVIDER_SMTP,
+		Smtp: &connector_v1.SmtpCredentials{
+			Host: "smtp.example.com",
+			From: "alerts@example.com",
+			Tls:  connector_v1.SmtpTls_SMTP_TLS_IMPLICIT,
+		},
+	})
+	require.NoError(t, err)
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestSendMessage_WithTemplate(t *testing.T) {
+	ctx := context.Background()
+	mockUC := new(mockConnectorUsecase)
+
+	mockUC.
+		On("Send", ctx, usecase.SendMessageInput{
+			ConnectorID: "conn-123",
+			TemplateID:  "tmpl-1",
+			Variables:   map[string]string{"service": "billing"},
+		}).
+		Return(&domain.Message{ID: "msg-1"}, nil).
+		Once()
+
+	handler := handler.NewSlackConnectorHandler(mockUC)
+	_, err := handler.SendMessage(ctx, &connector_v1.SendMessageRequest{
+		ConnectorId: "conn-123",
+		TemplateId:  "tmpl-1",
+		Variables:   map[string]string{"service": "billing"},
+	})
+	require.NoError(t, err)
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestRenderTemplate_UnimplementedWithoutUsecase(t *testing.T) {
+	handler := handler.NewSlackConnectorHandler(new(mockConnectorUsecase))
+
+	_, err := handler.RenderTemplate(context.Background(), &connector_v1.RenderTemplateRequest{Body: "hi"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+type mockRoutingUsecase struct {
+	usecase.RoutingUsecase
+	mock.Mock
+}
+
+func (m *mockRoutingUsecase) Notify(ctx context.Context, event domain.Event) ([]usecase.DeliveryResult, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).([]usecase.DeliveryResult), args.Error(1)
+}
+
+func TestNotify_ReportsPerDestinationErrors(t *testing.T) {
+	ctx := context.Background()
+	mockRouting := new(mockRoutingUsecase)
+
+	mockRouting.On("Notify", ctx, domain.Event{
+		TenantID: "tenant-1",
+		Type:     "incident",
+		Severity: domain.SeverityCritical,
+		Text:     "db down",
+	}).Return([]usecase.DeliveryResult{
+		{
+			Destination: domain.RouteDestination{ConnectorID: "conn-1"},
+			RuleIDs:     []string{"rule-1"},
+			Message:     &domain.Message{ID: "msg-1"},
+		},
+		{
+			Destination: domain.RouteDestination{ConnectorID: "conn-2", Channel: "#ops"},
+			RuleIDs:     []string{"rule-1"},
+			Err:         errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "token revoked"),
+		},
+	}, nil).Once()
+
+	handler := handler.NewSlackConnectorHandler(new(mockConnectorUsecase), handler.WithRoutingUsecase(mockRouting))
+
+	resp, err := handler.Notify(ctx, &connector_v1.NotifyRequest{
+		TenantId:  "tenant-1",
+		EventType: "incident",
+		Severity:  connector_v1.Severity_SEVERITY_CRITICAL,
+		Text:      "db down",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+
+	require.True(t, resp.Results[0].Success)
+	require.Equal(t, "msg-1", resp.Results[0].MessageId)
+
+	require.False(t, resp.Results[1].Success)
+	require.Equal(t, "#ops", resp.Results[1].Destination.Channel)
+	require.Equal(t, "FAILED_PRECONDITION", resp.Results[1].ErrorCode)
+	require.Equal(t, errors.ReasonTokenRevoked, resp.Results[1].ErrorReason)
+	require.Equal(t, "token revoked", resp.Results[1].ErrorMessage)
+
+	mockRouting.AssertExpectations(t)
+}
+
+type mockBatchUsecase struct {
+	mock.Mock
+}
+
+func (m *mockBatchUsecase) BatchSend(ctx context.Context, items []usecase.SendMessageInput) ([]usecase.BatchResult, error) {
+	args := m.Called(ctx, items)
+	return args.Get(0).([]usecase.BatchResult), args.Error(1)
+}
+
+func (m *mockBatchUsecase) SetWorkspaceRate(rate float64, burst int) {
+	m.Called(rate, burst)
+}
+
+func TestBatchSendMessage_PartialFailure(t *testing.T) {
+	ctx := context.Background()
+	mockBatch := new(mockBatchUsecase)
+
+	mockBatch.On("BatchSend", ctx, []usecase.SendMessageInput{
+		{ConnectorID: "conn-1", Text: "hello"},
+		{ConnectorID: "conn-2", Text: "hello"},
+	}).Return([]usecase.BatchResult{
+		{Index: 0, Message: &domain.Message{ID: "msg-1", Status: domain.MessageStatusDelivered}},
+		{Index: 1, Err: errors.NotFound(errors.ReasonConnectorNotFound, "connector", "conn-2")},
+	}, nil).Once()
+
+	handler := handler.NewSlackConnectorHandler(new(mockConnectorUsecase), handler.WithBatchUsecase(mockBatch))
+
+	resp, err := handler.BatchSendMessage(ctx, &connector_v1.BatchSendMessageRequest{
+		Messages: []*connector_v1.SendMessageRequest{
+			{ConnectorId: "conn-1", Text: "hello"},
+			{ConnectorId: "conn-2", Text: "hello"},
+		},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, resp.Succeeded)
+	require.EqualValues(t, 1, resp.Failed)
+
+	require.True(t, resp.Results[0].Success)
+	require.Equal(t, "delivered", resp.Results[0].Status)
+
+	require.False(t, resp.Results[1].Success)
+	require.Equal(t, "conn-2", resp.Results[1].ConnectorId)
+	require.Equal(t, "NOT_FOUND", resp.Results[1].ErrorCode)
+	require.Equal(t, errors.ReasonConnectorNotFound, resp.Results[1].ErrorReason)
+
+	mockBatch.AssertExpectations(t)
+}
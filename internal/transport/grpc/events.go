@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) WatchConnectorEvents(
+	req *connector_v1.WatchConnectorEventsRequest,
+	stream connector_v1.SlackConnectorService_WatchConnectorEventsServer,
+) error {
+	if h.eventUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.WatchConnectorEvents(req, stream)
+	}
+
+	filter := domain.EventFilter{TenantID: req.TenantId, ConnectorID: req.ConnectorId}
+	err := h.eventUsecase.Watch(stream.Context(), filter, req.AfterEventId, func(e *domain.ConnectorEvent) error {
+		return stream.Send(toProtoConnectorEvent(e))
+	})
+	if err != nil {
+		return errors.WrapGRPCError(err)
+	}
+	return nil
+}
+
+func toProtoConnectorEvent(e *domain.ConnectorEvent) *connector_v1.ConnectorEvent {
+	return &connector_v1.ConnectorEvent{
+		Id:          e.ID,
+		TenantId:    e.TenantID,
+		ConnectorId: e.ConnectorID,
+		Type:        string(e.Type),
+		Data:        e.Data,
+		CreatedAt:   timestamppb.New(e.CreatedAt).String(),
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+)
+
+// Metadata keys identifying the caller of a request.
+const (
+	MetadataActor        = "x-actor"
+	MetadataRequestID    = "x-request-id"
+	MetadataForwardedFor = "x-forwarded-for"
+)
+
+// UnaryRequestMetadataInterceptor returns an interceptor attaching the
+// caller's actor, request ID and addresses to the request context for the
+// audit log. Requests are not authenticated, so the x-actor metadata is only
+// recorded as the actor the caller claims. A request ID is generated when the client sent none, and is
+// returned in the response headers. x-forwarded-for is only read from peers on
+// loopback, i.e. the in-process gateways, or in trustedProxies.
+func UnaryRequestMetadataInterceptor(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		ctx = withRequestMetadata(ctx, trustedProxies)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, usecase.RequestMetadataFromContext(ctx).RequestID))
+		return next(ctx, req)
+	}
+}
+
+// StreamRequestMetadataInterceptor is the streaming counterpart of UnaryRequestMetadataInterceptor.
+func StreamRequestMetadataInterceptor(trustedProxies []netip.Prefix) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, next grpc.StreamHandler) error {
+		ctx := withRequestMetadata(ss.Context(), trustedProxies)
+		_ = ss.SetHeader(metadata.Pairs(MetadataRequestID, usecase.RequestMetadataFromContext(ctx).RequestID))
+		return next(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestMetadata(ctx context.Context, trustedProxies []netip.Prefix) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return strings.TrimSpace(v[0])
+		}
+		return ""
+	}
+
+	rm := usecase.RequestMetadata{
+		ClaimedActor: first(MetadataActor),
+		RequestID:    first(MetadataRequestID),
+	}
+	if rm.RequestID == "" {
+		rm.RequestID = uuid.NewString()
+	}
+
+	var peerAddr netip.Addr
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rm.PeerIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(rm.PeerIP); err == nil {
+			rm.PeerIP = host
+		}
+		peerAddr, _ = netip.ParseAddr(rm.PeerIP)
+	}
+	rm.SourceIP = rm.PeerIP
+	if trusted(peerAddr, trustedProxies) {
+		if ip := clientIP(md.Get(MetadataForwardedFor), trustedProxies); ip != "" {
+			rm.SourceIP = ip
+		}
+	}
+	return usecase.ContextWithRequestMetadata(ctx, rm)
+}
+
+// clientIP returns the right-most x-forwarded-for hop that is not a trusted
+// proxy: the hops to its left were added by the client and may be forged.
+// When every hop is trusted, the left-most one is returned.
+func clientIP(forwardedFor []string, trustedProxies []netip.Prefix) string {
+	var hops []string
+	for _, v := range forwardedFor {
+		for _, hop := range strings.Split(v, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			// Nothing left of a malformed hop can be trusted.
+			return ""
+		}
+		if i == 0 || !trusted(addr, trustedProxies) {
+			return addr.String()
+		}
+	}
+	return ""
+}
+
+func trusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() {
+		return true
+	}
+	for _, p := range trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
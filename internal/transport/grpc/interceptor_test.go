@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestUnaryRequestMetadataInterceptor(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 53211}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(handler.MetadataActor, "alice@example.com", handler.MetadataRequestID, "req-1"))
+
+	var got usecase.RequestMetadata
+	_, err := handler.UnaryRequestMetadataInterceptor(nil)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		got = usecase.RequestMetadataFromContext(ctx)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, usecase.RequestMetadata{ClaimedActor: "alice@example.com", RequestID: "req-1", SourceIP: "10.0.0.7", PeerIP: "10.0.0.7"}, got)
+}
+
+func TestUnaryRequestMetadataInterceptor_ForwardedFor(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	tests := []struct {
+		name         string
+		peer         string
+		forwardedFor string
+		sourceIP     string
+	}{
+		{"untrusted peer", "203.0.113.5", "198.51.100.4", "203.0.113.5"},
+		{"loopback gateway", "127.0.0.1", "198.51.100.4", "198.51.100.4"},
+		{"forged hop left of the client", "127.0.0.1", "192.0.2.1, 198.51.100.4", "198.51.100.4"},
+		{"trusted proxy hops skipped", "127.0.0.1", "198.51.100.4, 10.0.0.1", "198.51.100.4"},
+		{"trusted proxy peer", "10.0.0.2", "198.51.100.4", "198.51.100.4"},
+		{"every hop trusted", "127.0.0.1", "10.0.0.5, 10.0.0.6", "10.0.0.5"},
+		{"malformed hop", "127.0.0.1", "198.51.100.4, not-an-ip", "127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(tt.peer), Port: 53211}})
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(handler.MetadataForwardedFor, tt.forwardedFor))
+
+			var got usecase.RequestMetadata
+			_, err := handler.UnaryRequestMetadataInterceptor(trusted)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+				got = usecase.RequestMetadataFromContext(ctx)
+				return nil, nil
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tt.sourceIP, got.SourceIP)
+			require.Equal(t, tt.peer, got.PeerIP)
+			require.NotEmpty(t, got.RequestID)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) SetDeliveryPolicy(
+	ctx context.Context,
+	req *connector_v1.SetDeliveryPolicyRequest,
+) (*connector_v1.SetDeliveryPolicyResponse, error) {
+	conn, err := h.connUsecase.SetDeliveryPolicy(ctx, req.ConnectorId, fromProtoDeliveryPolicy(req.Policy))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.SetDeliveryPolicyResponse{
+		Connector: toProtoConnector(conn),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListMessages(
+	ctx context.Context,
+	req *connector_v1.ListMessagesRequest,
+) (*connector_v1.ListMessagesResponse, error) {
+	messages, err := h.connUsecase.ListMessages(ctx, req.ConnectorId, int(req.PageSize))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListMessagesResponse{}
+	for _, m := range messages {
+		resp.Messages = append(resp.Messages, &connector_v1.Message{
+			Id:             m.ID,
+			ConnectorId:    m.ConnectorID,
+			ChannelId:      m.ChannelID,
+			Text:           m.Text,
+			Status:         string(m.Status),
+			Attempts:       int32(m.Attempts),
+			LastError:      m.LastError,
+			PolicyDecision: toProtoPolicyDecision(m.Decision),
+			CreatedAt:      timestamppb.New(m.CreatedAt).String(),
+			UpdatedAt:      timestamppb.New(m.UpdatedAt).String(),
+		})
+	}
+	return resp, nil
+}
+
+func fromProtoDeliveryPolicy(p *connector_v1.DeliveryPolicy) *domain.DeliveryPolicy {
+	if p == nil {
+		return nil
+	}
+	out := &domain.DeliveryPolicy{
+		Timezone:          p.Timezone,
+		QuietHoursAction:  fromProtoPolicyAction(p.QuietHoursAction),
+		SeverityThreshold: fromProtoSeverity(p.SeverityThreshold),
+		MaxPerHour:        int(p.MaxPerHour),
+		RateLimitAction:   fromProtoPolicyAction(p.RateLimitAction),
+	}
+	for _, q := range p.QuietHours {
+		out.QuietHours = append(out.QuietHours, domain.QuietHours{
+			Start:    q.Start,
+			End:      q.End,
+			Weekdays: q.Weekdays,
+		})
+	}
+	return out
+}
+
+func toProtoDeliveryPolicy(p *domain.DeliveryPolicy) *connector_v1.DeliveryPolicy {
+	if p == nil {
+		return nil
+	}
+	out := &connector_v1.DeliveryPolicy{
+		Timezone:          p.Timezone,
+		QuietHoursAction:  toProtoPolicyAction(p.QuietHoursAction),
+		SeverityThreshold: toProtoSeverity(p.SeverityThreshold),
+		MaxPerHour:        int32(p.MaxPerHour),
+		RateLimitAction:   toProtoPolicyAction(p.RateLimitAction),
+	}
+	for _, q := range p.QuietHours {
+		out.QuietHours = append(out.QuietHours, &connector_v1.QuietHours{
+			Start:    q.Start,
+			End:      q.End,
+			Weekdays: q.Weekdays,
+		})
+	}
+	return out
+}
+
+func toProtoPolicyDecision(d *domain.PolicyDecision) *connector_v1.PolicyDecision {
+	if d == nil {
+		return nil
+	}
+	out := &connector_v1.PolicyDecision{
+		Action: toProtoPolicyAction(d.Action),
+		Reason: d.Reason,
+	}
+	if d.Action == domain.PolicyActionDefer && !d.DeferUntil.IsZero() {
+		out.DeferredUntil = timestamppb.New(d.DeferUntil).String()
+	}
+	return out
+}
+
+func fromProtoPolicyAction(a connector_v1.PolicyAction) domain.PolicyAction {
+	switch a {
+	case connector_v1.PolicyAction_POLICY_ACTION_DELIVER:
+		return domain.PolicyActionDeliver
+	case connector_v1.PolicyAction_POLICY_ACTION_DEFER:
+		return domain.PolicyActionDefer
+	case connector_v1.PolicyAction_POLICY_ACTION_DROP:
+		return domain.PolicyActionDrop
+	case connector_v1.PolicyAction_POLICY_ACTION_DOWNGRADE:
+		return domain.PolicyActionDowngrade
+	default:
+		return ""
+	}
+}
+
+func toProtoPolicyAction(a domain.PolicyAction) connector_v1.PolicyAction {
+	switch a {
+	case domain.PolicyActionDeliver:
+		return connector_v1.PolicyAction_POLICY_ACTION_DELIVER
+	case domain.PolicyActionDefer:
+		return connector_v1.PolicyAction_POLICY_ACTION_DEFER
+	case domain.PolicyActionDrop:
+		return connector_v1.PolicyAction_POLICY_ACTION_DROP
+	case domain.PolicyActionDowngrade:
+		return connector_v1.PolicyAction_POLICY_ACTION_DOWNGRADE
+	default:
+		return connector_v1.PolicyAction_POLICY_ACTION_UNSPECIFIED
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) GetTenantQuota(
+	ctx context.Context,
+	req *connector_v1.GetTenantQuotaRequest,
+) (*connector_v1.GetTenantQuotaResponse, error) {
+	if h.quotaUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.GetTenantQuota(ctx, req)
+	}
+	q, usage, err := h.quotaUsecase.GetQuota(ctx, req.TenantId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.GetTenantQuotaResponse{
+		Quota: toProtoTenantQuota(q),
+		Usage: &connector_v1.QuotaUsage{
+			Connectors:         int32(usage.Connectors),
+			MessagesThisMinute: int32(usage.MessagesThisMinute),
+			MessagesToday:      int32(usage.MessagesToday),
+		},
+	}, nil
+}
+
+func (h *SlackConnectorHandler) SetTenantQuota(
+	ctx context.Context,
+	req *connector_v1.SetTenantQuotaRequest,
+) (*connector_v1.SetTenantQuotaResponse, error) {
+	if h.quotaUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.SetTenantQuota(ctx, req)
+	}
+	q, err := h.quotaUsecase.SetQuota(ctx, domain.TenantQuota{
+		TenantID:          req.TenantId,
+		MaxConnectors:     int(req.MaxConnectors),
+		MessagesPerMinute: int(req.MessagesPerMinute),
+		MessagesPerDay:    int(req.MessagesPerDay),
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.SetTenantQuotaResponse{
+		Quota: toProtoTenantQuota(q),
+	}, nil
+}
+
+func toProtoTenantQuota(q *domain.TenantQuota) *connector_v1.TenantQuota {
+	out := &connector_v1.TenantQuota{
+		TenantId:          q.TenantID,
+		MaxConnectors:     int32(q.MaxConnectors),
+		MessagesPerMinute: int32(q.MessagesPerMinute),
+		MessagesPerDay:    int32(q.MessagesPerDay),
+	}
+	if !q.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(q.UpdatedAt).String()
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) CreateRoutingRule(
+	ctx context.Context,
+	req *connector_v1.CreateRoutingRuleRequest,
+) (*connector_v1.CreateRoutingRuleResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.CreateRoutingRule(ctx, req)
+	}
+	r, err := h.routingUsecase.CreateRoutingRule(ctx, usecase.RoutingRuleInput{
+		TenantID:     req.TenantId,
+		Name:         req.Name,
+		EventTypes:   req.EventTypes,
+		MinSeverity:  fromProtoSeverity(req.MinSeverity),
+		MatchLabels:  req.MatchLabels,
+		Destinations: fromProtoDestinations(req.Destinations),
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.CreateRoutingRuleResponse{
+		RoutingRule: toProtoRoutingRule(r),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) GetRoutingRule(
+	ctx context.Context,
+	req *connector_v1.GetRoutingRuleRequest,
+) (*connector_v1.GetRoutingRuleResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.GetRoutingRule(ctx, req)
+	}
+	r, err := h.routingUsecase.GetRoutingRule(ctx, req.RoutingRuleId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.GetRoutingRuleResponse{
+		RoutingRule: toProtoRoutingRule(r),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListRoutingRules(
+	ctx context.Context,
+	req *connector_v1.ListRoutingRulesRequest,
+) (*connector_v1.ListRoutingRulesResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListRoutingRules(ctx, req)
+	}
+	rules, err := h.routingUsecase.ListRoutingRules(ctx, req.TenantId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListRoutingRulesResponse{}
+	for _, r := range rules {
+		resp.RoutingRules = append(resp.RoutingRules, toProtoRoutingRule(r))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) UpdateRoutingRule(
+	ctx context.Context,
+	req *connector_v1.UpdateRoutingRuleRequest,
+) (*connector_v1.UpdateRoutingRuleResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.UpdateRoutingRule(ctx, req)
+	}
+	r, err := h.routingUsecase.UpdateRoutingRule(ctx, req.RoutingRuleId, usecase.RoutingRuleInput{
+		Name:         req.Name,
+		EventTypes:   req.EventTypes,
+		MinSeverity:  fromProtoSeverity(req.MinSeverity),
+		MatchLabels:  req.MatchLabels,
+		Destinations: fromProtoDestinations(req.Destinations),
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.UpdateRoutingRuleResponse{
+		RoutingRule: toProtoRoutingRule(r),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) DeleteRoutingRule(
+	ctx context.Context,
+	req *connector_v1.DeleteRoutingRuleRequest,
+) (*connector_v1.DeleteRoutingRuleResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.DeleteRoutingRule(ctx, req)
+	}
+	if err := h.routingUsecase.DeleteRoutingRule(ctx, req.RoutingRuleId); err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.DeleteRoutingRuleResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *SlackConnectorHandler) Notify(
+	ctx context.Context,
+	req *connector_v1.NotifyRequest,
+) (*connector_v1.NotifyResponse, error) {
+	if h.routingUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.Notify(ctx, req)
+	}
+	results, err := h.routingUsecase.Notify(ctx, domain.Event{
+		TenantID:   req.TenantId,
+		Type:       req.EventType,
+		Severity:   fromProtoSeverity(req.Severity),
+		Labels:     req.Labels,
+		Text:       req.Text,
+		TemplateID: req.TemplateId,
+		Variables:  req.Variables,
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+
+	resp := &connector_v1.NotifyResponse{}
+	for _, res := range results {
+		resp.Results = append(resp.Results, toProtoDeliveryResult(res))
+	}
+	return resp, nil
+}
+
+// toProtoDeliveryResult reports a failed delivery with the code, reason and
+// message the equivalent SendMessage call would have returned.
+func toProtoDeliveryResult(res usecase.DeliveryResult) *connector_v1.DeliveryResult {
+	out := &connector_v1.DeliveryResult{
+		Destination: &connector_v1.RouteDestination{
+			ConnectorId: res.Destination.ConnectorID,
+			Channel:     res.Destination.Channel,
+		},
+		RoutingRuleIds: res.RuleIDs,
+		Success:        res.Err == nil,
+	}
+	if res.Message != nil {
+		out.MessageId = res.Message.ID
+	}
+	if res.Err != nil {
+		out.ErrorCode, out.ErrorReason, out.ErrorMessage = itemError(res.Err)
+	}
+	return out
+}
+
+func toProtoRoutingRule(r *domain.RoutingRule) *connector_v1.RoutingRule {
+	out := &connector_v1.RoutingRule{
+		Id:          r.ID,
+		TenantId:    r.TenantID,
+		Name:        r.Name,
+		EventTypes:  r.EventTypes,
+		MinSeverity: toProtoSeverity(r.MinSeverity),
+		MatchLabels: r.MatchLabels,
+		CreatedAt:   timestamppb.New(r.CreatedAt).String(),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt).String(),
+	}
+	for _, d := range r.Destinations {
+		out.Destinations = append(out.Destinations, &connector_v1.RouteDestination{
+			ConnectorId: d.ConnectorID,
+			Channel:     d.Channel,
+		})
+	}
+	return out
+}
+
+func fromProtoDestinations(in []*connector_v1.RouteDestination) []domain.RouteDestination {
+	out := make([]domain.RouteDestination, 0, len(in))
+	for _, d := range in {
+		out = append(out, domain.RouteDestination{
+			ConnectorID: d.GetConnectorId(),
+			Channel:     d.GetChannel(),
+		})
+	}
+	return out
+}
+
+func fromProtoSeverity(s connector_v1.Severity) domain.Severity {
+	switch s {
+	case connector_v1.Severity_SEVERITY_INFO:
+		return domain.SeverityInfo
+	case connector_v1.Severity_SEVERITY_WARNING:
+		return domain.SeverityWarning
+	case connector_v1.Severity_SEVERITY_ERROR:
+		return domain.SeverityError
+	case connector_v1.Severity_SEVERITY_CRITICAL:
+		return domain.SeverityCritical
+	default:
+		return ""
+	}
+}
+
+func toProtoSeverity(s domain.Severity) connector_v1.Severity {
+	switch s {
+	case domain.SeverityInfo:
+		return connector_v1.Severity_SEVERITY_INFO
+	case domain.SeverityWarning:
+		return connector_v1.Severity_SEVERITY_WARNING
+	case domain.SeverityError:
+		return connector_v1.Severity_SEVERITY_ERROR
+	case domain.SeverityCritical:
+		return connector_v1.Severity_SEVERITY_CRITICAL
+	default:
+		return connector_v1.Severity_SEVERITY_UNSPECIFIED
+	}
+}
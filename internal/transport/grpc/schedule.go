@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) ScheduleMessage(
+	ctx context.Context,
+	req *connector_v1.ScheduleMessageRequest,
+) (*connector_v1.ScheduleMessageResponse, error) {
+	if h.scheduleUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ScheduleMessage(ctx, req)
+	}
+
+	in := usecase.ScheduleMessageInput{
+		SendMessageInput: usecase.SendMessageInput{
+			ConnectorID: req.ConnectorId,
+			Channel:     req.Channel,
+			Severity:    fromProtoSeverity(req.Severity),
+			Text:        req.Text,
+			TemplateID:  req.TemplateId,
+			Variables:   req.Variables,
+		},
+		CronExpr: req.CronExpression,
+	}
+	if req.SendAt != nil {
+		in.SendAt = req.SendAt.AsTime()
+	}
+
+	sm, err := h.scheduleUsecase.ScheduleMessage(ctx, in)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.ScheduleMessageResponse{
+		ScheduledMessage: toProtoScheduledMessage(sm),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListScheduledMessages(
+	ctx context.Context,
+	req *connector_v1.ListScheduledMessagesRequest,
+) (*connector_v1.ListScheduledMessagesResponse, error) {
+	if h.scheduleUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListScheduledMessages(ctx, req)
+	}
+	scheduled, err := h.scheduleUsecase.ListScheduledMessages(ctx, req.TenantId, req.ConnectorId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListScheduledMessagesResponse{}
+	for _, sm := range scheduled {
+		resp.ScheduledMessages = append(resp.ScheduledMessages, toProtoScheduledMessage(sm))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) CancelScheduledMessage(
+	ctx context.Context,
+	req *connector_v1.CancelScheduledMessageRequest,
+) (*connector_v1.CancelScheduledMessageResponse, error) {
+	if h.scheduleUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.CancelScheduledMessage(ctx, req)
+	}
+	if err := h.scheduleUsecase.CancelScheduledMessage(ctx, req.ScheduledMessageId); err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.CancelScheduledMessageResponse{
+		Success: true,
+	}, nil
+}
+
+func toProtoScheduledMessage(sm *domain.ScheduledMessage) *connector_v1.ScheduledMessage {
+	out := &connector_v1.ScheduledMessage{
+		Id:             sm.ID,
+		TenantId:       sm.TenantID,
+		ConnectorId:    sm.ConnectorID,
+		Channel:        sm.Channel,
+		Severity:       toProtoSeverity(sm.Severity),
+		Text:           sm.Text,
+		TemplateId:     sm.TemplateID,
+		Variables:      sm.Variables,
+		CronExpression: sm.CronExpr,
+		NextRunAt:      timestamppb.New(sm.NextRunAt).String(),
+		Status:         string(sm.Status),
+		LastError:      sm.LastError,
+		CreatedAt:      timestamppb.New(sm.CreatedAt).String(),
+		UpdatedAt:      timestamppb.New(sm.UpdatedAt).String(),
+	}
+	if sm.LastRunAt != nil {
+		out.LastRunAt = timestamppb.New(*sm.LastRunAt).String()
+	}
+	return out
+}
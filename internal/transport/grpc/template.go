@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) CreateTemplate(
+	ctx context.Context,
+	req *connector_v1.CreateTemplateRequest,
+) (*connector_v1.CreateTemplateResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.CreateTemplate(ctx, req)
+	}
+	t, err := h.templateUsecase.CreateTemplate(ctx, req.TenantId, req.Name, req.Body)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.CreateTemplateResponse{
+		Template: toProtoTemplate(t),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) GetTemplate(
+	ctx context.Context,
+	req *connector_v1.GetTemplateRequest,
+) (*connector_v1.GetTemplateResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.GetTemplate(ctx, req)
+	}
+	t, err := h.templateUsecase.GetTemplate(ctx, req.TemplateId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.GetTemplateResponse{
+		Template: toProtoTemplate(t),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListTemplates(
+	ctx context.Context,
+	req *connector_v1.ListTemplatesRequest,
+) (*connector_v1.ListTemplatesResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListTemplates(ctx, req)
+	}
+	templates, err := h.templateUsecase.ListTemplates(ctx, req.TenantId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListTemplatesResponse{}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, toProtoTemplate(t))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) UpdateTemplate(
+	ctx context.Context,
+	req *connector_v1.UpdateTemplateRequest,
+) (*connector_v1.UpdateTemplateResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.UpdateTemplate(ctx, req)
+	}
+	t, err := h.templateUsecase.UpdateTemplate(ctx, req.TemplateId, req.Name, req.Body)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.UpdateTemplateResponse{
+		Template: toProtoTemplate(t),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) DeleteTemplate(
+	ctx context.Context,
+	req *connector_v1.DeleteTemplateRequest,
+) (*connector_v1.DeleteTemplateResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.DeleteTemplate(ctx, req)
+	}
+	if err := h.templateUsecase.DeleteTemplate(ctx, req.TemplateId); err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.DeleteTemplateResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *SlackConnectorHandler) RenderTemplate(
+	ctx context.Context,
+	req *connector_v1.RenderTemplateRequest,
+) (*connector_v1.RenderTemplateResponse, error) {
+	if h.templateUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.RenderTemplate(ctx, req)
+	}
+	text, err := h.templateUsecase.RenderTemplate(ctx, usecase.RenderTemplateInput{
+		TemplateID: req.TemplateId,
+		Body:       req.Body,
+		Variables:  req.Variables,
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.RenderTemplateResponse{
+		Text: text,
+	}, nil
+}
+
+func toProtoTemplate(t *domain.Template) *connector_v1.Template {
+	return &connector_v1.Template{
+		Id:        t.ID,
+		TenantId:  t.TenantID,
+		Name:      t.Name,
+		Body:      t.Body,
+		CreatedAt: timestamppb.New(t.CreatedAt).String(),
+		UpdatedAt: timestamppb.New(t.UpdatedAt).String(),
+	}
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func (h *SlackConnectorHandler) CreateWebhookSubscription(
+	ctx context.Context,
+	req *connector_v1.CreateWebhookSubscriptionRequest,
+) (*connector_v1.CreateWebhookSubscriptionResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.CreateWebhookSubscription(ctx, req)
+	}
+	eventTypes := make([]domain.EventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, domain.EventType(t))
+	}
+	s, err := h.webhookUsecase.CreateSubscription(ctx, usecase.WebhookSubscriptionInput{
+		TenantID:      req.TenantId,
+		URL:           req.Url,
+		SigningSecret: req.SigningSecret,
+		EventTypes:    eventTypes,
+	})
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.CreateWebhookSubscriptionResponse{
+		Subscription:  toProtoWebhookSubscription(s),
+		SigningSecret: s.SigningSecret,
+	}, nil
+}
+
+func (h *SlackConnectorHandler) GetWebhookSubscription(
+	ctx context.Context,
+	req *connector_v1.GetWebhookSubscriptionRequest,
+) (*connector_v1.GetWebhookSubscriptionResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.GetWebhookSubscription(ctx, req)
+	}
+	s, err := h.webhookUsecase.GetSubscription(ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.GetWebhookSubscriptionResponse{
+		Subscription: toProtoWebhookSubscription(s),
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListWebhookSubscriptions(
+	ctx context.Context,
+	req *connector_v1.ListWebhookSubscriptionsRequest,
+) (*connector_v1.ListWebhookSubscriptionsResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListWebhookSubscriptions(ctx, req)
+	}
+	subs, err := h.webhookUsecase.ListSubscriptions(ctx, req.TenantId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListWebhookSubscriptionsResponse{}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, toProtoWebhookSubscription(s))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) DeleteWebhookSubscription(
+	ctx context.Context,
+	req *connector_v1.DeleteWebhookSubscriptionRequest,
+) (*connector_v1.DeleteWebhookSubscriptionResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.DeleteWebhookSubscription(ctx, req)
+	}
+	if err := h.webhookUsecase.DeleteSubscription(ctx, req.SubscriptionId); err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.DeleteWebhookSubscriptionResponse{
+		Success: true,
+	}, nil
+}
+
+func (h *SlackConnectorHandler) ListWebhookDeliveries(
+	ctx context.Context,
+	req *connector_v1.ListWebhookDeliveriesRequest,
+) (*connector_v1.ListWebhookDeliveriesResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.ListWebhookDeliveries(ctx, req)
+	}
+	deliveries, err := h.webhookUsecase.ListDeliveries(ctx, req.SubscriptionId, int(req.PageSize))
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	resp := &connector_v1.ListWebhookDeliveriesResponse{}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toProtoWebhookDelivery(d))
+	}
+	return resp, nil
+}
+
+func (h *SlackConnectorHandler) TestWebhook(
+	ctx context.Context,
+	req *connector_v1.TestWebhookRequest,
+) (*connector_v1.TestWebhookResponse, error) {
+	if h.webhookUsecase == nil {
+		return h.UnimplementedSlackConnectorServiceServer.TestWebhook(ctx, req)
+	}
+	d, err := h.webhookUsecase.TestWebhook(ctx, req.SubscriptionId)
+	if err != nil {
+		return nil, errors.WrapGRPCError(err)
+	}
+	return &connector_v1.TestWebhookResponse{
+		Success:      d.Status == domain.WebhookDeliveryDelivered,
+		DeliveryId:   d.ID,
+		ErrorMessage: d.LastError,
+	}, nil
+}
+
+func toProtoWebhookSubscription(s *domain.WebhookSubscription) *connector_v1.WebhookSubscription {
+	out := &connector_v1.WebhookSubscription{
+		Id:        s.ID,
+		TenantId:  s.TenantID,
+		Url:       s.URL,
+		CreatedAt: timestamppb.New(s.CreatedAt).String(),
+		UpdatedAt: timestamppb.New(s.UpdatedAt).String(),
+	}
+	for _, t := range s.EventTypes {
+		out.EventTypes = append(out.EventTypes, string(t))
+	}
+	return out
+}
+
+func toProtoWebhookDelivery(d *domain.WebhookDelivery) *connector_v1.WebhookDelivery {
+	out := &connector_v1.WebhookDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		EventId:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       int32(d.Attempts),
+		LastError:      d.LastError,
+		CreatedAt:      timestamppb.New(d.CreatedAt).String(),
+	}
+	if d.Status == domain.WebhookDeliveryPending {
+		out.NextAttemptAt = timestamppb.New(d.NextAttemptAt).String()
+	}
+	if d.DeliveredAt != nil {
+		out.DeliveredAt = timestamppb.New(*d.DeliveredAt).String()
+	}
+	return out
+}
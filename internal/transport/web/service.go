@@ -0,0 +1,147 @@
+package web
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+// The unary RPCs are forwarded to the gRPC server as they are.
+
+func (s *service) CreateConnector(ctx context.Context, req *connect.Request[connector_v1.CreateConnectorRequest]) (*connect.Response[connector_v1.CreateConnectorResponse], error) {
+	return unary(ctx, req, s.client.CreateConnector)
+}
+
+func (s *service) GetConnector(ctx context.Context, req *connect.Request[connector_v1.GetConnectorRequest]) (*connect.Response[connector_v1.GetConnectorResponse], error) {
+	return unary(ctx, req, s.client.GetConnector)
+}
+
+func (s *service) ListConnectors(ctx context.Context, req *connect.Request[connector_v1.ListConnectorsRequest]) (*connect.Response[connector_v1.ListConnectorsResponse], error) {
+	return unary(ctx, req, s.client.ListConnectors)
+}
+
+func (s *service) UpdateConnector(ctx context.Context, req *connect.Request[connector_v1.UpdateConnectorRequest]) (*connect.Response[connector_v1.UpdateConnectorResponse], error) {
+	return unary(ctx, req, s.client.UpdateConnector)
+}
+
+func (s *service) VerifyConnector(ctx context.Context, req *connect.Request[connector_v1.VerifyConnectorRequest]) (*connect.Response[connector_v1.VerifyConnectorResponse], error) {
+	return unary(ctx, req, s.client.VerifyConnector)
+}
+
+func (s *service) DeleteConnector(ctx context.Context, req *connect.Request[connector_v1.DeleteConnectorRequest]) (*connect.Response[connector_v1.DeleteConnectorResponse], error) {
+	return unary(ctx, req, s.client.DeleteConnector)
+}
+
+func (s *service) SendMessage(ctx context.Context, req *connect.Request[connector_v1.SendMessageRequest]) (*connect.Response[connector_v1.SendMessageResponse], error) {
+	return unary(ctx, req, s.client.SendMessage)
+}
+
+func (s *service) CreateTemplate(ctx context.Context, req *connect.Request[connector_v1.CreateTemplateRequest]) (*connect.Response[connector_v1.CreateTemplateResponse], error) {
+	return unary(ctx, req, s.client.CreateTemplate)
+}
+
+func (s *service) GetTemplate(ctx context.Context, req *connect.Request[connector_v1.GetTemplateRequest]) (*connect.Response[connector_v1.GetTemplateResponse], error) {
+	return unary(ctx, req, s.client.GetTemplate)
+}
+
+func (s *service) ListTemplates(ctx context.Context, req *connect.Request[connector_v1.ListTemplatesRequest]) (*connect.Response[connector_v1.ListTemplatesResponse], error) {
+	return unary(ctx, req, s.client.ListTemplates)
+}
+
+func (s *service) UpdateTemplate(ctx context.Context, req *connect.Request[connector_v1.UpdateTemplateRequest]) (*connect.Response[connector_v1.UpdateTemplateResponse], error) {
+	return unary(ctx, req, s.client.UpdateTemplate)
+}
+
+func (s *service) DeleteTemplate(ctx context.Context, req *connect.Request[connector_v1.DeleteTemplateRequest]) (*connect.Response[connector_v1.DeleteTemplateResponse], error) {
+	return unary(ctx, req, s.client.DeleteTemplate)
+}
+
+func (s *service) RenderTemplate(ctx context.Context, req *connect.Request[connector_v1.RenderTemplateRequest]) (*connect.Response[connector_v1.RenderTemplateResponse], error) {
+	return unary(ctx, req, s.client.RenderTemplate)
+}
+
+func (s *service) ScheduleMessage(ctx context.Context, req *connect.Request[connector_v1.ScheduleMessageRequest]) (*connect.Response[connector_v1.ScheduleMessageResponse], error) {
+	return unary(ctx, req, s.client.ScheduleMessage)
+}
+
+func (s *service) ListScheduledMessages(ctx context.Context, req *connect.Request[connector_v1.ListScheduledMessagesRequest]) (*connect.Response[connector_v1.ListScheduledMessagesResponse], error) {
+	return unary(ctx, req, s.client.ListScheduledMessages)
+}
+
+func (s *service) CancelScheduledMessage(ctx context.Context, req *connect.Request[connector_v1.CancelScheduledMessageRequest]) (*connect.Response[connector_v1.CancelScheduledMessageResponse], error) {
+	return unary(ctx, req, s.client.CancelScheduledMessage)
+}
+
+func (s *service) CreateRoutingRule(ctx context.Context, req *connect.Request[connector_v1.CreateRoutingRuleRequest]) (*connect.Response[connector_v1.CreateRoutingRuleResponse], error) {
+	return unary(ctx, req, s.client.CreateRoutingRule)
+}
+
+func (s *service) GetRoutingRule(ctx context.Context, req *connect.Request[connector_v1.GetRoutingRuleRequest]) (*connect.Response[connector_v1.GetRoutingRuleResponse], error) {
+	return unary(ctx, req, s.client.GetRoutingRule)
+}
+
+func (s *service) ListRoutingRules(ctx context.Context, req *connect.Request[connector_v1.ListRoutingRulesRequest]) (*connect.Response[connector_v1.ListRoutingRulesResponse], error) {
+	return unary(ctx, req, s.client.ListRoutingRules)
+}
+
+func (s *service) UpdateRoutingRule(ctx context.Context, req *connect.Request[connector_v1.UpdateRoutingRuleRequest]) (*connect.Response[connector_v1.UpdateRoutingRuleResponse], error) {
+	return unary(ctx, req, s.client.UpdateRoutingRule)
+}
+
+func (s *service) DeleteRoutingRule(ctx context.Context, req *connect.Request[connector_v1.DeleteRoutingRuleRequest]) (*connect.Response[connector_v1.DeleteRoutingRuleResponse], error) {
+	return unary(ctx, req, s.client.DeleteRoutingRule)
+}
+
+func (s *service) Notify(ctx context.Context, req *connect.Request[connector_v1.NotifyRequest]) (*connect.Response[connector_v1.NotifyResponse], error) {
+	return unary(ctx, req, s.client.Notify)
+}
+
+func (s *service) SetDeliveryPolicy(ctx context.Context, req *connect.Request[connector_v1.SetDeliveryPolicyRequest]) (*connect.Response[connector_v1.SetDeliveryPolicyResponse], error) {
+	return unary(ctx, req, s.client.SetDeliveryPolicy)
+}
+
+func (s *service) ListMessages(ctx context.Context, req *connect.Request[connector_v1.ListMessagesRequest]) (*connect.Response[connector_v1.ListMessagesResponse], error) {
+	return unary(ctx, req, s.client.ListMessages)
+}
+
+func (s *service) BatchSendMessage(ctx context.Context, req *connect.Request[connector_v1.BatchSendMessageRequest]) (*connect.Response[connector_v1.BatchSendMessageResponse], error) {
+	return unary(ctx, req, s.client.BatchSendMessage)
+}
+
+func (s *service) CreateWebhookSubscription(ctx context.Context, req *connect.Request[connector_v1.CreateWebhookSubscriptionRequest]) (*connect.Response[connector_v1.CreateWebhookSubscriptionResponse], error) {
+	return unary(ctx, req, s.client.CreateWebhookSubscription)
+}
+
+func (s *service) GetWebhookSubscription(ctx context.Context, req *connect.Request[connector_v1.GetWebhookSubscriptionRequest]) (*connect.Response[connector_v1.GetWebhookSubscriptionResponse], error) {
+	return unary(ctx, req, s.client.GetWebhookSubscription)
+}
+
+func (s *service) ListWebhookSubscriptions(ctx context.Context, req *connect.Request[connector_v1.ListWebhookSubscriptionsRequest]) (*connect.Response[connector_v1.ListWebhookSubscriptionsResponse], error) {
+	return unary(ctx, req, s.client.ListWebhookSubscriptions)
+}
+
+func (s *service) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[connector_v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[connector_v1.DeleteWebhookSubscriptionResponse], error) {
+	return unary(ctx, req, s.client.DeleteWebhookSubscription)
+}
+
+func (s *service) ListWebhookDeliveries(ctx context.Context, req *connect.Request[connector_v1.ListWebhookDeliveriesRequest]) (*connect.Response[connector_v1.ListWebhookDeliveriesResponse], error) {
+	return unary(ctx, req, s.client.ListWebhookDeliveries)
+}
+
+func (s *service) TestWebhook(ctx context.Context, req *connect.Request[connector_v1.TestWebhookRequest]) (*connect.Response[connector_v1.TestWebhookResponse], error) {
+	return unary(ctx, req, s.client.TestWebhook)
+}
+
+func (s *service) ListAuditEvents(ctx context.Context, req *connect.Request[connector_v1.ListAuditEventsRequest]) (*connect.Response[connector_v1.ListAuditEventsResponse], error) {
+	return unary(ctx, req, s.client.ListAuditEvents)
+}
+
+func (s *service) GetTenantQuota(ctx context.Context, req *connect.Request[connector_v1.GetTenantQuotaRequest]) (*connect.Response[connector_v1.GetTenantQuotaResponse], error) {
+	return unary(ctx, req, s.client.GetTenantQuota)
+}
+
+func (s *service) SetTenantQuota(ctx context.Context, req *connect.Request[connector_v1.SetTenantQuotaRequest]) (*connect.Response[connector_v1.SetTenantQuotaResponse], error) {
+	return unary(ctx, req, s.client.SetTenantQuota)
+}
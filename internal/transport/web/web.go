@@ -0,0 +1,149 @@
+// Package web serves the SlackConnectorService to browser clients over the
+// Connect protocol and gRPC-Web, forwarding every call to the gRPC server.
+package web
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	connectcors "connectrpc.com/cors"
+	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/gen/proto/v1connect"
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+)
+
+// forwardedHeaders are the request headers passed on as gRPC metadata.
+// X-Forwarded-For is extended with the caller's address by outgoingContext.
+var forwardedHeaders = []string{handler.MetadataActor, handler.MetadataRequestID}
+
+var _ v1connect.SlackConnectorServiceHandler = (*service)(nil)
+
+type service struct {
+	client connector_v1.SlackConnectorServiceClient
+}
+
+// NewHandler returns the path to mount the Connect and gRPC-Web handler on,
+// and the handler calling the gRPC server behind conn. Calls go through the
+// gRPC server so its interceptors apply to browser requests too.
+func NewHandler(conn *grpc.ClientConn) (string, http.Handler) {
+	return v1connect.NewSlackConnectorServiceHandler(&service{client: connector_v1.NewSlackConnectorServiceClient(conn)})
+}
+
+// WithCORS lets browsers on allowedOrigins call h, caching preflight
+// responses for maxAge. The REST gateway's methods are allowed too. h is
+// returned as is when no origin is allowed.
+func WithCORS(h http.Handler, allowedOrigins []string, maxAge time.Duration) http.Handler {
+	if len(allowedOrigins) == 0 {
+		return h
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: append(connectcors.AllowedMethods(), http.MethodPut, http.MethodPatch, http.MethodDelete),
+		AllowedHeaders: append(connectcors.AllowedHeaders(), handler.MetadataActor, handler.MetadataRequestID),
+		ExposedHeaders: append(connectcors.ExposedHeaders(), handler.MetadataRequestID),
+		MaxAge:         int(maxAge.Seconds()),
+	}).Handler(h)
+}
+
+func (s *service) WatchConnectorEvents(
+	ctx context.Context,
+	req *connect.Request[connector_v1.WatchConnectorEventsRequest],
+	stream *connect.ServerStream[connector_v1.ConnectorEvent],
+) error {
+	events, err := s.client.WatchConnectorEvents(outgoingContext(ctx, req), req.Msg)
+	if err != nil {
+		return connectError(err, nil)
+	}
+	for sent := false; ; sent = true {
+		event, err := events.Recv()
+		if err != nil {
+			if stderrors.Is(err, io.EOF) {
+				return nil
+			}
+			header, _ := events.Header()
+			return connectError(err, header)
+		}
+		if !sent {
+			header, _ := events.Header()
+			copyRequestID(stream.ResponseHeader(), header)
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+}
+
+// unary forwards a unary call to the gRPC server.
+func unary[Req, Res any](
+	ctx context.Context,
+	req *connect.Request[Req],
+	call func(context.Context, *Req, ...grpc.CallOption) (*Res, error),
+) (*connect.Response[Res], error) {
+	var header metadata.MD
+	res, err := call(outgoingContext(ctx, req), req.Msg, grpc.Header(&header))
+	if err != nil {
+		return nil, connectError(err, header)
+	}
+	resp := connect.NewResponse(res)
+	copyRequestID(resp.Header(), header)
+	return resp, nil
+}
+
+// outgoingContext passes the caller's headers on as gRPC metadata. The
+// caller's address is appended to X-Forwarded-For like a proxy would, so the
+// gRPC server, which trusts this loopback hop, records it rather than the
+// loopback address, and only believes earlier hops added by trusted proxies.
+func outgoingContext(ctx context.Context, req connect.AnyRequest) context.Context {
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if v := req.Header().Get(key); v != "" {
+			md.Set(key, v)
+		}
+	}
+	hops := req.Header().Values(handler.MetadataForwardedFor)
+	if req.Peer().Addr != "" {
+		host, _, err := net.SplitHostPort(req.Peer().Addr)
+		if err != nil {
+			host = req.Peer().Addr
+		}
+		hops = append(hops, host)
+	}
+	if len(hops) > 0 {
+		md.Set(handler.MetadataForwardedFor, strings.Join(hops, ", "))
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// connectError converts a gRPC status error, keeping its code and details.
+func connectError(err error, header metadata.MD) error {
+	st := status.Convert(err)
+	cerr := connect.NewError(connect.Code(st.Code()), stderrors.New(st.Message()))
+	for _, d := range st.Proto().GetDetails() {
+		msg, err := d.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		if detail, err := connect.NewErrorDetail(msg); err == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+	copyRequestID(cerr.Meta(), header)
+	return cerr
+}
+
+func copyRequestID(dst http.Header, md metadata.MD) {
+	if v := md.Get(handler.MetadataRequestID); len(v) > 0 {
+		dst.Set(handler.MetadataRequestID, strings.TrimSpace(v[0]))
+	}
+}
@@ -0,0 +1,127 @@
+package web_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/gen/proto/v1connect"
+	handler "github.com/iBoBoTi/connector-service/internal/transport/grpc"
+	"github.com/iBoBoTi/connector-service/internal/transport/web"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type stubServer struct {
+	connector_v1.UnimplementedSlackConnectorServiceServer
+	incoming metadata.MD
+}
+
+func (s *stubServer) GetConnector(ctx context.Context, req *connector_v1.GetConnectorRequest) (*connector_v1.GetConnectorResponse, error) {
+	s.incoming, _ = metadata.FromIncomingContext(ctx)
+	if req.ConnectorId == "missing" {
+		return nil, errors.WrapGRPCError(errors.NotFound(errors.ReasonConnectorNotFound, "connector", req.ConnectorId))
+	}
+	return &connector_v1.GetConnectorResponse{Connector: &connector_v1.Connector{Id: req.ConnectorId}}, nil
+}
+
+func newServer(t *testing.T, srv connector_v1.SlackConnectorServiceServer, origins ...string) *httptest.Server {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(handler.UnaryRequestMetadataInterceptor(nil)))
+	connector_v1.RegisterSlackConnectorServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	mux := http.NewServeMux()
+	mux.Handle(web.NewHandler(conn))
+	server := httptest.NewServer(web.WithCORS(mux, origins, time.Hour))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConnect_GetConnector(t *testing.T) {
+	for name, opts := range map[string][]connect.ClientOption{
+		"connect":  nil,
+		"grpc-web": {connect.WithGRPCWeb()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stub := &stubServer{}
+			server := newServer(t, stub)
+			client := v1connect.NewSlackConnectorServiceClient(server.Client(), server.URL, opts...)
+
+			req := connect.NewRequest(&connector_v1.GetConnectorRequest{ConnectorId: "conn-1"})
+			req.Header().Set(handler.MetadataActor, "alice@example.com")
+			req.Header().Set(handler.MetadataForwardedFor, "198.51.100.4")
+			resp, err := client.GetConnector(context.Background(), req)
+
+			require.NoError(t, err)
+			require.Equal(t, "conn-1", resp.Msg.GetConnector().GetId())
+			require.NotEmpty(t, resp.Header().Get(handler.MetadataRequestID))
+			require.Equal(t, []string{"alice@example.com"}, stub.incoming.Get(handler.MetadataActor))
+			require.Equal(t, []string{"198.51.100.4, 127.0.0.1"}, stub.incoming.Get(handler.MetadataForwardedFor))
+		})
+	}
+}
+
+func TestConnect_KeepsErrorCodeAndDetails(t *testing.T) {
+	server := newServer(t, &stubServer{})
+	client := v1connect.NewSlackConnectorServiceClient(server.Client(), server.URL)
+
+	_, err := client.GetConnector(context.Background(), connect.NewRequest(&connector_v1.GetConnectorRequest{ConnectorId: "missing"}))
+
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	var cerr *connect.Error
+	require.ErrorAs(t, err, &cerr)
+	var reason string
+	for _, d := range cerr.Details() {
+		if v, err := d.Value(); err == nil {
+			if info, ok := v.(*errdetails.ErrorInfo); ok {
+				reason = info.GetReason()
+			}
+		}
+	}
+	require.Equal(t, errors.ReasonConnectorNotFound, reason)
+}
+
+func TestWithCORS_AnswersPreflightForAllowedOrigins(t *testing.T) {
+	server := newServer(t, &stubServer{}, "https://admin.example.com")
+
+	preflight := func(origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, server.URL+v1connect.SlackConnectorServiceGetConnectorProcedure, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "connect-protocol-version,content-type,x-actor")
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := preflight("https://admin.example.com")
+	require.Equal(t, "https://admin.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "3600", resp.Header.Get("Access-Control-Max-Age"))
+
+	resp = preflight("https://evil.example.com")
+	require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
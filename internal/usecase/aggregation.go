@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type AggregationUsecase interface {
+	FlushDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type aggregationUsecase struct {
+	repo   repository.AggregationRepository
+	sender ConnectorUsecase
+}
+
+// NewAggregationUsecase creates a new AggregationUsecase that posts digests through sender.
+func NewAggregationUsecase(repo repository.AggregationRepository, sender ConnectorUsecase) AggregationUsecase {
+	return &aggregationUsecase{repo: repo, sender: sender}
+}
+
+// FlushDue posts a digest for every aggregate whose window closed by now. Rows
+// are leased while they are flushed so that each digest is posted by a single
+// replica. An aggregate that collected a single message posts it unchanged;
+// digests carry the highest severity of the messages they replace.
+func (u *aggregationUsecase) FlushDue(ctx context.Context, now time.Time) (int, error) {
+	return u.repo.ProcessDue(ctx, now.UTC(), dispatchBatchSize, func(ctx context.Context, a *domain.MessageAggregate) error {
+		msg, err := u.sender.Send(ctx, SendMessageInput{
+			ConnectorID: a.ConnectorID,
+			Channel:     a.Channel,
+			Severity:    a.Severity,
+			Text:        digestText(a),
+		})
+
+		flushedAt := now.UTC()
+		a.FlushedAt = &flushedAt
+		if err != nil {
+			slog.Error("error posting message digest", "aggregate_id", a.ID, "error", err)
+			a.LastError = errors.PublicMessage(err)
+			return nil
+		}
+		a.MessageID = msg.ID
+		return nil
+	})
+}
+
+// digestText summarises the aggregated messages with their count and the
+// first and last time one was seen.
+func digestText(a *domain.MessageAggregate) string {
+	if a.Count <= 1 {
+		return a.FirstText
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d messages for %s (first seen %s, last seen %s)\n\n%s",
+		a.Count, a.GroupKey,
+		a.FirstSeen.UTC().Format(time.RFC3339), a.LastSeen.UTC().Format(time.RFC3339),
+		a.FirstText)
+	if a.LastText != a.FirstText {
+		fmt.Fprintf(&b, "\n\nLatest:\n%s", a.LastText)
+	}
+	return b.String()
+}
@@ -0,0 +1,190 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDedupRepository struct {
+	mock.Mock
+}
+
+func (m *mockDedupRepository) Claim(ctx context.Context, connectorID, key, messageID string, now, expiresAt time.Time) (bool, string, error) {
+	args := m.Called(ctx, connectorID, key, messageID, now, expiresAt)
+	return args.Bool(0), args.String(1), args.Error(2)
+}
+
+func (m *mockDedupRepository) Release(ctx context.Context, connectorID, key, messageID string) error {
+	args := m.Called(ctx, connectorID, key, messageID)
+	return args.Error(0)
+}
+
+type mockAggregationRepository struct {
+	mock.Mock
+	due []*domain.MessageAggregate
+}
+
+func (m *mockAggregationRepository) Add(ctx context.Context, a *domain.MessageAggregate) (*domain.MessageAggregate, error) {
+	args := m.Called(ctx, a)
+	out := args.Get(0)
+	if out == nil {
+		return nil, args.Error(1)
+	}
+	return out.(*domain.MessageAggregate), args.Error(1)
+}
+
+func (m *mockAggregationRepository) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, a *domain.MessageAggregate) error) (int, error) {
+	for _, a := range m.due {
+		if err := fn(ctx, a); err != nil {
+			return 0, err
+		}
+	}
+	return len(m.due), nil
+}
+
+func TestSend_SuppressesDuplicateDedupKey(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockDedup := new(mockDedupRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithDeduplication(mockDedup, 5*time.Minute))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).Once()
+	mockDedup.On("Claim", ctx, "conn-123", "disk-full:db-1", mock.Anything, mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return time.Until(expiresAt) > 9*time.Minute
+	})).Return(false, "msg-first", nil).Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{
+		ConnectorID: "conn-123",
+		Text:        "disk full",
+		DedupKey:    "disk-full:db-1",
+		DedupWindow: 10 * time.Minute,
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.MessageStatusSuppressed, msg.Status)
+	require.Equal(t, "msg-first", msg.ID)
+
+	mockDedup.AssertExpectations(t)
+}
+
+func TestSend_ReleasesDedupKeyWhenDeliveryFails(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	mockDedup := new(mockDedupRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack,
+		usecase.WithDeduplication(mockDedup, 5*time.Minute))
+
+	var claimedID string
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).Once()
+	mockDedup.On("Claim", ctx, "conn-123", "deploy-42", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { claimedID = args.String(3) }).
+		Return(true, "", nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("SendMessage", mock.Anything, "dummy-token", "C123456", "deployed").
+		Return(errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "token revoked")).Once()
+	mockDedup.On("Release", mock.Anything, "conn-123", "deploy-42", mock.MatchedBy(func(id string) bool {
+		return id == claimedID
+	})).Return(nil).Once()
+
+	_, err := u.Send(ctx, usecase.SendMessageInput{ConnectorID: "conn-123", Text: "deployed", DedupKey: "deploy-42"})
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+
+	mockDedup.AssertExpectations(t)
+}
+
+func TestSend_DedupKeyRequiresDeduplication(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).Once()
+
+	_, err := u.Send(ctx, usecase.SendMessageInput{ConnectorID: "conn-123", Text: "hi", DedupKey: "k"})
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+	require.Equal(t, errors.ReasonFeatureDisabled, typed.Reason)
+	require.Equal(t, "deduplication", typed.Metadata["feature"])
+	require.Empty(t, typed.Violations)
+
+	_, err = u.Send(ctx, usecase.SendMessageInput{ConnectorID: "conn-123", Text: "hi", DedupWindow: 48 * time.Hour})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestSend_AggregatesGroupKey(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockAggregates := new(mockAggregationRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithAggregation(mockAggregates, time.Minute))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).Once()
+	mockAggregates.On("Add", ctx, mock.MatchedBy(func(a *domain.MessageAggregate) bool {
+		return a.GroupKey == "cpu-high" && a.Channel == "#ops" && a.Severity == domain.SeverityError &&
+			a.FlushAt.Sub(a.FirstSeen) == 30*time.Second && a.FirstSeen.Location() == time.UTC
+	})).Return(&domain.MessageAggregate{ID: "agg-1", Count: 4}, nil).Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{
+		ConnectorID:       "conn-123",
+		Channel:           "#ops",
+		Severity:          domain.SeverityError,
+		Text:              "cpu high on web-1",
+		GroupKey:          "cpu-high",
+		AggregationWindow: 30 * time.Second,
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.MessageStatusAggregated, msg.Status)
+	require.Equal(t, "agg-1", msg.ID)
+
+	mockAggregates.AssertExpectations(t)
+}
+
+func TestFlushDue_PostsDigest(t *testing.T) {
+	ctx := context.Background()
+	first := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	now := first.Add(time.Minute)
+
+	single := &domain.MessageAggregate{ID: "agg-1", ConnectorID: "conn-1", GroupKey: "deploy", FirstText: "deployed", LastText: "deployed", Count: 1}
+	many := &domain.MessageAggregate{
+		ID: "agg-2", ConnectorID: "conn-1", Channel: "#ops", GroupKey: "cpu-high", Severity: domain.SeverityCritical,
+		FirstText: "cpu high on web-1", LastText: "cpu high on web-3", Count: 12,
+		FirstSeen: first, LastSeen: first.Add(50 * time.Second),
+	}
+	mockAggregates := &mockAggregationRepository{due: []*domain.MessageAggregate{single, many}}
+	sender := new(mockSender)
+	u := usecase.NewAggregationUsecase(mockAggregates, sender)
+
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{ConnectorID: "conn-1", Text: "deployed"}).
+		Return(&domain.Message{ID: "msg-1"}, nil).Once()
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{
+		ConnectorID: "conn-1",
+		Channel:     "#ops",
+		Severity:    domain.SeverityCritical,
+		Text: "12 messages for cpu-high (first seen 2024-05-06T09:00:00Z, last seen 2024-05-06T09:00:50Z)\n\n" +
+			"cpu high on web-1\n\nLatest:\ncpu high on web-3",
+	}).Return(nil, errors.ErrUnavailable).Once()
+
+	n, err := u.FlushDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.Equal(t, "msg-1", single.MessageID)
+	require.Equal(t, now, *single.FlushedAt)
+	require.NotNil(t, many.FlushedAt)
+	require.NotEmpty(t, many.LastError)
+
+	sender.AssertExpectations(t)
+}
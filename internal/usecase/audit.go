@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// RequestMetadata identifies the caller of a request for the audit log.
+// ClaimedActor is the actor the client named, which nothing verifies. SourceIP
+// is the client's address as reported by trusted proxies, PeerIP the address
+// the request arrived from.
+type RequestMetadata struct {
+	ClaimedActor string
+	RequestID    string
+	SourceIP     string
+	PeerIP       string
+}
+
+type requestMetadataKey struct{}
+
+// ContextWithRequestMetadata attaches the caller's metadata to ctx.
+func ContextWithRequestMetadata(ctx context.Context, md RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, md)
+}
+
+// RequestMetadataFromContext returns the metadata attached with ContextWithRequestMetadata.
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	md, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return md
+}
+
+// WithAuditLog records connector mutations and credential reads in audit.
+func WithAuditLog(audit AuditUsecase) Option {
+	return func(u *connectorUsecase) {
+		u.audit = audit
+	}
+}
+
+// recordAudit records action on a connector when an audit log is configured.
+func (u *connectorUsecase) recordAudit(ctx context.Context, action domain.AuditAction, tenantID, connectorID string, err error) {
+	if u.audit != nil {
+		u.audit.Record(ctx, action, tenantID, connectorID, err)
+	}
+}
+
+type AuditUsecase interface {
+	Record(ctx context.Context, action domain.AuditAction, tenantID, connectorID string, err error)
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter, limit int) ([]*domain.AuditEvent, error)
+}
+
+type auditUsecase struct {
+	repo repository.AuditRepository
+
+	mu     sync.Mutex
+	export io.Writer
+}
+
+// NewAuditUsecase creates a new AuditUsecase storing events in repo. When
+// export is not nil every event is also written to it as a JSON line.
+func NewAuditUsecase(repo repository.AuditRepository, export io.Writer) AuditUsecase {
+	return &auditUsecase{repo: repo, export: export}
+}
+
+// Record appends an audit event for action with the caller's metadata from
+// ctx. A non-nil err records a failure. Failing to record is logged and never
+// fails the audited operation.
+func (u *auditUsecase) Record(ctx context.Context, action domain.AuditAction, tenantID, connectorID string, err error) {
+	md := RequestMetadataFromContext(ctx)
+	e := &domain.AuditEvent{
+		TenantID:     tenantID,
+		ClaimedActor: md.ClaimedActor,
+		Action:       action,
+		ConnectorID:  connectorID,
+		RequestID:    md.RequestID,
+		SourceIP:     md.SourceIP,
+		PeerIP:       md.PeerIP,
+		Outcome:      domain.AuditOutcomeSuccess,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err != nil {
+		e.Outcome = domain.AuditOutcomeFailure
+		e.Error = errors.PublicMessage(err)
+	}
+
+	if err := u.repo.Append(context.WithoutCancel(ctx), e); err != nil {
+		slog.Error("error appending audit event", "action", action, "connector_id", connectorID, "error", err)
+	}
+	if u.export != nil {
+		u.writeExport(e)
+	}
+}
+
+func (u *auditUsecase) writeExport(e *domain.AuditEvent) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("error encoding audit event", "error", err)
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, err := u.export.Write(append(line, '\n')); err != nil {
+		slog.Error("error exporting audit event", "error", err)
+	}
+}
+
+// ListAuditEvents returns the events matching filter, newest first.
+func (u *auditUsecase) ListAuditEvents(ctx context.Context, filter domain.AuditFilter, limit int) ([]*domain.AuditEvent, error) {
+	if filter.TenantID == "" && filter.ConnectorID == "" {
+		return nil, errors.InvalidArgument(
+			errors.FieldViolation{Field: "tenant_id", Description: "tenant_id or connector_id is required"},
+			errors.FieldViolation{Field: "connector_id", Description: "tenant_id or connector_id is required"},
+		)
+	}
+	limit = pageSize(limit)
+
+	events, err := u.repo.List(ctx, filter, limit)
+	if err != nil {
+		slog.Error("error listing audit events", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return events, nil
+}
@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAuditRepository struct {
+	mock.Mock
+	appended []*domain.AuditEvent
+}
+
+func (m *mockAuditRepository) Append(ctx context.Context, e *domain.AuditEvent) error {
+	m.appended = append(m.appended, e)
+	return nil
+}
+
+func (m *mockAuditRepository) List(ctx context.Context, filter domain.AuditFilter, limit int) ([]*domain.AuditEvent, error) {
+	args := m.Called(ctx, filter, limit)
+	return args.Get(0).([]*domain.AuditEvent), args.Error(1)
+}
+
+func TestAuditRecord_UsesRequestMetadataAndExports(t *testing.T) {
+	repo := new(mockAuditRepository)
+	var export bytes.Buffer
+	audit := usecase.NewAuditUsecase(repo, &export)
+
+	ctx := usecase.ContextWithRequestMetadata(context.Background(), usecase.RequestMetadata{
+		ClaimedActor: "alice@example.com",
+		RequestID:    "req-1",
+		SourceIP:     "10.0.0.7",
+	})
+	audit.Record(ctx, domain.AuditConnectorCredentialsRead, "tenant-1", "conn-123", errors.ErrInternal)
+
+	require.Len(t, repo.appended, 1)
+	e := repo.appended[0]
+	require.Equal(t, "alice@example.com", e.ClaimedActor)
+	require.Equal(t, "req-1", e.RequestID)
+	require.Equal(t, "10.0.0.7", e.SourceIP)
+	require.Equal(t, domain.AuditOutcomeFailure, e.Outcome)
+
+	var exported domain.AuditEvent
+	require.NoError(t, json.Unmarshal(export.Bytes(), &exported))
+	require.Equal(t, domain.AuditConnectorCredentialsRead, exported.Action)
+	require.Equal(t, "conn-123", exported.ConnectorID)
+	require.Equal(t, byte('\n'), export.Bytes()[export.Len()-1])
+}
+
+func TestCreateConnector_RecordsAuditEvent(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	auditRepo := new(mockAuditRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack,
+		usecase.WithAuditLog(usecase.NewAuditUsecase(auditRepo, nil)))
+
+	mockSecrets.On("StoreCredentials", ctx, mock.Anything, "xoxb-token").Return(nil).Once()
+	mockSlack.On("ResolveChannelID", ctx, "xoxb-token", "general").Return("C123456", nil).Once()
+	mockRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+	conn, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{
+		WorkspaceID:    "WS123",
+		TenantID:       "tenant-1",
+		DefaultChannel: "general",
+		Credentials:    "xoxb-token",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, auditRepo.appended, 1)
+	e := auditRepo.appended[0]
+	require.Equal(t, domain.AuditConnectorCreate, e.Action)
+	require.Equal(t, conn.ID, e.ConnectorID)
+	require.Equal(t, "tenant-1", e.TenantID)
+	require.Equal(t, domain.AuditOutcomeSuccess, e.Outcome)
+}
+
+func TestCreateConnector_AuditsFailures(t *testing.T) {
+	auditRepo := new(mockAuditRepository)
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithAuditLog(usecase.NewAuditUsecase(auditRepo, nil)))
+
+	_, err := u.CreateConnector(context.Background(), usecase.CreateConnectorInput{TenantID: "tenant-1"})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	require.Len(t, auditRepo.appended, 1)
+	require.Equal(t, domain.AuditOutcomeFailure, auditRepo.appended[0].Outcome)
+}
+
+func TestListAuditEvents_RequiresTenantOrConnector(t *testing.T) {
+	u := usecase.NewAuditUsecase(new(mockAuditRepository), nil)
+
+	_, err := u.ListAuditEvents(context.Background(), domain.AuditFilter{}, 0)
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type BatchUsecase interface {
+	BatchSend(ctx context.Context, items []SendMessageInput) ([]BatchResult, error)
+	SetWorkspaceRate(rate float64, burst int)
+}
+
+// BatchOptions bounds batch sends. Parallelism is the number of messages sent
+// at once; WorkspaceRate and WorkspaceBurst limit the messages per second
+// posted to a single workspace across every batch handled by this server.
+type BatchOptions struct {
+	MaxItems       int
+	Parallelism    int
+	WorkspaceRate  float64
+	WorkspaceBurst int
+}
+
+// BatchResult is the outcome of one item of a batch, in request order. Err is
+// set when that item failed; other items are unaffected.
+type BatchResult struct {
+	Index   int
+	Message *domain.Message
+	Err     error
+}
+
+type batchUsecase struct {
+	connectors repository.ConnectorRepository
+	sender     ConnectorUsecase
+	opts       BatchOptions
+
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// NewBatchUsecase creates a new BatchUsecase that delivers through sender.
+func NewBatchUsecase(connectors repository.ConnectorRepository, sender ConnectorUsecase, opts BatchOptions) BatchUsecase {
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = 1
+	}
+	u := &batchUsecase{
+		connectors: connectors,
+		sender:     sender,
+		opts:       opts,
+		limiters:   make(map[string]*rate.Limiter),
+	}
+	u.SetWorkspaceRate(opts.WorkspaceRate, opts.WorkspaceBurst)
+	return u
+}
+
+// SetWorkspaceRate replaces WorkspaceRate and WorkspaceBurst, including for
+// workspaces whose limiter already exists. A zero rate disables the limit.
+func (u *batchUsecase) SetWorkspaceRate(r float64, burst int) {
+	limit := rate.Inf
+	if r > 0 {
+		limit = rate.Limit(r)
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.limit, u.burst = limit, burst
+	for _, l := range u.limiters {
+		l.SetLimit(limit)
+		l.SetBurst(burst)
+	}
+}
+
+// BatchSend sends every item concurrently, at most Parallelism at a time and
+// no faster than the rate limit of each item's workspace. Failures are
+// reported per item; the call itself only fails for an invalid batch.
+func (u *batchUsecase) BatchSend(ctx context.Context, items []SendMessageInput) ([]BatchResult, error) {
+	if len(items) == 0 {
+		return nil, errors.InvalidArgument(errors.FieldViolation{Field: "messages", Description: "at least one message is required"})
+	}
+	if u.opts.MaxItems > 0 && len(items) > u.opts.MaxItems {
+		return nil, errors.InvalidArgument(errors.FieldViolation{
+			Field:       "messages",
+			Description: "at most " + strconv.Itoa(u.opts.MaxItems) + " messages are allowed per batch",
+		})
+	}
+
+	results := make([]BatchResult, len(items))
+	workspaces := u.workspaces(ctx, items, results)
+
+	sem := make(chan struct{}, u.opts.Parallelism)
+	var wg sync.WaitGroup
+	for i, in := range items {
+		results[i].Index = i
+		if results[i].Err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *BatchResult, in SendMessageInput, workspace string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				res.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			if err := u.limiter(workspace).Wait(ctx); err != nil {
+				res.Err = ctx.Err()
+				if res.Err == nil {
+					res.Err = errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "workspace rate limit exceeded")
+				}
+				return
+			}
+			res.Message, res.Err = u.sender.Send(ctx, in)
+		}(&results[i], in, workspaces[in.ConnectorID])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// workspaces looks up each distinct connector once and returns the rate limit
+// key of its workspace. Items of unknown connectors are failed in results.
+func (u *batchUsecase) workspaces(ctx context.Context, items []SendMessageInput, results []BatchResult) map[string]string {
+	keys := make(map[string]string)
+	failed := make(map[string]error)
+	for i, in := range items {
+		if err := validateSendInput(in); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err, ok := failed[in.ConnectorID]; ok {
+			results[i].Err = err
+			continue
+		}
+		if _, ok := keys[in.ConnectorID]; ok {
+			continue
+		}
+
+		conn, err := u.connectors.GetByID(ctx, in.ConnectorID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				failed[in.ConnectorID] = errors.NotFound(errors.ReasonConnectorNotFound, "connector", in.ConnectorID)
+			} else {
+				slog.Error("error getting connector by id", "error", err)
+				failed[in.ConnectorID] = errors.ErrInternal
+			}
+			results[i].Err = failed[in.ConnectorID]
+			continue
+		}
+		keys[in.ConnectorID] = string(conn.Provider) + ":" + conn.WorkspaceID
+	}
+	return keys
+}
+
+// limiter returns the shared rate limiter of a workspace.
+func (u *batchUsecase) limiter(workspace string) *rate.Limiter {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	l, ok := u.limiters[workspace]
+	if !ok {
+		l = rate.NewLimiter(u.limit, u.burst)
+		u.limiters[workspace] = l
+	}
+	return l
+}
@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSender records how many sends run at once and fails connector "broken".
+type countingSender struct {
+	usecase.ConnectorUsecase
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	sent     []time.Time
+}
+
+func (s *countingSender) Send(ctx context.Context, in usecase.SendMessageInput) (*domain.Message, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.peak {
+		s.peak = s.inFlight
+	}
+	s.sent = append(s.sent, time.Now())
+	s.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	if in.ConnectorID == "broken" {
+		return nil, errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "token revoked")
+	}
+	return &domain.Message{ID: "msg-" + in.Text, Status: domain.MessageStatusDelivered}, nil
+}
+
+func TestBatchSend_PartialFailure(t *testing.T) {
+	ctx := context.Background()
+	mockConnRepo := new(mockConnectorRepository)
+	sender := &countingSender{}
+	u := usecase.NewBatchUsecase(mockConnRepo, sender, usecase.BatchOptions{MaxItems: 10, Parallelism: 2})
+
+	mockConnRepo.On("GetByID", ctx, "conn-1").Return(&domain.Connector{ID: "conn-1", WorkspaceID: "ws-1"}, nil).Once()
+	mockConnRepo.On("GetByID", ctx, "broken").Return(&domain.Connector{ID: "broken", WorkspaceID: "ws-2"}, nil).Once()
+	mockConnRepo.On("GetByID", ctx, "missing").Return(nil, sql.ErrNoRows).Once()
+
+	results, err := u.BatchSend(ctx, []usecase.SendMessageInput{
+		{ConnectorID: "conn-1", Text: "a"},
+		{ConnectorID: "missing", Text: "b"},
+		{ConnectorID: "broken", Text: "c"},
+		{ConnectorID: "conn-1"},
+		{ConnectorID: "conn-1", Text: "e"},
+		{ConnectorID: "missing", Text: "f"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "msg-a", results[0].Message.ID)
+	require.ErrorIs(t, results[1].Err, errors.ErrNotFound)
+	require.ErrorIs(t, results[2].Err, errors.ErrFailedPrecondition)
+	require.ErrorIs(t, results[3].Err, errors.ErrInvalidArgument)
+	require.NoError(t, results[4].Err)
+	require.ErrorIs(t, results[5].Err, errors.ErrNotFound)
+	for i, res := range results {
+		require.Equal(t, i, res.Index)
+	}
+
+	require.LessOrEqual(t, sender.peak, 2)
+	mockConnRepo.AssertExpectations(t)
+}
+
+func TestBatchSend_RespectsWorkspaceRate(t *testing.T) {
+	ctx := context.Background()
+	mockConnRepo := new(mockConnectorRepository)
+	sender := &countingSender{}
+	u := usecase.NewBatchUsecase(mockConnRepo, sender, usecase.BatchOptions{
+		MaxItems:       10,
+		Parallelism:    10,
+		WorkspaceRate:  50,
+		WorkspaceBurst: 1,
+	})
+
+	mockConnRepo.On("GetByID", ctx, mock.Anything).Return(&domain.Connector{ID: "conn-1", WorkspaceID: "ws-1"}, nil).Once()
+
+	items := make([]usecase.SendMessageInput, 5)
+	for i := range items {
+		items[i] = usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hi"}
+	}
+	start := time.Now()
+	results, err := u.BatchSend(ctx, items)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NoError(t, res.Err)
+	}
+
+	// Five sends at 50/s with no burst need at least four 20ms intervals.
+	require.GreaterOrEqual(t, time.Since(start), 75*time.Millisecond)
+}
+
+func TestBatchSend_SetWorkspaceRate(t *testing.T) {
+	ctx := context.Background()
+	mockConnRepo := new(mockConnectorRepository)
+	u := usecase.NewBatchUsecase(mockConnRepo, &countingSender{}, usecase.BatchOptions{
+		MaxItems:       10,
+		Parallelism:    10,
+		WorkspaceRate:  0.1,
+		WorkspaceBurst: 1,
+	})
+
+	mockConnRepo.On("GetByID", ctx, mock.Anything).Return(&domain.Connector{ID: "conn-1", WorkspaceID: "ws-1"}, nil)
+
+	// The first send uses up the burst of the workspace's limiter.
+	_, err := u.BatchSend(ctx, []usecase.SendMessageInput{{ConnectorID: "conn-1", Text: "hi"}})
+	require.NoError(t, err)
+
+	// Lifting the limit applies to the existing limiter too.
+	u.SetWorkspaceRate(0, 1)
+	items := make([]usecase.SendMessageInput, 5)
+	for i := range items {
+		items[i] = usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hi"}
+	}
+	start := time.Now()
+	results, err := u.BatchSend(ctx, items)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NoError(t, res.Err)
+	}
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestBatchSend_RejectsOversizedBatch(t *testing.T) {
+	u := usecase.NewBatchUsecase(new(mockConnectorRepository), &countingSender{}, usecase.BatchOptions{MaxItems: 2})
+
+	_, err := u.BatchSend(context.Background(), make([]usecase.SendMessageInput, 3))
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	_, err = u.BatchSend(context.Background(), nil)
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,60 +16,212 @@ import (
 )
 
 type ConnectorUsecase interface {
-	CreateConnector(ctx context.Context, workspaceID, tenantID, defaultChannel, slackToken string) (*domain.Connector, error)
+	CreateConnector(ctx context.Context, in CreateConnectorInput) (*domain.Connector, error)
 	GetConnector(ctx context.Context, connectorID string) (*domain.Connector, error)
+	ListConnectors(ctx context.Context, tenantID, pageToken string, limit int) ([]*domain.Connector, string, error)
+	UpdateConnector(ctx context.Context, in UpdateConnectorInput) (*domain.Connector, error)
+	VerifyConnector(ctx context.Context, connectorID string) (*VerifyResult, error)
 	DeleteConnector(ctx context.Context, connectorID string) error
+	SetDeliveryPolicy(ctx context.Context, connectorID string, policy *domain.DeliveryPolicy) (*domain.Connector, error)
 	SendMessage(ctx context.Context, connectorID, msg string) error
+	Send(ctx context.Context, in SendMessageInput) (*domain.Message, error)
+	ListMessages(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error)
+}
+
+// SendMessageInput is a message to deliver. Either Text or TemplateID is set;
+// templates are rendered with Variables. Channel, when set, is a channel name
+// used instead of the connector's default channel.
+//
+// Severity is checked against the connector's delivery policy.
+//
+// A DedupKey suppresses later sends with the same key to the connector for
+// DedupWindow. A GroupKey collects sends for AggregationWindow and posts them
+// as a single digest. Zero windows use the server defaults.
+type SendMessageInput struct {
+	ConnectorID       string
+	Channel           string
+	Severity          domain.Severity
+	Text              string
+	TemplateID        string
+	Variables         map[string]string
+	DedupKey          string
+	DedupWindow       time.Duration
+	GroupKey          string
+	AggregationWindow time.Duration
+}
+
+// Upper bounds of the dedup and aggregation windows a caller may request.
+const (
+	maxDedupWindow       = 24 * time.Hour
+	maxAggregationWindow = time.Hour
+)
+
+// Default and maximum number of items returned by a page of the List calls.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// pageSize returns the requested page size capped at maxPageSize, or
+// defaultPageSize when none was requested.
+func pageSize(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultPageSize
+	case limit > maxPageSize:
+		return maxPageSize
+	}
+	return limit
+}
+
+// CreateConnectorInput holds everything needed to create a connector.
+// Credentials is the provider credential: a bot token for Slack, the incoming
+// webhook URL for Microsoft Teams, Discord and Mattermost.
+type CreateConnectorInput struct {
+	Provider       domain.Provider
+	WorkspaceID    string
+	TenantID       string
+	DefaultChannel string
+	Credentials    string
+	DeliveryPolicy *domain.DeliveryPolicy
+}
+
+// Option configures optional collaborators of the connector usecase.
+type Option func(*connectorUsecase)
+
+// WithMessenger registers the Messenger used for connectors of the given provider.
+func WithMessenger(provider domain.Provider, m services.Messenger) Option {
+	return func(u *connectorUsecase) {
+		u.messengers[provider] = m
+	}
+}
+
+// WithRetryPolicy retries deliveries that fail with a rate limit or an unavailable provider.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(u *connectorUsecase) {
+		u.retryPolicy = p
+	}
+}
+
+// WithMessageLog records every delivery, including dead-lettered ones, in messages.
+func WithMessageLog(messages repository.MessageRepository) Option {
+	return func(u *connectorUsecase) {
+		u.messages = messages
+	}
+}
+
+// WithTemplates lets send requests reference the tenant's stored templates.
+func WithTemplates(templates repository.TemplateRepository) Option {
+	return func(u *connectorUsecase) {
+		u.templates = templates
+	}
+}
+
+// WithDeduplication enables dedup keys, suppressing duplicates for window
+// unless the request sets its own.
+func WithDeduplication(dedup repository.DedupRepository, window time.Duration) Option {
+	return func(u *connectorUsecase) {
+		u.dedup = dedup
+		u.dedupWindow = window
+	}
+}
+
+// WithAggregation enables group keys, collecting messages for window unless the
+// request sets its own. Digests are posted by an AggregationUsecase.
+func WithAggregation(aggregates repository.AggregationRepository, window time.Duration) Option {
+	return func(u *connectorUsecase) {
+		u.aggregates = aggregates
+		u.aggregationWindow = window
+	}
 }
 
 type connectorUsecase struct {
-	repo    repository.ConnectorRepository
-	secrets services.AWSSecretsManager
-	slack   services.SlackClient
+	repo              repository.ConnectorRepository
+	templates         repository.TemplateRepository
+	secrets           services.AWSSecretsManager
+	messengers        map[domain.Provider]services.Messenger
+	messages          repository.MessageRepository
+	dedup             repository.DedupRepository
+	dedupWindow       time.Duration
+	deferrals         repository.ScheduledMessageRepository
+	aggregates        repository.AggregationRepository
+	aggregationWindow time.Duration
+	events            repository.EventRepository
+	audit             AuditUsecase
+	quotas            repository.QuotaRepository
+	defaultQuota      *QuotaDefaults
+	retryPolicy       RetryPolicy
 }
 
-// NewConnectorUsecase creates a new ConnectorService.
+// NewConnectorUsecase creates a new ConnectorService. The Slack client is always
+// registered; other providers are added with WithMessenger.
 func NewConnectorUsecase(
 	repo repository.ConnectorRepository,
 	secrets services.AWSSecretsManager,
 	slack services.SlackClient,
+	opts ...Option,
 ) ConnectorUsecase {
-	return &connectorUsecase{
-		repo:    repo,
-		secrets: secrets,
-		slack:   slack,
+	u := &connectorUsecase{
+		repo:        repo,
+		secrets:     secrets,
+		messengers:  map[domain.Provider]services.Messenger{domain.ProviderSlack: slack},
+		retryPolicy: noRetries,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
-// CreateConnector coordinates creating a new connector in DB and storing the Slack token in Secrets Manager.
-func (s *connectorUsecase) CreateConnector(
-	ctx context.Context,
-	workspaceID, tenantID, channelName, slackToken string,
-) (*domain.Connector, error) {
+// CreateConnector coordinates creating a new connector in DB and storing the provider credentials in Secrets Manager.
+func (s *connectorUsecase) CreateConnector(ctx context.Context, in CreateConnectorInput) (_ *domain.Connector, err error) {
 	connID := uuid.NewString()
+	defer func() { s.recordAudit(ctx, domain.AuditConnectorCreate, in.TenantID, connID, err) }()
+
+	if err := validateRequired(map[string]string{
+		"workspace_id":         in.WorkspaceID,
+		"tenant_id":            in.TenantID,
+		"default_channel_name": in.DefaultChannel,
+		"credentials":          in.Credentials,
+	}); err != nil {
+		return nil, err
+	}
+	if err := validateDeliveryPolicy(in.DeliveryPolicy); err != nil {
+		return nil, err
+	}
+	if err := s.checkConnectorQuota(ctx, in.TenantID); err != nil {
+		return nil, err
+	}
 
-	if slackToken == "" || channelName == "" || workspaceID == "" || tenantID == "" {
-		return nil, errors.ErrInvalidArgument
+	provider := in.Provider
+	if provider == "" {
+		provider = domain.ProviderSlack
+	}
+	messenger, err := s.messenger(provider)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.secrets.StoreSlackToken(ctx, connID, slackToken); err != nil {
-		slog.Error("error storing slack token", "error", err)
+	if err := s.secrets.StoreCredentials(ctx, connID, in.Credentials); err != nil {
+		slog.Error("error storing connector credentials", "error", err)
 		return nil, errors.ErrInternal
 	}
 
-	channelID, err := s.slack.ResolveChannelID(ctx, slackToken, channelName)
+	channelID, err := messenger.ResolveChannelID(ctx, in.Credentials, in.DefaultChannel)
 	if err != nil {
-		slog.Error("error resolving channel id using the channel name", "error", err)
-		return nil, errors.ErrInvalidArgument
+		slog.Error("error resolving channel id using the channel name", "provider", provider, "error", err)
+		return nil, errors.Typed(err, errors.ErrInvalidArgument)
 	}
 
 	now := time.Now()
 
 	connector := &domain.Connector{
 		ID:               connID,
-		WorkspaceID:      workspaceID,
-		TenantID:         tenantID,
+		Provider:         provider,
+		WorkspaceID:      in.WorkspaceID,
+		TenantID:         in.TenantID,
 		DefaultChannelID: channelID,
+		DeliveryPolicy:   in.DeliveryPolicy,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
@@ -78,6 +231,11 @@ func (s *connectorUsecase) CreateConnector(
 		return nil, errors.ErrInternal
 	}
 
+	s.emit(ctx, connector, domain.EventConnectorCreated, map[string]string{
+		"provider":           string(connector.Provider),
+		"workspace_id":       connector.WorkspaceID,
+		"default_channel_id": connector.DefaultChannelID,
+	})
 	return connector, nil
 }
 
@@ -86,7 +244,7 @@ func (s *connectorUsecase) GetConnector(ctx context.Context, connectorID string)
 	connector, err := s.repo.GetByID(ctx, connectorID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.ErrNotFound
+			return nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", connectorID)
 		}
 		slog.Error("error getting connector by id", "error", err)
 		return nil, errors.ErrInternal
@@ -94,47 +252,346 @@ func (s *connectorUsecase) GetConnector(ctx context.Context, connectorID string)
 	return connector, nil
 }
 
-// DeleteConnector removes the connector from DB and the Slack token from Secrets Manager.
-func (s *connectorUsecase) DeleteConnector(ctx context.Context, connectorID string) error {
+// ListConnectors returns a page of the tenant's connectors ordered by ID, and
+// the token of the next page, which is empty on the last page.
+func (s *connectorUsecase) ListConnectors(ctx context.Context, tenantID, pageToken string, limit int) ([]*domain.Connector, string, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, "", err
+	}
+	limit = pageSize(limit)
+
+	connectors, err := s.repo.ListByTenant(ctx, tenantID, pageToken, limit)
+	if err != nil {
+		slog.Error("error listing connectors", "error", err)
+		return nil, "", errors.ErrInternal
+	}
+	var next string
+	if len(connectors) == limit {
+		next = connectors[len(connectors)-1].ID
+	}
+	return connectors, next, nil
+}
+
+// DeleteConnector removes the connector from DB and its credentials from Secrets Manager.
+func (s *connectorUsecase) DeleteConnector(ctx context.Context, connectorID string) (err error) {
+	// Events and audit records carry the tenant, so look the connector up
+	// while it still exists.
+	var conn *domain.Connector
+	if s.events != nil || s.audit != nil {
+		conn, err = s.repo.GetByID(ctx, connectorID)
+		if err != nil && err != sql.ErrNoRows {
+			slog.Error("error getting connector by id", "error", err)
+			return errors.ErrInternal
+		}
+	}
+	defer func() {
+		tenantID := ""
+		if conn != nil {
+			tenantID = conn.TenantID
+		}
+		s.recordAudit(ctx, domain.AuditConnectorDelete, tenantID, connectorID, err)
+	}()
+
 	if err := s.repo.Delete(ctx, connectorID); err != nil {
 		slog.Error("error deleting connector", "error", err)
 		return errors.ErrInternal
 	}
 
-	if err := s.secrets.DeleteSlackToken(ctx, connectorID); err != nil {
-		slog.Error("error deleting slack token", "error", err)
+	if err := s.secrets.DeleteCredentials(ctx, connectorID); err != nil {
+		slog.Error("error deleting connector credentials", "error", err)
 		return errors.ErrInternal
 	}
 
+	if conn != nil {
+		s.emit(ctx, conn, domain.EventConnectorDeleted, nil)
+	}
 	return nil
 }
 
+// SendMessage posts msg to the connector's default channel through its provider.
 func (u *connectorUsecase) SendMessage(ctx context.Context, connectorID, msg string) error {
-	if connectorID == "" || msg == "" {
-		return errors.ErrInvalidArgument
+	_, err := u.Send(ctx, SendMessageInput{ConnectorID: connectorID, Text: msg})
+	return err
+}
+
+// Send renders the message if it references a template and posts it to the
+// requested channel, or the connector's default channel, retrying according to
+// the retry policy. Messages that still fail are dead-lettered. Duplicates of a
+// dedup key are returned as suppressed and grouped messages as aggregated
+// without being posted. The connector's delivery policy may drop, defer or
+// downgrade the message; its decision is set on the returned message. Messages
+// beyond the tenant's quota fail with ResourceExhausted.
+func (u *connectorUsecase) Send(ctx context.Context, in SendMessageInput) (*domain.Message, error) {
+	if err := validateSendInput(in); err != nil {
+		return nil, err
 	}
 
-	conn, err := u.repo.GetByID(ctx, connectorID)
+	conn, err := u.repo.GetByID(ctx, in.ConnectorID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.ErrNotFound
+			return nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", in.ConnectorID)
 		}
 		slog.Error("error getting connector by id", "error", err)
-		return errors.ErrInternal
+		return nil, errors.ErrInternal
+	}
+
+	text, err := u.messageText(ctx, conn, in)
+	if err != nil {
+		return nil, err
+	}
+
+	messenger, err := u.messenger(conn.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Message times are stored in UTC columns without a time zone and compared
+	// against UTC windows, so they are normalised once here.
+	now := time.Now().UTC()
+	msg := &domain.Message{
+		ID:          uuid.NewString(),
+		ConnectorID: conn.ID,
+		TenantID:    conn.TenantID,
+		ChannelID:   conn.DefaultChannelID,
+		Text:        text,
+		Status:      domain.MessageStatusDelivered,
+		CreatedAt:   now,
+	}
+
+	if in.DedupKey != "" {
+		claimed, err := u.claimDedupKey(ctx, msg, in)
+		if err != nil || !claimed {
+			return msg, err
+		}
+	}
+	if handled, err := u.applyPolicy(ctx, conn, msg, in); err != nil || handled {
+		if err != nil {
+			u.releaseDedupKey(ctx, msg, in)
+			return nil, err
+		}
+		return msg, nil
+	}
+	if in.GroupKey != "" {
+		return msg, u.aggregate(ctx, msg, in)
+	}
+	if err := u.consumeMessageQuota(ctx, conn.TenantID, now); err != nil {
+		u.releaseDedupKey(ctx, msg, in)
+		return nil, err
 	}
 
 	// Retrieve secret
-	secretName := "connector/" + connectorID
-	token, err := u.secrets.GetSlackToken(ctx, secretName)
+	token, err := u.secrets.GetCredentials(ctx, in.ConnectorID)
+	u.recordAudit(ctx, domain.AuditConnectorCredentialsRead, conn.TenantID, conn.ID, err)
 	if err != nil {
-		slog.Error("error getting slack token from secret manager", "error", err)
-		return errors.ErrInternal
+		slog.Error("error getting connector credentials from secret manager", "error", err)
+		u.releaseDedupKey(ctx, msg, in)
+		return nil, errors.ErrInternal
 	}
 
-	if err := u.slack.SendMessage(ctx, token, conn.DefaultChannelID, msg); err != nil {
-		slog.Error("error sending slack message", "error", err)
-		return errors.ErrInternal
+	if in.Channel != "" {
+		msg.ChannelID, err = messenger.ResolveChannelID(ctx, token, in.Channel)
+		if err != nil {
+			slog.Error("error resolving channel id using the channel name", "provider", conn.Provider, "error", err)
+			u.releaseDedupKey(ctx, msg, in)
+			return nil, errors.Typed(err, errors.ErrInvalidArgument)
+		}
+	}
+
+	attempts, err := u.retryPolicy.retry(services.WithDeliveryID(ctx, msg.ID), func(ctx context.Context) error {
+		return messenger.SendMessage(ctx, token, msg.ChannelID, msg.Text)
+	})
+	msg.Attempts = attempts
+	msg.UpdatedAt = time.Now().UTC()
+	if err != nil {
+		msg.Status = domain.MessageStatusDeadLettered
+		msg.LastError = errors.PublicMessage(err)
+	}
+	u.recordMessage(ctx, msg)
+	u.emitDelivery(ctx, conn, msg, err)
+	if err != nil {
+		slog.Error("error sending message", "provider", conn.Provider, "attempts", attempts, "error", err)
+		u.releaseDedupKey(ctx, msg, in)
+		return nil, errors.Typed(err, errors.ErrInternal)
+	}
+
+	return msg, nil
+}
+
+// ListMessages returns the connector's delivery log, newest first.
+func (u *connectorUsecase) ListMessages(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error) {
+	if err := validateRequired(map[string]string{"connector_id": connectorID}); err != nil {
+		return nil, err
+	}
+	if u.messages == nil {
+		return nil, errors.New(errors.ErrFailedPrecondition, errors.ReasonFeatureDisabled, "the message log is not enabled on this server").
+			WithMetadata("feature", "message_log")
+	}
+	limit = pageSize(limit)
+
+	messages, err := u.messages.ListByConnector(ctx, connectorID, limit)
+	if err != nil {
+		slog.Error("error listing messages", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return messages, nil
+}
+
+// claimDedupKey reports whether msg is the first send of its dedup key within
+// the window. Duplicates are returned as suppressed with the ID of the message
+// that was delivered.
+func (u *connectorUsecase) claimDedupKey(ctx context.Context, msg *domain.Message, in SendMessageInput) (bool, error) {
+	if u.dedup == nil {
+		return false, errors.New(errors.ErrFailedPrecondition, errors.ReasonFeatureDisabled, "deduplication is not enabled on this server").
+			WithMetadata("feature", "deduplication")
+	}
+	window := in.DedupWindow
+	if window == 0 {
+		window = u.dedupWindow
+	}
+
+	claimed, messageID, err := u.dedup.Claim(ctx, msg.ConnectorID, in.DedupKey, msg.ID, msg.CreatedAt, msg.CreatedAt.Add(window))
+	if err != nil {
+		slog.Error("error claiming dedup key", "error", err)
+		return false, errors.ErrInternal
+	}
+	if !claimed {
+		msg.ID = messageID
+		msg.Status = domain.MessageStatusSuppressed
+	}
+	return claimed, nil
+}
+
+// releaseDedupKey frees the dedup key of a send that failed, so that it can be retried.
+func (u *connectorUsecase) releaseDedupKey(ctx context.Context, msg *domain.Message, in SendMessageInput) {
+	if in.DedupKey == "" || u.dedup == nil {
+		return
+	}
+	if err := u.dedup.Release(context.WithoutCancel(ctx), msg.ConnectorID, in.DedupKey, msg.ID); err != nil {
+		slog.Error("error releasing dedup key", "message_id", msg.ID, "error", err)
+	}
+}
+
+// aggregate adds msg to the open aggregate of its group. The returned message
+// carries the aggregate ID and is posted later as part of a digest.
+func (u *connectorUsecase) aggregate(ctx context.Context, msg *domain.Message, in SendMessageInput) error {
+	if u.aggregates == nil {
+		return errors.New(errors.ErrFailedPrecondition, errors.ReasonFeatureDisabled, "aggregation is not enabled on this server").
+			WithMetadata("feature", "aggregation")
+	}
+	window := in.AggregationWindow
+	if window == 0 {
+		window = u.aggregationWindow
 	}
 
+	a, err := u.aggregates.Add(ctx, &domain.MessageAggregate{
+		ID:          uuid.NewString(),
+		ConnectorID: msg.ConnectorID,
+		Channel:     in.Channel,
+		GroupKey:    in.GroupKey,
+		Severity:    in.Severity,
+		FirstText:   msg.Text,
+		FirstSeen:   msg.CreatedAt,
+		FlushAt:     msg.CreatedAt.Add(window),
+	})
+	if err != nil {
+		slog.Error("error adding message to aggregate", "error", err)
+		u.releaseDedupKey(ctx, msg, in)
+		return errors.ErrInternal
+	}
+	msg.ID = a.ID
+	msg.Status = domain.MessageStatusAggregated
 	return nil
 }
+
+// messageText returns the text to send, rendering the referenced template. Templates
+// of other tenants are reported as not found.
+func (u *connectorUsecase) messageText(ctx context.Context, conn *domain.Connector, in SendMessageInput) (string, error) {
+	if in.TemplateID == "" {
+		return in.Text, nil
+	}
+	if u.templates == nil {
+		return "", errors.New(errors.ErrFailedPrecondition, errors.ReasonFeatureDisabled, "templates are not enabled on this server").
+			WithMetadata("feature", "templates")
+	}
+
+	t, err := getTemplate(ctx, u.templates, in.TemplateID)
+	if err != nil {
+		return "", err
+	}
+	if t.TenantID != conn.TenantID {
+		return "", errors.NotFound(errors.ReasonTemplateNotFound, "template", in.TemplateID)
+	}
+	return renderTemplate(ctx, t.Name, t.Body, in.Variables)
+}
+
+// recordMessage writes the outcome of a delivery to the message log. Failing to
+// record never fails the send itself.
+func (u *connectorUsecase) recordMessage(ctx context.Context, m *domain.Message) {
+	if u.messages == nil {
+		return
+	}
+	if err := u.messages.Create(context.WithoutCancel(ctx), m); err != nil {
+		slog.Error("error recording message", "message_id", m.ID, "error", err)
+	}
+}
+
+// messenger returns the Messenger registered for provider. Connectors stored
+// before providers existed have no provider and are treated as Slack.
+func (u *connectorUsecase) messenger(provider domain.Provider) (services.Messenger, error) {
+	if provider == "" {
+		provider = domain.ProviderSlack
+	}
+	m, ok := u.messengers[provider]
+	if !ok || m == nil {
+		return nil, errors.New(errors.ErrInvalidArgument, errors.ReasonUnsupportedProvider, "unsupported provider: "+string(provider)).
+			WithFieldViolation("provider", "provider is not supported by this server")
+	}
+	return m, nil
+}
+
+func validateSendInput(in SendMessageInput) error {
+	var violations []errors.FieldViolation
+	if in.ConnectorID == "" {
+		violations = append(violations, errors.FieldViolation{Field: "connector_id", Description: "connector_id is required"})
+	}
+	if in.Text == "" && in.TemplateID == "" {
+		violations = append(violations, errors.FieldViolation{Field: "text", Description: "text or template_id is required"})
+	}
+	if in.Severity != "" && !in.Severity.Valid() {
+		violations = append(violations, errors.FieldViolation{Field: "severity", Description: "unknown severity " + string(in.Severity)})
+	}
+	if in.DedupWindow < 0 || in.DedupWindow > maxDedupWindow {
+		violations = append(violations, errors.FieldViolation{Field: "dedup_window_seconds", Description: "dedup window must be between 0 and 24h"})
+	}
+	if in.DedupWindow != 0 && in.DedupKey == "" {
+		violations = append(violations, errors.FieldViolation{Field: "dedup_key", Description: "dedup_key is required with a dedup window"})
+	}
+	if in.AggregationWindow < 0 || in.AggregationWindow > maxAggregationWindow {
+		violations = append(violations, errors.FieldViolation{Field: "aggregation_window_seconds", Description: "aggregation window must be between 0 and 1h"})
+	}
+	if in.AggregationWindow != 0 && in.GroupKey == "" {
+		violations = append(violations, errors.FieldViolation{Field: "group_key", Description: "group_key is required with an aggregation window"})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.InvalidArgument(violations...)
+}
+
+// validateRequired reports every empty field at once as BadRequest field violations.
+func validateRequired(fields map[string]string) error {
+	var violations []errors.FieldViolation
+	for field, value := range fields {
+		if value == "" {
+			violations = append(violations, errors.FieldViolation{
+				Field:       field,
+				Description: field + " is required",
+			})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+	return errors.InvalidArgument(violations...)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/services"
 	"github.com/iBoBoTi/connector-service/internal/usecase"
 	"github.com/iBoBoTi/connector-service/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -31,6 +34,22 @@ func (m *mockConnectorRepository) GetByID(ctx context.Context, connectorID strin
 	return conn.(*domain.Connector), args.Error(1)
 }
 
+func (m *mockConnectorRepository) ListByTenant(ctx context.Context, tenantID, afterID string, limit int) ([]*domain.Connector, error) {
+	args := m.Called(ctx, tenantID, afterID, limit)
+	conns, _ := args.Get(0).([]*domain.Connector)
+	return conns, args.Error(1)
+}
+
+func (m *mockConnectorRepository) UpdateDeliveryPolicy(ctx context.Context, connectorID string, policy *domain.DeliveryPolicy, updatedAt time.Time) error {
+	args := m.Called(ctx, connectorID, policy, updatedAt)
+	return args.Error(0)
+}
+
+func (m *mockConnectorRepository) UpdateDefaultChannel(ctx context.Context, connectorID, channelID string, updatedAt time.Time) error {
+	args := m.Called(ctx, connectorID, channelID, updatedAt)
+	return args.Error(0)
+}
+
 func (m *mockConnectorRepository) Delete(ctx context.Context, connectorID string) error {
 	args := m.Called(ctx, connectorID)
 	return args.Error(0)
@@ -40,17 +59,17 @@ type mockSecretsManager struct {
 	mock.Mock
 }
 
-func (m *mockSecretsManager) StoreSlackToken(ctx context.Context, connectorID, token string) error {
-	args := m.Called(ctx, connectorID, token)
+func (m *mockSecretsManager) StoreCredentials(ctx context.Context, connectorID, credentials string) error {
+	args := m.Called(ctx, connectorID, credentials)
 	return args.Error(0)
 }
 
-func (m *mockSecretsManager) GetSlackToken(ctx context.Context, connectorID string) (string, error) {
+func (m *mockSecretsManager) GetCredentials(ctx context.Context, connectorID string) (string, error) {
 	args := m.Called(ctx, connectorID)
 	return args.String(0), args.Error(1)
 }
 
-func (m *mockSecretsManager) DeleteSlackToken(ctx context.Context, connectorID string) error {
+func (m *mockSecretsManager) DeleteCredentials(ctx context.Context, connectorID string) error {
 	args := m.Called(ctx, connectorID)
 	return args.Error(0)
 }
@@ -69,6 +88,11 @@ func (m *mockSlackClient) SendMessage(ctx context.Context, token, channelID, mes
 	return args.Error(0)
 }
 
+func (m *mockSlackClient) VerifyChannel(ctx context.Context, token, channelID string) error {
+	args := m.Called(ctx, token, channelID)
+	return args.Error(0)
+}
+
 func TestCreateConnector_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -78,7 +102,7 @@ func TestCreateConnector_Success(t *testing.T) {
 
 	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
 
-	mockSecrets.On("StoreSlackToken", ctx, mock.AnythingOfType("string"), "dummy-token").Return(nil).Once()
+	mockSecrets.On("StoreCredentials", ctx, mock.AnythingOfType("string"), "dummy-token").Return(nil).Once()
 
 	mockSlack.On("ResolveChannelID", ctx, "dummy-token", "#general").Return("C123456", nil).Once()
 
@@ -89,7 +113,12 @@ func TestCreateConnector_Success(t *testing.T) {
 		Return(nil).
 		Once()
 
-	connector, err := u.CreateConnector(ctx, "workspace-1", "tenant-1", "#general", "dummy-token")
+	connector, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{
+		WorkspaceID:    "workspace-1",
+		TenantID:       "tenant-1",
+		DefaultChannel: "#general",
+		Credentials:    "dummy-token",
+	})
 
 	require.NoError(t, err)
 	require.Equal(t, "123", connector.ID)
@@ -104,7 +133,7 @@ func TestCreateConnector_InvalidArguments(t *testing.T) {
 
 	u := usecase.NewConnectorUsecase(nil, nil, nil)
 
-	id, err := u.CreateConnector(ctx, "", "", "", "")
+	id, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{})
 	require.Empty(t, id)
 	require.ErrorIs(t, err, errors.ErrInvalidArgument)
 }
@@ -143,6 +172,38 @@ func TestGetConnector_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListConnectors_ReturnsNextPageTokenOnFullPage(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := new(mockConnectorRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, nil, nil)
+
+	mockRepo.On("ListByTenant", ctx, "tenant-1", "conn-1", 2).
+		Return([]*domain.Connector{{ID: "conn-2"}, {ID: "conn-3"}}, nil).
+		Once()
+	mockRepo.On("ListByTenant", ctx, "tenant-1", "conn-3", 2).
+		Return([]*domain.Connector{{ID: "conn-4"}}, nil).
+		Once()
+
+	conns, next, err := u.ListConnectors(ctx, "tenant-1", "conn-1", 2)
+	require.NoError(t, err)
+	require.Len(t, conns, 2)
+	require.Equal(t, "conn-3", next)
+
+	conns, next, err = u.ListConnectors(ctx, "tenant-1", next, 2)
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+	require.Empty(t, next)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListConnectors_RequiresTenant(t *testing.T) {
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), nil, nil)
+
+	_, _, err := u.ListConnectors(context.Background(), "", "", 0)
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
 func TestDeleteConnector_Success(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockConnectorRepository)
@@ -157,7 +218,7 @@ func TestDeleteConnector_Success(t *testing.T) {
 		Once()
 
 	mockSecrets.
-		On("DeleteSlackToken", ctx, "conn-123").
+		On("DeleteCredentials", ctx, "conn-123").
 		Return(nil).
 		Once()
 
@@ -198,11 +259,11 @@ func TestSendMessage_Success(t *testing.T) {
 		}, nil).
 		Once()
 	mockSecrets.
-		On("GetSlackToken", ctx, "connector/conn-123").
+		On("GetCredentials", ctx, "conn-123").
 		Return("dummy-token", nil).
 		Once()
 	mockSlack.
-		On("SendMessage", ctx, "dummy-token", "C123456", "Hello from test").
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello from test").
 		Return(nil).
 		Once()
 
@@ -213,3 +274,242 @@ func TestSendMessage_Success(t *testing.T) {
 	mockSecrets.AssertExpectations(t)
 	mockSlack.AssertExpectations(t)
 }
+
+func TestCreateConnector_MissingFieldsReportsViolations(t *testing.T) {
+	ctx := context.Background()
+
+	u := usecase.NewConnectorUsecase(nil, nil, nil)
+
+	_, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{
+		WorkspaceID:    "workspace-1",
+		DefaultChannel: "#general",
+	})
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+	require.Equal(t, []errors.FieldViolation{
+		{Field: "credentials", Description: "credentials is required"},
+		{Field: "tenant_id", Description: "tenant_id is required"},
+	}, typed.Violations)
+}
+
+func TestSendMessage_RateLimitedKeepsRetryInfo(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	rateLimited := errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "slack rate limit exceeded").
+		WithRetryAfter(5 * time.Second)
+
+	mockRepo.
+		On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).
+		Once()
+	mockSecrets.
+		On("GetCredentials", ctx, "conn-123").
+		Return("dummy-token", nil).
+		Once()
+	mockSlack.
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").
+		Return(fmt.Errorf("failed to send Slack message: %w", rateLimited)).
+		Once()
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	require.Equal(t, 5*time.Second, typed.RetryAfter)
+}
+
+func TestSendMessage_UsesProviderMessenger(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	mockTeams := new(mockSlackClient)
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack,
+		usecase.WithMessenger(domain.ProviderMicrosoftTeams, mockTeams))
+
+	mockRepo.
+		On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{
+			ID:               "conn-123",
+			Provider:         domain.ProviderMicrosoftTeams,
+			DefaultChannelID: "General",
+		}, nil).
+		Once()
+	mockSecrets.
+		On("GetCredentials", ctx, "conn-123").
+		Return("https://teams.example/webhook", nil).
+		Once()
+	mockTeams.
+		On("SendMessage", mock.Anything, "https://teams.example/webhook", "General", "Hello").
+		Return(nil).
+		Once()
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+	require.NoError(t, err)
+
+	mockTeams.AssertExpectations(t)
+	mockSlack.AssertNotCalled(t, "SendMessage")
+}
+
+func TestCreateConnector_UnsupportedProvider(t *testing.T) {
+	ctx := context.Background()
+
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), new(mockSecretsManager), new(mockSlackClient))
+
+	_, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{
+		Provider:       domain.ProviderDiscord,
+		WorkspaceID:    "guild-1",
+		TenantID:       "tenant-1",
+		DefaultChannel: "alerts",
+		Credentials:    "https://discord.example/api/webhooks/1/abc",
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+type mockMessageRepository struct {
+	mock.Mock
+}
+
+func (m *mockMessageRepository) Create(ctx context.Context, msg *domain.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+func (m *mockMessageRepository) ListByConnector(ctx context.Context, connectorID string, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, connectorID, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *mockMessageRepository) CountDeliveredSince(ctx context.Context, connectorID string, since time.Time) (int, error) {
+	args := m.Called(ctx, connectorID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func TestSendMessage_RetriesUnavailableProvider(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	mockMessages := new(mockMessageRepository)
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack,
+		usecase.WithMessageLog(mockMessages),
+		usecase.WithRetryPolicy(usecase.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	mockRepo.
+		On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", TenantID: "tenant-1", DefaultChannelID: "C123456"}, nil).
+		Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").
+		Return(errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "slack is unavailable")).
+		Once()
+	mockSlack.
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").
+		Return(nil).
+		Once()
+	mockMessages.
+		On("Create", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.Status == domain.MessageStatusDelivered && m.Attempts == 2 && m.TenantID == "tenant-1"
+		})).
+		Return(nil).
+		Once()
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+	require.NoError(t, err)
+
+	mockSlack.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestSendMessage_DeadLettersAfterRetries(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	mockMessages := new(mockMessageRepository)
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack,
+		usecase.WithMessageLog(mockMessages),
+		usecase.WithRetryPolicy(usecase.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+
+	mockRepo.
+		On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", DefaultChannelID: "C123456"}, nil).
+		Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").
+		Return(errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "slack rate limit exceeded").
+			WithRetryAfter(time.Millisecond)).
+		Twice()
+	mockMessages.
+		On("Create", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.Status == domain.MessageStatusDeadLettered && m.Attempts == 2 && m.LastError != ""
+		})).
+		Return(nil).
+		Once()
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+
+	mockSlack.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestSendMessage_KeepsWebhookURLOutOfFailures(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockMessages := new(mockMessageRepository)
+	events := new(mockEventRepository)
+
+	// A port nothing listens on, so the post fails in the transport.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	webhookURL := "http://" + lis.Addr().String() + "/api/webhooks/123/s3cr3t-token"
+	require.NoError(t, lis.Close())
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, new(mockSlackClient),
+		usecase.WithMessenger(domain.ProviderDiscord, services.NewDiscordClient(services.WithPrivateNetworks())),
+		usecase.WithMessageLog(mockMessages),
+		usecase.WithEvents(events))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", Provider: domain.ProviderDiscord, DefaultChannelID: "general"}, nil).
+		Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return(webhookURL, nil).Once()
+	var logged *domain.Message
+	mockMessages.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { logged = args.Get(1).(*domain.Message) }).
+		Return(nil).
+		Once()
+	var failed *domain.ConnectorEvent
+	events.On("Append", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { failed = args.Get(1).(*domain.ConnectorEvent) }).
+		Return(nil).
+		Once()
+
+	err = u.SendMessage(ctx, "conn-123", "Hello")
+	require.ErrorIs(t, err, errors.ErrUnavailable)
+	require.NotContains(t, err.Error(), "s3cr3t-token")
+
+	require.Equal(t, "discord is unreachable", logged.LastError)
+	require.Equal(t, domain.EventMessageFailed, failed.Type)
+	require.Equal(t, "Unavailable", failed.Data["code"])
+	require.Equal(t, errors.ReasonProviderUnavailable, failed.Data["reason"])
+	require.Equal(t, "discord is unreachable", failed.Data["error"])
+	for _, v := range failed.Data {
+		require.NotContains(t, v, "s3cr3t-token")
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"log/slog"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// UpdateConnectorInput changes a connector's default channel and/or rotates
+// its credentials. Empty fields are left unchanged.
+type UpdateConnectorInput struct {
+	ConnectorID    string
+	DefaultChannel string
+	Credentials    string
+}
+
+// VerifyResult is the outcome of checking a connector's stored credentials.
+// Reason and Message explain why a connector failed verification.
+type VerifyResult struct {
+	OK      bool
+	Reason  string
+	Message string
+}
+
+// UpdateConnector resolves the new default channel and stores rotated
+// credentials. New credentials are checked against the connector's channel
+// before they replace the stored ones, when the provider supports it.
+func (u *connectorUsecase) UpdateConnector(ctx context.Context, in UpdateConnectorInput) (_ *domain.Connector, err error) {
+	tenantID := ""
+	defer func() { u.recordAudit(ctx, domain.AuditConnectorUpdate, tenantID, in.ConnectorID, err) }()
+
+	if err := validateRequired(map[string]string{"connector_id": in.ConnectorID}); err != nil {
+		return nil, err
+	}
+	if in.DefaultChannel == "" && in.Credentials == "" {
+		return nil, errors.InvalidArgument(
+			errors.FieldViolation{Field: "default_channel_name", Description: "default_channel_name or credentials is required"},
+			errors.FieldViolation{Field: "credentials", Description: "default_channel_name or credentials is required"},
+		)
+	}
+	conn, err := u.GetConnector(ctx, in.ConnectorID)
+	if err != nil {
+		return nil, err
+	}
+	tenantID = conn.TenantID
+	messenger, err := u.messenger(conn.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token := in.Credentials
+	if token == "" {
+		token, err = u.secrets.GetCredentials(ctx, conn.ID)
+		u.recordAudit(ctx, domain.AuditConnectorCredentialsRead, conn.TenantID, conn.ID, err)
+		if err != nil {
+			slog.Error("error getting connector credentials from secret manager", "error", err)
+			return nil, errors.ErrInternal
+		}
+	}
+
+	channelID := conn.DefaultChannelID
+	if in.DefaultChannel != "" {
+		channelID, err = messenger.ResolveChannelID(ctx, token, in.DefaultChannel)
+		if err != nil {
+			slog.Error("error resolving channel id using the channel name", "provider", conn.Provider, "error", err)
+			return nil, errors.Typed(err, errors.ErrInvalidArgument)
+		}
+	} else if v, ok := messenger.(services.Verifier); ok {
+		if err := v.VerifyChannel(ctx, token, channelID); err != nil {
+			slog.Error("error verifying rotated connector credentials", "provider", conn.Provider, "error", err)
+			return nil, errors.Typed(err, errors.ErrInvalidArgument)
+		}
+	}
+
+	if in.Credentials != "" {
+		if err := u.secrets.StoreCredentials(ctx, conn.ID, in.Credentials); err != nil {
+			slog.Error("error storing connector credentials", "error", err)
+			return nil, errors.ErrInternal
+		}
+	}
+
+	conn.DefaultChannelID = channelID
+	conn.UpdatedAt = time.Now()
+	if err := u.repo.UpdateDefaultChannel(ctx, conn.ID, channelID, conn.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", conn.ID)
+		}
+		slog.Error("error updating connector default channel", "error", err)
+		return nil, errors.ErrInternal
+	}
+
+	if in.DefaultChannel != "" {
+		u.emit(ctx, conn, domain.EventConnectorUpdated, map[string]string{"field": "default_channel_id", "default_channel_id": channelID})
+	}
+	if in.Credentials != "" {
+		u.emit(ctx, conn, domain.EventConnectorUpdated, map[string]string{"field": "credentials"})
+	}
+	return conn, nil
+}
+
+// VerifyConnector checks that the stored credentials are still valid and can
+// post to the connector's default channel, without posting a message.
+// Rejected credentials are reported in the result, not as an error, and also
+// report the connector's status change.
+func (u *connectorUsecase) VerifyConnector(ctx context.Context, connectorID string) (*VerifyResult, error) {
+	if err := validateRequired(map[string]string{"connector_id": connectorID}); err != nil {
+		return nil, err
+	}
+	conn, err := u.GetConnector(ctx, connectorID)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := u.messenger(conn.Provider)
+	if err != nil {
+		return nil, err
+	}
+	verifier, ok := messenger.(services.Verifier)
+	if !ok {
+		return nil, errors.New(errors.ErrFailedPrecondition, errors.ReasonUnsupportedProvider,
+			"connectors of provider "+string(conn.Provider)+" cannot be verified")
+	}
+
+	token, err := u.secrets.GetCredentials(ctx, conn.ID)
+	u.recordAudit(ctx, domain.AuditConnectorCredentialsRead, conn.TenantID, conn.ID, err)
+	if err != nil {
+		slog.Error("error getting connector credentials from secret manager", "error", err)
+		return nil, errors.ErrInternal
+	}
+
+	err = verifier.VerifyChannel(ctx, token, conn.DefaultChannelID)
+	if err == nil {
+		return &VerifyResult{OK: true}, nil
+	}
+
+	// Revoked tokens and deleted or archived channels fail verification; rate
+	// limits and outages do not say anything about the connector.
+	var e *errors.Error
+	rejected := stderrors.As(err, &e) && (stderrors.Is(e, errors.ErrFailedPrecondition) || stderrors.Is(e, errors.ErrNotFound))
+	if !rejected {
+		slog.Error("error verifying connector", "provider", conn.Provider, "error", err)
+		return nil, errors.Typed(err, errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable,
+			string(conn.Provider)+" could not be reached").WithCause(err))
+	}
+
+	if e.Reason == errors.ReasonTokenRevoked || e.Reason == errors.ReasonInvalidAuth {
+		u.emit(ctx, conn, domain.EventConnectorStatusChanged, map[string]string{
+			"status": "credentials_invalid",
+			"reason": e.Reason,
+		})
+	}
+	return &VerifyResult{Reason: e.Reason, Message: e.Message}, nil
+}
@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func slackConnector() *domain.Connector {
+	return &domain.Connector{ID: "conn-123", Provider: domain.ProviderSlack, TenantID: "tenant-1", DefaultChannelID: "C123"}
+}
+
+func TestUpdateConnector_RotatesVerifiedCredentials(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(slackConnector(), nil).Once()
+	mockSlack.On("VerifyChannel", ctx, "new-token", "C123").Return(nil).Once()
+	mockSecrets.On("StoreCredentials", ctx, "conn-123", "new-token").Return(nil).Once()
+	mockRepo.On("UpdateDefaultChannel", ctx, "conn-123", "C123", mock.Anything).Return(nil).Once()
+
+	conn, err := u.UpdateConnector(ctx, usecase.UpdateConnectorInput{ConnectorID: "conn-123", Credentials: "new-token"})
+	require.NoError(t, err)
+	require.Equal(t, "C123", conn.DefaultChannelID)
+	mockRepo.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+	mockSlack.AssertExpectations(t)
+}
+
+func TestUpdateConnector_KeepsCredentialsThatFailVerification(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(slackConnector(), nil).Once()
+	mockSlack.On("VerifyChannel", ctx, "bad-token", "C123").
+		Return(errors.New(errors.ErrFailedPrecondition, errors.ReasonInvalidAuth, "slack token is not valid")).
+		Once()
+
+	_, err := u.UpdateConnector(ctx, usecase.UpdateConnectorInput{ConnectorID: "conn-123", Credentials: "bad-token"})
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+	mockSecrets.AssertNotCalled(t, "StoreCredentials", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateDefaultChannel", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateConnector_ResolvesNewChannelWithStoredCredentials(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(slackConnector(), nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("ResolveChannelID", ctx, "dummy-token", "alerts").Return("C999", nil).Once()
+	mockRepo.On("UpdateDefaultChannel", ctx, "conn-123", "C999", mock.Anything).Return(nil).Once()
+
+	conn, err := u.UpdateConnector(ctx, usecase.UpdateConnectorInput{ConnectorID: "conn-123", DefaultChannel: "alerts"})
+	require.NoError(t, err)
+	require.Equal(t, "C999", conn.DefaultChannelID)
+	mockSecrets.AssertNotCalled(t, "StoreCredentials", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateConnector_RequiresAChange(t *testing.T) {
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), nil, nil)
+
+	_, err := u.UpdateConnector(context.Background(), usecase.UpdateConnectorInput{ConnectorID: "conn-123"})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestVerifyConnector_ReportsRevokedTokenAndStatusChange(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	events := new(mockEventRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack, usecase.WithEvents(events))
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(slackConnector(), nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("VerifyChannel", ctx, "dummy-token", "C123").
+		Return(errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "slack token has been revoked")).
+		Once()
+	events.On("Append", mock.Anything, mock.MatchedBy(func(e *domain.ConnectorEvent) bool {
+		return e.Type == domain.EventConnectorStatusChanged && e.Data["reason"] == errors.ReasonTokenRevoked
+	})).Return(nil).Once()
+
+	res, err := u.VerifyConnector(ctx, "conn-123")
+	require.NoError(t, err)
+	require.Equal(t, &usecase.VerifyResult{Reason: errors.ReasonTokenRevoked, Message: "slack token has been revoked"}, res)
+	events.AssertExpectations(t)
+}
+
+func TestVerifyConnector_ReturnsProviderOutages(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(slackConnector(), nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("VerifyChannel", ctx, "dummy-token", "C123").Return(context.DeadlineExceeded).Once()
+
+	_, err := u.VerifyConnector(ctx, "conn-123")
+	require.ErrorIs(t, err, errors.ErrUnavailable)
+}
+
+func TestVerifyConnector_UnsupportedProvider(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithMessenger(domain.ProviderDiscord, struct{ services.Messenger }{new(mockSlackClient)}),
+	)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(&domain.Connector{ID: "conn-123", Provider: domain.ProviderDiscord}, nil).Once()
+
+	_, err := u.VerifyConnector(ctx, "conn-123")
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+}
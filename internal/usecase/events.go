@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// eventReplayPageSize is the number of stored events read at once when a watcher resumes.
+const eventReplayPageSize = 500
+
+// WithEvents appends connector lifecycle and delivery events to events.
+func WithEvents(events repository.EventRepository) Option {
+	return func(u *connectorUsecase) {
+		u.events = events
+	}
+}
+
+// emit appends an event for conn. Failing to append never fails the operation itself.
+func (u *connectorUsecase) emit(ctx context.Context, conn *domain.Connector, typ domain.EventType, data map[string]string) {
+	if u.events == nil {
+		return
+	}
+	e := &domain.ConnectorEvent{TenantID: conn.TenantID, ConnectorID: conn.ID, Type: typ, Data: data}
+	if err := u.events.Append(context.WithoutCancel(ctx), e); err != nil {
+		slog.Error("error appending connector event", "connector_id", conn.ID, "type", typ, "error", err)
+	}
+}
+
+// emitDelivery appends the outcome of a delivery. Failures caused by revoked or
+// rejected credentials also report the connector's status change.
+func (u *connectorUsecase) emitDelivery(ctx context.Context, conn *domain.Connector, msg *domain.Message, err error) {
+	if err == nil {
+		u.emit(ctx, conn, domain.EventMessageDelivered, map[string]string{
+			"message_id": msg.ID,
+			"channel_id": msg.ChannelID,
+			"attempts":   strconv.Itoa(msg.Attempts),
+		})
+		return
+	}
+
+	data := map[string]string{
+		"message_id": msg.ID,
+		"channel_id": msg.ChannelID,
+		"attempts":   strconv.Itoa(msg.Attempts),
+		"code":       errors.Code(err).String(),
+		"error":      errors.PublicMessage(err),
+	}
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		data["reason"] = e.Reason
+	}
+	u.emit(ctx, conn, domain.EventMessageFailed, data)
+
+	if e != nil && (e.Reason == errors.ReasonTokenRevoked || e.Reason == errors.ReasonInvalidAuth) {
+		u.emit(ctx, conn, domain.EventConnectorStatusChanged, map[string]string{
+			"status": "credentials_invalid",
+			"reason": e.Reason,
+		})
+	}
+}
+
+type EventUsecase interface {
+	Watch(ctx context.Context, filter domain.EventFilter, afterID int64, send func(*domain.ConnectorEvent) error) error
+}
+
+type eventUsecase struct {
+	repo   repository.EventRepository
+	broker services.EventBroker
+}
+
+// NewEventUsecase creates a new EventUsecase streaming the events published on
+// broker, resuming from the events stored in repo.
+func NewEventUsecase(repo repository.EventRepository, broker services.EventBroker) EventUsecase {
+	return &eventUsecase{repo: repo, broker: broker}
+}
+
+// Watch calls send for every event matching filter until ctx is cancelled or
+// send fails. When afterID is set, the stored events after it are sent first.
+// Events are sent once, in increasing ID order. A watcher that falls behind,
+// or whose server lost its notification connection, gets an Unavailable error
+// and should resume from the last ID it received.
+func (u *eventUsecase) Watch(ctx context.Context, filter domain.EventFilter, afterID int64, send func(*domain.ConnectorEvent) error) error {
+	if filter.TenantID == "" && filter.ConnectorID == "" {
+		return errors.InvalidArgument(
+			errors.FieldViolation{Field: "tenant_id", Description: "tenant_id or connector_id is required"},
+			errors.FieldViolation{Field: "connector_id", Description: "tenant_id or connector_id is required"},
+		)
+	}
+	if afterID < 0 {
+		return errors.InvalidArgument(errors.FieldViolation{Field: "after_event_id", Description: "must not be negative"})
+	}
+
+	// Subscribe before replaying so that no event falls between the two.
+	sub := u.broker.Subscribe()
+	defer sub.Close()
+
+	last := afterID
+	if afterID > 0 {
+		for {
+			events, err := u.repo.ListAfter(ctx, filter, last, eventReplayPageSize)
+			if err != nil {
+				slog.Error("error listing connector events", "error", err)
+				return errors.ErrInternal
+			}
+			for _, e := range events {
+				if err := send(e); err != nil {
+					return err
+				}
+				last = e.ID
+			}
+			if len(events) < eventReplayPageSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return errors.New(errors.ErrUnavailable, errors.ReasonEventStreamLagged, "event stream interrupted, resume with after_event_id").
+					WithMetadata("last_event_id", strconv.FormatInt(last, 10)).
+					WithCause(sub.Err())
+			}
+			// IDs commit in increasing order, so anything at or below last
+			// was already sent from the log.
+			if e.ID <= last || !filter.Matches(e) {
+				continue
+			}
+			if err := send(e); err != nil {
+				return err
+			}
+			last = e.ID
+		}
+	}
+}
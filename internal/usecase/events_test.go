@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventRepository struct {
+	mock.Mock
+}
+
+func (m *mockEventRepository) Append(ctx context.Context, e *domain.ConnectorEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *mockEventRepository) GetByID(ctx context.Context, id int64) (*domain.ConnectorEvent, error) {
+	args := m.Called(ctx, id)
+	out := args.Get(0)
+	if out == nil {
+		return nil, args.Error(1)
+	}
+	return out.(*domain.ConnectorEvent), args.Error(1)
+}
+
+func (m *mockEventRepository) ListAfter(ctx context.Context, filter domain.EventFilter, afterID int64, limit int) ([]*domain.ConnectorEvent, error) {
+	args := m.Called(ctx, filter, afterID, limit)
+	out := args.Get(0)
+	if out == nil {
+		return nil, args.Error(1)
+	}
+	return out.([]*domain.ConnectorEvent), args.Error(1)
+}
+
+func (m *mockEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func event(id int64, tenantID string) *domain.ConnectorEvent {
+	return &domain.ConnectorEvent{ID: id, TenantID: tenantID, ConnectorID: "conn-123", Type: domain.EventMessageDelivered}
+}
+
+func TestWatch_ReplaysThenStreamsLiveEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := new(mockEventRepository)
+	broker := services.NewEventBroker(10)
+	u := usecase.NewEventUsecase(repo, broker)
+	filter := domain.EventFilter{TenantID: "tenant-1"}
+
+	// Events 7 and 8 are published while the log is replayed: 7 was already
+	// read from the log and 8 belongs to another tenant.
+	repo.On("ListAfter", ctx, filter, int64(5), mock.Anything).
+		Run(func(mock.Arguments) {
+			broker.Publish(event(7, "tenant-1"))
+			broker.Publish(event(8, "tenant-2"))
+			broker.Publish(event(9, "tenant-1"))
+		}).
+		Return([]*domain.ConnectorEvent{event(6, "tenant-1"), event(7, "tenant-1")}, nil).
+		Once()
+
+	var got []int64
+	err := u.Watch(ctx, filter, 5, func(e *domain.ConnectorEvent) error {
+		got = append(got, e.ID)
+		if e.ID == 9 {
+			cancel()
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int64{6, 7, 9}, got)
+	repo.AssertExpectations(t)
+}
+
+func TestWatch_WithoutResumeSkipsReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := new(mockEventRepository)
+	broker := services.NewEventBroker(10)
+	u := usecase.NewEventUsecase(repo, broker)
+
+	go func() {
+		// Publish until the watcher has subscribed and received an event.
+		for id := int64(1); ctx.Err() == nil; id++ {
+			broker.Publish(event(id, "tenant-1"))
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	err := u.Watch(ctx, domain.EventFilter{ConnectorID: "conn-123"}, 0, func(e *domain.ConnectorEvent) error {
+		cancel()
+		return nil
+	})
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "ListAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWatch_SlowWatcherIsInterrupted(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockEventRepository)
+	broker := services.NewEventBroker(1)
+	u := usecase.NewEventUsecase(repo, broker)
+	filter := domain.EventFilter{TenantID: "tenant-1"}
+
+	repo.On("ListAfter", ctx, filter, int64(1), mock.Anything).
+		Run(func(mock.Arguments) {
+			broker.Publish(event(2, "tenant-1"))
+			broker.Publish(event(3, "tenant-1"))
+		}).
+		Return([]*domain.ConnectorEvent{}, nil).
+		Once()
+
+	var got []int64
+	err := u.Watch(ctx, filter, 1, func(e *domain.ConnectorEvent) error {
+		got = append(got, e.ID)
+		return nil
+	})
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrUnavailable)
+	require.Equal(t, errors.ReasonEventStreamLagged, typed.Reason)
+	require.Equal(t, "2", typed.Metadata["last_event_id"])
+	require.Equal(t, []int64{2}, got)
+}
+
+func TestWatch_RequiresTenantOrConnector(t *testing.T) {
+	u := usecase.NewEventUsecase(new(mockEventRepository), services.NewEventBroker(1))
+
+	err := u.Watch(context.Background(), domain.EventFilter{}, 0, func(*domain.ConnectorEvent) error { return nil })
+
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestSend_EmitsStatusChangeWhenTokenRevoked(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	events := new(mockEventRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack, usecase.WithEvents(events))
+
+	conn := &domain.Connector{ID: "conn-123", TenantID: "tenant-1", DefaultChannelID: "C123456"}
+	mockRepo.On("GetByID", ctx, "conn-123").Return(conn, nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").
+		Return(errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "slack token has been revoked")).
+		Once()
+
+	var types []domain.EventType
+	events.On("Append", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			e := args.Get(1).(*domain.ConnectorEvent)
+			require.Equal(t, "tenant-1", e.TenantID)
+			types = append(types, e.Type)
+		}).
+		Return(nil)
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+
+	require.ErrorIs(t, err, errors.ErrFailedPrecondition)
+	require.Equal(t, []domain.EventType{domain.EventMessageFailed, domain.EventConnectorStatusChanged}, types)
+}
@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/internal/connectortest"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func TestConnectorUsecase_WithFakes(t *testing.T) {
+	ctx := context.Background()
+	slack := connectortest.NewSlackServer()
+	defer slack.Close()
+	general := slack.AddChannel("general")
+	alerts := slack.AddChannel("alerts")
+
+	repo := connectortest.NewConnectorRepository()
+	secrets := connectortest.NewSecretStore()
+	uc := usecase.NewConnectorUsecase(repo, secrets, services.NewSlackClient(services.WithSlackAPIURL(slack.URL())),
+		usecase.WithRetryPolicy(usecase.RetryPolicy{MaxAttempts: 2, MaxDelay: 10 * time.Millisecond}))
+
+	conn, err := uc.CreateConnector(ctx, usecase.CreateConnectorInput{
+		WorkspaceID:    connectortest.SlackTeamID,
+		TenantID:       "tenant-1",
+		DefaultChannel: "general",
+		Credentials:    "xoxb-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, general, conn.DefaultChannelID)
+	stored, ok := secrets.Credentials(conn.ID)
+	require.True(t, ok)
+	require.Equal(t, "xoxb-1", stored)
+
+	slack.RateLimit("chat.postMessage", 1, 0)
+	require.NoError(t, uc.SendMessage(ctx, conn.ID, "deploy **finished**"))
+	require.Equal(t, 2, slack.Calls("chat.postMessage"))
+	messages := slack.MessagesIn(general)
+	require.Len(t, messages, 1)
+	require.Equal(t, "deploy *finished*", messages[0].Text)
+
+	updated, err := uc.UpdateConnector(ctx, usecase.UpdateConnectorInput{ConnectorID: conn.ID, DefaultChannel: "alerts"})
+	require.NoError(t, err)
+	require.Equal(t, alerts, updated.DefaultChannelID)
+	require.Equal(t, alerts, repo.Connectors()[0].DefaultChannelID)
+
+	slack.RevokeToken("xoxb-1")
+	result, err := uc.VerifyConnector(ctx, conn.ID)
+	require.NoError(t, err)
+	require.False(t, result.OK)
+	require.Equal(t, errors.ReasonTokenRevoked, result.Reason)
+
+	require.NoError(t, uc.DeleteConnector(ctx, conn.ID))
+	require.Empty(t, repo.Connectors())
+	_, ok = secrets.Credentials(conn.ID)
+	require.False(t, ok)
+}
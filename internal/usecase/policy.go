@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// WithDeferrals lets delivery policies defer messages, storing them as
+// scheduled messages sent by the scheduler loop. Without it deferred messages
+// are delivered right away.
+func WithDeferrals(scheduled repository.ScheduledMessageRepository) Option {
+	return func(u *connectorUsecase) {
+		u.deferrals = scheduled
+	}
+}
+
+// SetDeliveryPolicy replaces the connector's delivery policy. A nil policy removes it.
+func (u *connectorUsecase) SetDeliveryPolicy(ctx context.Context, connectorID string, policy *domain.DeliveryPolicy) (_ *domain.Connector, err error) {
+	tenantID := ""
+	defer func() { u.recordAudit(ctx, domain.AuditConnectorSetPolicy, tenantID, connectorID, err) }()
+
+	if err := validateDeliveryPolicy(policy); err != nil {
+		return nil, err
+	}
+	conn, err := u.GetConnector(ctx, connectorID)
+	if err != nil {
+		return nil, err
+	}
+	tenantID = conn.TenantID
+
+	conn.DeliveryPolicy = policy
+	conn.UpdatedAt = time.Now()
+	if err := u.repo.UpdateDeliveryPolicy(ctx, connectorID, policy, conn.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", connectorID)
+		}
+		slog.Error("error updating connector delivery policy", "error", err)
+		return nil, errors.ErrInternal
+	}
+	u.emit(ctx, conn, domain.EventConnectorUpdated, map[string]string{"field": "delivery_policy"})
+	return conn, nil
+}
+
+// applyPolicy evaluates the connector's delivery policy for msg. Dropped and
+// deferred messages are recorded and reported as handled; downgraded messages
+// have their broadcast mentions removed and are delivered.
+func (u *connectorUsecase) applyPolicy(ctx context.Context, conn *domain.Connector, msg *domain.Message, in SendMessageInput) (bool, error) {
+	policy := conn.DeliveryPolicy
+	if policy == nil {
+		return false, nil
+	}
+
+	sent := 0
+	if policy.MaxPerHour > 0 && u.messages != nil {
+		var err error
+		sent, err = u.messages.CountDeliveredSince(ctx, conn.ID, msg.CreatedAt.Truncate(time.Hour))
+		if err != nil {
+			slog.Error("error counting delivered messages", "error", err)
+			return false, errors.ErrInternal
+		}
+	}
+
+	decision := policy.Evaluate(msg.CreatedAt, in.Severity, sent)
+	msg.Decision = &decision
+
+	switch decision.Action {
+	case domain.PolicyActionDrop:
+		msg.Status = domain.MessageStatusDropped
+	case domain.PolicyActionDefer:
+		if u.deferrals == nil {
+			slog.Warn("delivery policy deferred a message but deferrals are not enabled, delivering it", "connector_id", conn.ID)
+			return false, nil
+		}
+		if err := u.deferrals.Create(ctx, &domain.ScheduledMessage{
+			ID:          msg.ID,
+			TenantID:    conn.TenantID,
+			ConnectorID: conn.ID,
+			Channel:     in.Channel,
+			Severity:    in.Severity,
+			Text:        msg.Text,
+			NextRunAt:   decision.DeferUntil,
+			Status:      domain.ScheduleStatusScheduled,
+			CreatedAt:   msg.CreatedAt,
+			UpdatedAt:   msg.CreatedAt,
+		}); err != nil {
+			slog.Error("error deferring message", "error", err)
+			return false, errors.ErrInternal
+		}
+		msg.Status = domain.MessageStatusDeferred
+	case domain.PolicyActionDowngrade:
+		msg.Text = stripBroadcastMentions(msg.Text)
+		return false, nil
+	default:
+		return false, nil
+	}
+
+	msg.UpdatedAt = msg.CreatedAt
+	u.recordMessage(ctx, msg)
+	return true, nil
+}
+
+var broadcastMentions = regexp.MustCompile(`<!(channel|here|everyone)(\|[^>]*)?>|@(channel|here|everyone)\b`)
+
+// stripBroadcastMentions turns mentions that notify a whole channel into plain words.
+func stripBroadcastMentions(text string) string {
+	return broadcastMentions.ReplaceAllString(text, "$1$3")
+}
+
+func validateDeliveryPolicy(p *domain.DeliveryPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.Validate(); err != nil {
+		return errors.InvalidArgument(errors.FieldViolation{
+			Field:       "delivery_policy",
+			Description: err.Error(),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// quietNow returns quiet hours in UTC that include the current time.
+func quietNow() []domain.QuietHours {
+	now := time.Now().UTC()
+	return []domain.QuietHours{{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}}
+}
+
+func TestSend_DefersDuringQuietHours(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockMessages := new(mockMessageRepository)
+	mockScheduled := new(mockScheduledMessageRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithMessageLog(mockMessages),
+		usecase.WithDeferrals(mockScheduled))
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(&domain.Connector{
+		ID:               "conn-123",
+		TenantID:         "tenant-1",
+		DefaultChannelID: "C123456",
+		DeliveryPolicy:   &domain.DeliveryPolicy{QuietHours: quietNow()},
+	}, nil).Once()
+	mockScheduled.On("Create", ctx, mock.MatchedBy(func(sm *domain.ScheduledMessage) bool {
+		return sm.Text == "nightly report" && sm.Channel == "#reports" && sm.Severity == domain.SeverityInfo &&
+			sm.NextRunAt.After(time.Now())
+	})).Return(nil).Once()
+	mockMessages.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.Status == domain.MessageStatusDeferred && m.Decision.Action == domain.PolicyActionDefer
+	})).Return(nil).Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{
+		ConnectorID: "conn-123",
+		Channel:     "#reports",
+		Severity:    domain.SeverityInfo,
+		Text:        "nightly report",
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.MessageStatusDeferred, msg.Status)
+	require.Contains(t, msg.Decision.Reason, "quiet hours")
+
+	mockScheduled.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestSend_DowngradeStripsBroadcastMentions(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack)
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(&domain.Connector{
+		ID:               "conn-123",
+		DefaultChannelID: "C123456",
+		DeliveryPolicy: &domain.DeliveryPolicy{
+			QuietHours:       quietNow(),
+			QuietHoursAction: domain.PolicyActionDowngrade,
+		},
+	}, nil).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("SendMessage", mock.Anything, "dummy-token", "C123456", "here: build is green, channel everyone").
+		Return(nil).Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{
+		ConnectorID: "conn-123",
+		Text:        "<!here>: build is green, <!channel> @everyone",
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.MessageStatusDelivered, msg.Status)
+	require.Equal(t, domain.PolicyActionDowngrade, msg.Decision.Action)
+
+	mockSlack.AssertExpectations(t)
+}
+
+func TestSend_DropsOverHourlyLimit(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockMessages := new(mockMessageRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, new(mockSecretsManager), new(mockSlackClient),
+		usecase.WithMessageLog(mockMessages))
+
+	mockRepo.On("GetByID", ctx, "conn-123").Return(&domain.Connector{
+		ID:               "conn-123",
+		DefaultChannelID: "C123456",
+		DeliveryPolicy: &domain.DeliveryPolicy{
+			MaxPerHour:        5,
+			RateLimitAction:   domain.PolicyActionDrop,
+			SeverityThreshold: domain.SeverityError,
+		},
+	}, nil).Once()
+	mockMessages.On("CountDeliveredSince", ctx, "conn-123", mock.MatchedBy(func(since time.Time) bool {
+		// created_at holds UTC wall-clock times, so the hour starts in UTC too.
+		return since.Location() == time.UTC && since.Equal(since.Truncate(time.Hour))
+	})).Return(5, nil).Once()
+	mockMessages.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.Status == domain.MessageStatusDropped
+	})).Return(nil).Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{ConnectorID: "conn-123", Text: "cpu at 80%", Severity: domain.SeverityWarning})
+	require.NoError(t, err)
+	require.Equal(t, domain.MessageStatusDropped, msg.Status)
+
+	mockMessages.AssertExpectations(t)
+}
+
+func TestSetDeliveryPolicy_InvalidTimezone(t *testing.T) {
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), new(mockSecretsManager), new(mockSlackClient))
+
+	_, err := u.SetDeliveryPolicy(context.Background(), "conn-123", &domain.DeliveryPolicy{Timezone: "Nowhere/Town"})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
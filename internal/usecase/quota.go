@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// QuotaDefaults holds the quota of tenants without a stored one. It is safe
+// for concurrent use, so the defaults can be replaced while the server runs.
+type QuotaDefaults struct {
+	q atomic.Pointer[domain.TenantQuota]
+}
+
+// NewQuotaDefaults creates QuotaDefaults holding q.
+func NewQuotaDefaults(q domain.TenantQuota) *QuotaDefaults {
+	d := &QuotaDefaults{}
+	d.Set(q)
+	return d
+}
+
+// Get returns the current defaults. A nil QuotaDefaults is unlimited.
+func (d *QuotaDefaults) Get() domain.TenantQuota {
+	if d == nil {
+		return domain.TenantQuota{}
+	}
+	return *d.q.Load()
+}
+
+// Set replaces the defaults.
+func (d *QuotaDefaults) Set(q domain.TenantQuota) {
+	d.q.Store(&q)
+}
+
+// WithQuotas enforces tenant quotas on connector creation and message sends.
+// Tenants without a stored quota get defaults.
+func WithQuotas(quotas repository.QuotaRepository, defaults *QuotaDefaults) Option {
+	return func(u *connectorUsecase) {
+		u.quotas = quotas
+		u.defaultQuota = defaults
+	}
+}
+
+// checkConnectorQuota rejects creating a connector once the tenant has
+// MaxConnectors. Concurrent creations may briefly exceed the limit.
+func (u *connectorUsecase) checkConnectorQuota(ctx context.Context, tenantID string) error {
+	if u.quotas == nil {
+		return nil
+	}
+	q, err := tenantQuota(ctx, u.quotas, u.defaultQuota, tenantID)
+	if err != nil {
+		return err
+	}
+	if q.MaxConnectors <= 0 {
+		return nil
+	}
+
+	n, err := u.quotas.CountConnectors(ctx, tenantID)
+	if err != nil {
+		slog.Error("error counting tenant connectors", "error", err)
+		return errors.ErrInternal
+	}
+	if n >= q.MaxConnectors {
+		return errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "tenant connector quota exceeded").
+			WithQuotaViolation("tenant:"+tenantID, fmt.Sprintf("at most %d connectors", q.MaxConnectors)).
+			WithMetadata("quota", "max_connectors").
+			WithMetadata("limit", strconv.Itoa(q.MaxConnectors))
+	}
+	return nil
+}
+
+// consumeMessageQuota counts a message against the tenant's per-minute and
+// per-day limits, rejecting it once either is reached. RetryInfo reports when
+// the exceeded window ends.
+func (u *connectorUsecase) consumeMessageQuota(ctx context.Context, tenantID string, now time.Time) error {
+	if u.quotas == nil {
+		return nil
+	}
+	q, err := tenantQuota(ctx, u.quotas, u.defaultQuota, tenantID)
+	if err != nil {
+		return err
+	}
+	if q.MessagesPerMinute <= 0 && q.MessagesPerDay <= 0 {
+		return nil
+	}
+
+	exceeded, err := u.quotas.ConsumeMessage(ctx, tenantID, now, q.MessagesPerMinute, q.MessagesPerDay)
+	if err != nil {
+		slog.Error("error counting tenant messages", "error", err)
+		return errors.ErrInternal
+	}
+
+	quota, limit := "messages_per_minute", q.MessagesPerMinute
+	switch exceeded {
+	case "":
+		return nil
+	case domain.QuotaWindowDay:
+		quota, limit = "messages_per_day", q.MessagesPerDay
+	}
+	return errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "tenant message quota exceeded").
+		WithQuotaViolation("tenant:"+tenantID, fmt.Sprintf("at most %d messages per %s", limit, exceeded)).
+		WithMetadata("quota", quota).
+		WithMetadata("limit", strconv.Itoa(limit)).
+		WithRetryAfter(exceeded.End(now).Sub(now))
+}
+
+func tenantQuota(ctx context.Context, repo repository.QuotaRepository, defaults *QuotaDefaults, tenantID string) (*domain.TenantQuota, error) {
+	q, err := repo.Get(ctx, tenantID)
+	if err == sql.ErrNoRows {
+		d := defaults.Get()
+		d.TenantID = tenantID
+		return &d, nil
+	}
+	if err != nil {
+		slog.Error("error getting tenant quota", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return q, nil
+}
+
+type QuotaUsecase interface {
+	GetQuota(ctx context.Context, tenantID string) (*domain.TenantQuota, *domain.QuotaUsage, error)
+	SetQuota(ctx context.Context, q domain.TenantQuota) (*domain.TenantQuota, error)
+}
+
+type quotaUsecase struct {
+	repo     repository.QuotaRepository
+	defaults *QuotaDefaults
+}
+
+// NewQuotaUsecase creates a new QuotaUsecase. Tenants without a stored quota
+// report defaults.
+func NewQuotaUsecase(repo repository.QuotaRepository, defaults *QuotaDefaults) QuotaUsecase {
+	return &quotaUsecase{repo: repo, defaults: defaults}
+}
+
+// GetQuota returns the tenant's quota and its current usage.
+func (u *quotaUsecase) GetQuota(ctx context.Context, tenantID string) (*domain.TenantQuota, *domain.QuotaUsage, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, nil, err
+	}
+	q, err := tenantQuota(ctx, u.repo, u.defaults, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var usage domain.QuotaUsage
+	if usage.Connectors, err = u.repo.CountConnectors(ctx, tenantID); err != nil {
+		slog.Error("error counting tenant connectors", "error", err)
+		return nil, nil, errors.ErrInternal
+	}
+	if usage.MessagesThisMinute, usage.MessagesToday, err = u.repo.MessageUsage(ctx, tenantID, time.Now()); err != nil {
+		slog.Error("error getting tenant message usage", "error", err)
+		return nil, nil, errors.ErrInternal
+	}
+	return q, &usage, nil
+}
+
+// SetQuota replaces the tenant's quota. Zero limits are unlimited.
+func (u *quotaUsecase) SetQuota(ctx context.Context, q domain.TenantQuota) (*domain.TenantQuota, error) {
+	if err := validateRequired(map[string]string{"tenant_id": q.TenantID}); err != nil {
+		return nil, err
+	}
+	var violations []errors.FieldViolation
+	for field, v := range map[string]int{
+		"max_connectors":      q.MaxConnectors,
+		"messages_per_minute": q.MessagesPerMinute,
+		"messages_per_day":    q.MessagesPerDay,
+	} {
+		if v < 0 {
+			violations = append(violations, errors.FieldViolation{Field: field, Description: "must not be negative"})
+		}
+	}
+	if len(violations) > 0 {
+		sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+		return nil, errors.InvalidArgument(violations...)
+	}
+
+	q.UpdatedAt = time.Now()
+	if err := u.repo.Upsert(ctx, &q); err != nil {
+		slog.Error("error setting tenant quota", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return &q, nil
+}
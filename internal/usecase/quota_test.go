@@ -0,0 +1,172 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type mockQuotaRepository struct {
+	mock.Mock
+}
+
+func (m *mockQuotaRepository) Get(ctx context.Context, tenantID string) (*domain.TenantQuota, error) {
+	args := m.Called(ctx, tenantID)
+	out := args.Get(0)
+	if out == nil {
+		return nil, args.Error(1)
+	}
+	return out.(*domain.TenantQuota), args.Error(1)
+}
+
+func (m *mockQuotaRepository) Upsert(ctx context.Context, q *domain.TenantQuota) error {
+	args := m.Called(ctx, q)
+	return args.Error(0)
+}
+
+func (m *mockQuotaRepository) CountConnectors(ctx context.Context, tenantID string) (int, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockQuotaRepository) ConsumeMessage(ctx context.Context, tenantID string, now time.Time, perMinute, perDay int) (domain.QuotaWindow, error) {
+	args := m.Called(ctx, tenantID, now, perMinute, perDay)
+	return args.Get(0).(domain.QuotaWindow), args.Error(1)
+}
+
+func (m *mockQuotaRepository) MessageUsage(ctx context.Context, tenantID string, now time.Time) (int, int, error) {
+	args := m.Called(ctx, tenantID, now)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *mockQuotaRepository) DeleteUsageBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestCreateConnector_RejectsWhenConnectorQuotaReached(t *testing.T) {
+	ctx := context.Background()
+	quotas := new(mockQuotaRepository)
+	mockSecrets := new(mockSecretsManager)
+	u := usecase.NewConnectorUsecase(new(mockConnectorRepository), mockSecrets, new(mockSlackClient),
+		usecase.WithQuotas(quotas, usecase.NewQuotaDefaults(domain.TenantQuota{MaxConnectors: 10})))
+
+	quotas.On("Get", ctx, "tenant-1").Return(&domain.TenantQuota{TenantID: "tenant-1", MaxConnectors: 2}, nil).Once()
+	quotas.On("CountConnectors", ctx, "tenant-1").Return(2, nil).Once()
+
+	_, err := u.CreateConnector(ctx, usecase.CreateConnectorInput{
+		WorkspaceID:    "WS123",
+		TenantID:       "tenant-1",
+		DefaultChannel: "general",
+		Credentials:    "xoxb-token",
+	})
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	require.Equal(t, errors.ReasonQuotaExceeded, typed.Reason)
+	require.Equal(t, "max_connectors", typed.Metadata["quota"])
+
+	var failure *errdetails.QuotaFailure
+	for _, d := range typed.GRPCStatus().Details() {
+		if qf, ok := d.(*errdetails.QuotaFailure); ok {
+			failure = qf
+		}
+	}
+	require.NotNil(t, failure)
+	require.Equal(t, "tenant:tenant-1", failure.Violations[0].Subject)
+	mockSecrets.AssertNotCalled(t, "StoreCredentials", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSend_RejectsWhenMessageQuotaReached(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	quotas := new(mockQuotaRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, new(mockSlackClient),
+		usecase.WithQuotas(quotas, usecase.NewQuotaDefaults(domain.TenantQuota{MessagesPerMinute: 60, MessagesPerDay: 1000})))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", TenantID: "tenant-1", DefaultChannelID: "C123456"}, nil).Once()
+	quotas.On("Get", ctx, "tenant-1").Return(nil, sql.ErrNoRows).Once()
+	quotas.On("ConsumeMessage", ctx, "tenant-1", mock.Anything, 60, 1000).Return(domain.QuotaWindowMinute, nil).Once()
+
+	err := u.SendMessage(ctx, "conn-123", "Hello")
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.ErrorIs(t, err, errors.ErrResourceExhausted)
+	require.Equal(t, "messages_per_minute", typed.Metadata["quota"])
+	require.Equal(t, "60", typed.Metadata["limit"])
+	require.True(t, typed.RetryAfter > 0 && typed.RetryAfter <= time.Minute)
+	mockSecrets.AssertNotCalled(t, "GetCredentials", mock.Anything, mock.Anything)
+}
+
+func TestSend_UnlimitedQuotaSkipsCounting(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	quotas := new(mockQuotaRepository)
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack, usecase.WithQuotas(quotas, nil))
+
+	mockRepo.On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", TenantID: "tenant-1", DefaultChannelID: "C123456"}, nil).Once()
+	quotas.On("Get", ctx, "tenant-1").Return(nil, sql.ErrNoRows).Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.On("SendMessage", mock.Anything, "dummy-token", "C123456", "Hello").Return(nil).Once()
+
+	require.NoError(t, u.SendMessage(ctx, "conn-123", "Hello"))
+	quotas.AssertNotCalled(t, "ConsumeMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetQuota_RejectsNegativeLimits(t *testing.T) {
+	u := usecase.NewQuotaUsecase(new(mockQuotaRepository), nil)
+
+	_, err := u.SetQuota(context.Background(), domain.TenantQuota{TenantID: "tenant-1", MessagesPerDay: -1})
+
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.Equal(t, "messages_per_day", typed.Violations[0].Field)
+}
+
+func TestGetQuota_ReportsDefaultsAndUsage(t *testing.T) {
+	ctx := context.Background()
+	quotas := new(mockQuotaRepository)
+	u := usecase.NewQuotaUsecase(quotas, usecase.NewQuotaDefaults(domain.TenantQuota{MaxConnectors: 5}))
+
+	quotas.On("Get", ctx, "tenant-1").Return(nil, sql.ErrNoRows).Once()
+	quotas.On("CountConnectors", ctx, "tenant-1").Return(3, nil).Once()
+	quotas.On("MessageUsage", ctx, "tenant-1", mock.Anything).Return(4, 120, nil).Once()
+
+	q, usage, err := u.GetQuota(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Equal(t, "tenant-1", q.TenantID)
+	require.Equal(t, 5, q.MaxConnectors)
+	require.Equal(t, domain.QuotaUsage{Connectors: 3, MessagesThisMinute: 4, MessagesToday: 120}, *usage)
+}
+
+func TestGetQuota_ReportsReplacedDefaults(t *testing.T) {
+	ctx := context.Background()
+	quotas := new(mockQuotaRepository)
+	defaults := usecase.NewQuotaDefaults(domain.TenantQuota{MaxConnectors: 5})
+	u := usecase.NewQuotaUsecase(quotas, defaults)
+
+	quotas.On("Get", ctx, "tenant-1").Return(nil, sql.ErrNoRows)
+	quotas.On("CountConnectors", ctx, "tenant-1").Return(0, nil)
+	quotas.On("MessageUsage", ctx, "tenant-1", mock.Anything).Return(0, 0, nil)
+
+	defaults.Set(domain.TenantQuota{MaxConnectors: 8, MessagesPerDay: 100})
+	q, _, err := u.GetQuota(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Equal(t, 8, q.MaxConnectors)
+	require.Equal(t, 100, q.MessagesPerDay)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// RetryPolicy controls how message deliveries are retried before being dead-lettered.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// noRetries is used unless WithRetryPolicy is given.
+var noRetries = RetryPolicy{MaxAttempts: 1}
+
+// retry calls fn until it succeeds, returns a non-retryable error or the policy is
+// exhausted. Retry-After hints from rate limited providers take precedence over
+// the exponential backoff. It returns the number of attempts made.
+func (p RetryPolicy) retry(ctx context.Context, fn func(ctx context.Context) error) (int, error) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil || attempt == maxAttempts || !retryable(err) {
+			return attempt, err
+		}
+
+		timer := time.NewTimer(p.delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+
+	var typed *errors.Error
+	if stderrors.As(err, &typed) && typed.RetryAfter > 0 {
+		d = typed.RetryAfter
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+func retryable(err error) bool {
+	return stderrors.Is(err, errors.ErrResourceExhausted) || stderrors.Is(err, errors.ErrUnavailable)
+}
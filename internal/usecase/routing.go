@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type RoutingUsecase interface {
+	CreateRoutingRule(ctx context.Context, in RoutingRuleInput) (*domain.RoutingRule, error)
+	GetRoutingRule(ctx context.Context, ruleID string) (*domain.RoutingRule, error)
+	ListRoutingRules(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error)
+	UpdateRoutingRule(ctx context.Context, ruleID string, in RoutingRuleInput) (*domain.RoutingRule, error)
+	DeleteRoutingRule(ctx context.Context, ruleID string) error
+	Notify(ctx context.Context, event domain.Event) ([]DeliveryResult, error)
+}
+
+// RoutingRuleInput holds the conditions and destinations of a rule. TenantID
+// is ignored on update.
+type RoutingRuleInput struct {
+	TenantID     string
+	Name         string
+	EventTypes   []string
+	MinSeverity  domain.Severity
+	MatchLabels  map[string]string
+	Destinations []domain.RouteDestination
+}
+
+// DeliveryResult is the outcome of sending an event to one destination. Err is
+// a typed error when the delivery failed.
+type DeliveryResult struct {
+	Destination domain.RouteDestination
+	RuleIDs     []string
+	Message     *domain.Message
+	Err         error
+}
+
+type routingUsecase struct {
+	repo       repository.RoutingRuleRepository
+	connectors repository.ConnectorRepository
+	sender     ConnectorUsecase
+}
+
+// NewRoutingUsecase creates a new RoutingUsecase that delivers through sender.
+func NewRoutingUsecase(
+	repo repository.RoutingRuleRepository,
+	connectors repository.ConnectorRepository,
+	sender ConnectorUsecase,
+) RoutingUsecase {
+	return &routingUsecase{
+		repo:       repo,
+		connectors: connectors,
+		sender:     sender,
+	}
+}
+
+// CreateRoutingRule validates the rule and stores it under the tenant.
+func (u *routingUsecase) CreateRoutingRule(ctx context.Context, in RoutingRuleInput) (*domain.RoutingRule, error) {
+	if err := validateRequired(map[string]string{
+		"tenant_id": in.TenantID,
+		"name":      in.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	r := &domain.RoutingRule{
+		ID:        uuid.NewString(),
+		TenantID:  in.TenantID,
+		Name:      in.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	applyRoutingRuleInput(r, in)
+	if err := u.validateRule(ctx, r); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, r); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, errors.New(errors.ErrAlreadyExists, errors.ReasonRoutingRuleExists, "routing rule "+in.Name+" already exists").
+				WithResource("routing_rule", in.Name)
+		}
+		slog.Error("error creating routing rule", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return r, nil
+}
+
+// GetRoutingRule retrieves a routing rule by ID.
+func (u *routingUsecase) GetRoutingRule(ctx context.Context, ruleID string) (*domain.RoutingRule, error) {
+	r, err := u.repo.GetByID(ctx, ruleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonRoutingRuleNotFound, "routing_rule", ruleID)
+		}
+		slog.Error("error getting routing rule by id", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return r, nil
+}
+
+// ListRoutingRules returns every rule of the tenant ordered by name.
+func (u *routingUsecase) ListRoutingRules(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, err
+	}
+	rules, err := u.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		slog.Error("error listing routing rules", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return rules, nil
+}
+
+// UpdateRoutingRule replaces the conditions and destinations of a rule. An
+// empty name is left unchanged.
+func (u *routingUsecase) UpdateRoutingRule(ctx context.Context, ruleID string, in RoutingRuleInput) (*domain.RoutingRule, error) {
+	r, err := u.GetRoutingRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if in.Name != "" {
+		r.Name = in.Name
+	}
+	applyRoutingRuleInput(r, in)
+	if err := u.validateRule(ctx, r); err != nil {
+		return nil, err
+	}
+
+	r.UpdatedAt = time.Now()
+	if err := u.repo.Update(ctx, r); err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, errors.NotFound(errors.ReasonRoutingRuleNotFound, "routing_rule", ruleID)
+		case repository.IsUniqueViolation(err):
+			return nil, errors.New(errors.ErrAlreadyExists, errors.ReasonRoutingRuleExists, "routing rule "+r.Name+" already exists").
+				WithResource("routing_rule", r.Name)
+		}
+		slog.Error("error updating routing rule", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return r, nil
+}
+
+// DeleteRoutingRule removes a routing rule.
+func (u *routingUsecase) DeleteRoutingRule(ctx context.Context, ruleID string) error {
+	if err := u.repo.Delete(ctx, ruleID); err != nil {
+		slog.Error("error deleting routing rule", "error", err)
+		return errors.ErrInternal
+	}
+	return nil
+}
+
+// Notify sends the event to the destinations of every matching rule of its
+// tenant. A destination targeted by several rules receives the event once.
+// Deliveries run concurrently and failures are reported per destination, so a
+// broken connector never prevents the others from being notified. Templates
+// are rendered with the event labels overlaid by its variables.
+func (u *routingUsecase) Notify(ctx context.Context, event domain.Event) ([]DeliveryResult, error) {
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
+	rules, err := u.repo.ListByTenant(ctx, event.TenantID)
+	if err != nil {
+		slog.Error("error listing routing rules", "error", err)
+		return nil, errors.ErrInternal
+	}
+
+	var results []DeliveryResult
+	seen := make(map[domain.RouteDestination]int)
+	for _, r := range rules {
+		if !r.Matches(event) {
+			continue
+		}
+		for _, d := range r.Destinations {
+			if i, ok := seen[d]; ok {
+				results[i].RuleIDs = append(results[i].RuleIDs, r.ID)
+				continue
+			}
+			seen[d] = len(results)
+			results = append(results, DeliveryResult{Destination: d, RuleIDs: []string{r.ID}})
+		}
+	}
+
+	vars := make(map[string]string, len(event.Labels)+len(event.Variables))
+	for k, v := range event.Labels {
+		vars[k] = v
+	}
+	for k, v := range event.Variables {
+		vars[k] = v
+	}
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(res *DeliveryResult) {
+			defer wg.Done()
+			res.Message, res.Err = u.sender.Send(ctx, SendMessageInput{
+				ConnectorID: res.Destination.ConnectorID,
+				Channel:     res.Destination.Channel,
+				Severity:    event.Severity,
+				Text:        event.Text,
+				TemplateID:  event.TemplateID,
+				Variables:   vars,
+			})
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func applyRoutingRuleInput(r *domain.RoutingRule, in RoutingRuleInput) {
+	r.EventTypes = in.EventTypes
+	r.MinSeverity = in.MinSeverity
+	r.MatchLabels = in.MatchLabels
+	r.Destinations = in.Destinations
+}
+
+// validateRule checks the rule's conditions and that every destination is a
+// connector of the rule's tenant.
+func (u *routingUsecase) validateRule(ctx context.Context, r *domain.RoutingRule) error {
+	var violations []errors.FieldViolation
+	if r.MinSeverity != "" && !r.MinSeverity.Valid() {
+		violations = append(violations, errors.FieldViolation{
+			Field:       "min_severity",
+			Description: "unknown severity " + string(r.MinSeverity),
+		})
+	}
+	if len(r.Destinations) == 0 {
+		violations = append(violations, errors.FieldViolation{
+			Field:       "destinations",
+			Description: "at least one destination is required",
+		})
+	}
+	for i, d := range r.Destinations {
+		field := "destinations[" + strconv.Itoa(i) + "].connector_id"
+		if d.ConnectorID == "" {
+			violations = append(violations, errors.FieldViolation{Field: field, Description: "connector_id is required"})
+			continue
+		}
+		conn, err := u.connectors.GetByID(ctx, d.ConnectorID)
+		if err != nil && err != sql.ErrNoRows {
+			slog.Error("error getting connector by id", "error", err)
+			return errors.ErrInternal
+		}
+		if err == sql.ErrNoRows || conn.TenantID != r.TenantID {
+			violations = append(violations, errors.FieldViolation{Field: field, Description: "connector " + d.ConnectorID + " not found"})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.InvalidArgument(violations...)
+}
+
+func validateEvent(e domain.Event) error {
+	var violations []errors.FieldViolation
+	if e.TenantID == "" {
+		violations = append(violations, errors.FieldViolation{Field: "tenant_id", Description: "tenant_id is required"})
+	}
+	if e.Type == "" {
+		violations = append(violations, errors.FieldViolation{Field: "event_type", Description: "event_type is required"})
+	}
+	if e.Severity != "" && !e.Severity.Valid() {
+		violations = append(violations, errors.FieldViolation{Field: "severity", Description: "unknown severity " + string(e.Severity)})
+	}
+	if e.Text == "" && e.TemplateID == "" {
+		violations = append(violations, errors.FieldViolation{Field: "text", Description: "text or template_id is required"})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.InvalidArgument(violations...)
+}
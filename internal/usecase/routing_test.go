@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRoutingRuleRepository struct {
+	mock.Mock
+}
+
+func (m *mockRoutingRuleRepository) Create(ctx context.Context, r *domain.RoutingRule) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockRoutingRuleRepository) GetByID(ctx context.Context, id string) (*domain.RoutingRule, error) {
+	args := m.Called(ctx, id)
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*domain.RoutingRule), args.Error(1)
+}
+
+func (m *mockRoutingRuleRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.RoutingRule, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*domain.RoutingRule), args.Error(1)
+}
+
+func (m *mockRoutingRuleRepository) Update(ctx context.Context, r *domain.RoutingRule) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *mockRoutingRuleRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateRoutingRule_RejectsOtherTenantsConnectors(t *testing.T) {
+	ctx := context.Background()
+	mockConnRepo := new(mockConnectorRepository)
+	u := usecase.NewRoutingUsecase(new(mockRoutingRuleRepository), mockConnRepo, new(mockSender))
+
+	mockConnRepo.On("GetByID", ctx, "conn-other").
+		Return(&domain.Connector{ID: "conn-other", TenantID: "tenant-2"}, nil).Once()
+	mockConnRepo.On("GetByID", ctx, "conn-missing").Return(nil, sql.ErrNoRows).Once()
+
+	_, err := u.CreateRoutingRule(ctx, usecase.RoutingRuleInput{
+		TenantID:    "tenant-1",
+		Name:        "pages",
+		MinSeverity: "fatal",
+		Destinations: []domain.RouteDestination{
+			{ConnectorID: "conn-other"},
+			{ConnectorID: "conn-missing"},
+		},
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	var e *errors.Error
+	require.ErrorAs(t, err, &e)
+	require.Len(t, e.Violations, 3)
+	require.Equal(t, "min_severity", e.Violations[0].Field)
+	require.Equal(t, "destinations[0].connector_id", e.Violations[1].Field)
+	require.Equal(t, "destinations[1].connector_id", e.Violations[2].Field)
+
+	mockConnRepo.AssertExpectations(t)
+}
+
+func TestNotify_FansOutToMatchingRules(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoutingRuleRepository)
+	sender := new(mockSender)
+	u := usecase.NewRoutingUsecase(mockRepo, new(mockConnectorRepository), sender)
+
+	mockRepo.On("ListByTenant", ctx, "tenant-1").Return([]*domain.RoutingRule{
+		{
+			ID:           "rule-critical",
+			MinSeverity:  domain.SeverityCritical,
+			Destinations: []domain.RouteDestination{{ConnectorID: "pager"}},
+		},
+		{
+			ID:           "rule-payments",
+			EventTypes:   []string{"deploy.failed", "deploy.succeeded"},
+			MatchLabels:  map[string]string{"team": "payments"},
+			Destinations: []domain.RouteDestination{{ConnectorID: "slack", Channel: "#payments"}, {ConnectorID: "email"}},
+		},
+		{
+			ID:           "rule-errors",
+			MinSeverity:  domain.SeverityError,
+			Destinations: []domain.RouteDestination{{ConnectorID: "slack", Channel: "#payments"}},
+		},
+		{
+			ID:           "rule-search",
+			MatchLabels:  map[string]string{"team": "search"},
+			Destinations: []domain.RouteDestination{{ConnectorID: "search"}},
+		},
+	}, nil).Once()
+
+	vars := map[string]string{"team": "payments", "service": "billing"}
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{
+		ConnectorID: "slack", Channel: "#payments", Severity: domain.SeverityError, Text: "deploy failed", Variables: vars,
+	}).Return(&domain.Message{ID: "msg-1"}, nil).Once()
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{
+		ConnectorID: "email", Severity: domain.SeverityError, Text: "deploy failed", Variables: vars,
+	}).Return(nil, errors.New(errors.ErrFailedPrecondition, errors.ReasonInvalidAuth, "invalid credentials")).Once()
+
+	results, err := u.Notify(ctx, domain.Event{
+		TenantID:  "tenant-1",
+		Type:      "deploy.failed",
+		Severity:  domain.SeverityError,
+		Labels:    map[string]string{"team": "payments", "service": "api"},
+		Variables: map[string]string{"service": "billing"},
+		Text:      "deploy failed",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, domain.RouteDestination{ConnectorID: "slack", Channel: "#payments"}, results[0].Destination)
+	require.Equal(t, []string{"rule-payments", "rule-errors"}, results[0].RuleIDs)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "msg-1", results[0].Message.ID)
+
+	require.Equal(t, "email", results[1].Destination.ConnectorID)
+	require.ErrorIs(t, results[1].Err, errors.ErrFailedPrecondition)
+
+	sender.AssertExpectations(t)
+}
+
+func TestNotify_NoMatchingRules(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockRoutingRuleRepository)
+	u := usecase.NewRoutingUsecase(mockRepo, new(mockConnectorRepository), new(mockSender))
+
+	mockRepo.On("ListByTenant", ctx, "tenant-1").Return([]*domain.RoutingRule{
+		{ID: "rule-1", EventTypes: []string{"incident"}, Destinations: []domain.RouteDestination{{ConnectorID: "slack"}}},
+	}, nil).Once()
+
+	results, err := u.Notify(ctx, domain.Event{TenantID: "tenant-1", Type: "deploy", Text: "hi"})
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
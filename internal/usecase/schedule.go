@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type ScheduleUsecase interface {
+	ScheduleMessage(ctx context.Context, in ScheduleMessageInput) (*domain.ScheduledMessage, error)
+	ListScheduledMessages(ctx context.Context, tenantID, connectorID string) ([]*domain.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, id string) error
+	DispatchDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// ScheduleMessageInput describes a future send. SendAt, CronExpr or both must be
+// set: with both, the first send happens at SendAt and then on every cron occurrence.
+type ScheduleMessageInput struct {
+	SendMessageInput
+	SendAt   time.Time
+	CronExpr string
+}
+
+// cronParser accepts standard five field expressions, descriptors such as
+// @daily and a CRON_TZ= prefix for a time zone other than UTC.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// dispatchBatchSize bounds how many due schedules one replica locks per tick.
+const dispatchBatchSize = 50
+
+type scheduleUsecase struct {
+	repo       repository.ScheduledMessageRepository
+	connectors repository.ConnectorRepository
+	sender     ConnectorUsecase
+}
+
+// NewScheduleUsecase creates a new ScheduleUsecase that delivers through sender.
+func NewScheduleUsecase(
+	repo repository.ScheduledMessageRepository,
+	connectors repository.ConnectorRepository,
+	sender ConnectorUsecase,
+) ScheduleUsecase {
+	return &scheduleUsecase{
+		repo:       repo,
+		connectors: connectors,
+		sender:     sender,
+	}
+}
+
+// ScheduleMessage validates the schedule and stores it for the dispatcher.
+func (u *scheduleUsecase) ScheduleMessage(ctx context.Context, in ScheduleMessageInput) (*domain.ScheduledMessage, error) {
+	if err := validateSendInput(in.SendMessageInput); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	next, err := firstRun(in.SendAt, in.CronExpr, now)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := u.connectors.GetByID(ctx, in.ConnectorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonConnectorNotFound, "connector", in.ConnectorID)
+		}
+		slog.Error("error getting connector by id", "error", err)
+		return nil, errors.ErrInternal
+	}
+
+	sm := &domain.ScheduledMessage{
+		ID:          uuid.NewString(),
+		TenantID:    conn.TenantID,
+		ConnectorID: conn.ID,
+		Channel:     in.Channel,
+		Severity:    in.Severity,
+		Text:        in.Text,
+		TemplateID:  in.TemplateID,
+		Variables:   in.Variables,
+		CronExpr:    in.CronExpr,
+		NextRunAt:   next,
+		Status:      domain.ScheduleStatusScheduled,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.repo.Create(ctx, sm); err != nil {
+		slog.Error("error creating scheduled message", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return sm, nil
+}
+
+// ListScheduledMessages returns the tenant's schedules, optionally for a single connector.
+func (u *scheduleUsecase) ListScheduledMessages(ctx context.Context, tenantID, connectorID string) ([]*domain.ScheduledMessage, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, err
+	}
+	out, err := u.repo.List(ctx, tenantID, connectorID)
+	if err != nil {
+		slog.Error("error listing scheduled messages", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return out, nil
+}
+
+// CancelScheduledMessage stops a pending schedule, including future recurrences.
+func (u *scheduleUsecase) CancelScheduledMessage(ctx context.Context, id string) error {
+	if err := u.repo.Cancel(ctx, id, time.Now().UTC()); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NotFound(errors.ReasonScheduleNotFound, "scheduled_message", id)
+		}
+		slog.Error("error cancelling scheduled message", "error", err)
+		return errors.ErrInternal
+	}
+	return nil
+}
+
+// DispatchDue sends every schedule due at now. Each schedule is leased to one
+// replica while it is sent, so replicas running the same loop do not deliver a
+// schedule twice.
+// One-off schedules complete (or fail); recurring ones move to their next
+// occurrence after now, so runs missed while the service was down fire once.
+func (u *scheduleUsecase) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	return u.repo.ProcessDue(ctx, now.UTC(), dispatchBatchSize, func(ctx context.Context, sm *domain.ScheduledMessage) error {
+		_, sendErr := u.sender.Send(ctx, SendMessageInput{
+			ConnectorID: sm.ConnectorID,
+			Channel:     sm.Channel,
+			Severity:    sm.Severity,
+			Text:        sm.Text,
+			TemplateID:  sm.TemplateID,
+			Variables:   sm.Variables,
+		})
+
+		ranAt := now.UTC()
+		sm.LastRunAt = &ranAt
+		sm.UpdatedAt = ranAt
+		sm.LastError = ""
+		if sendErr != nil {
+			slog.Error("error sending scheduled message", "scheduled_message_id", sm.ID, "error", sendErr)
+			sm.LastError = errors.PublicMessage(sendErr)
+		}
+
+		if sm.CronExpr == "" {
+			sm.Status = domain.ScheduleStatusCompleted
+			if sendErr != nil {
+				sm.Status = domain.ScheduleStatusFailed
+			}
+			return nil
+		}
+
+		schedule, err := cronParser.Parse(sm.CronExpr)
+		if err != nil {
+			sm.Status = domain.ScheduleStatusFailed
+			sm.LastError = err.Error()
+			return nil
+		}
+		sm.NextRunAt = schedule.Next(ranAt).UTC()
+		return nil
+	})
+}
+
+func firstRun(sendAt time.Time, cronExpr string, now time.Time) (time.Time, error) {
+	if sendAt.IsZero() && cronExpr == "" {
+		return time.Time{}, errors.InvalidArgument(errors.FieldViolation{
+			Field:       "send_at",
+			Description: "send_at or cron_expression is required",
+		})
+	}
+	if !sendAt.IsZero() && sendAt.Before(now.Add(-time.Minute)) {
+		return time.Time{}, errors.InvalidArgument(errors.FieldViolation{
+			Field:       "send_at",
+			Description: "send_at must not be in the past",
+		})
+	}
+
+	if cronExpr == "" {
+		return sendAt.UTC(), nil
+	}
+	schedule, err := cronParser.Parse(cronExpr)
+	if err != nil {
+		return time.Time{}, errors.InvalidArgument(errors.FieldViolation{
+			Field:       "cron_expression",
+			Description: err.Error(),
+		})
+	}
+	if !sendAt.IsZero() {
+		return sendAt.UTC(), nil
+	}
+	return schedule.Next(now).UTC(), nil
+}
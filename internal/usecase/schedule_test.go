@@ -0,0 +1,160 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockScheduledMessageRepository struct {
+	mock.Mock
+	due []*domain.ScheduledMessage
+}
+
+func (m *mockScheduledMessageRepository) Create(ctx context.Context, sm *domain.ScheduledMessage) error {
+	args := m.Called(ctx, sm)
+	return args.Error(0)
+}
+
+func (m *mockScheduledMessageRepository) GetByID(ctx context.Context, id string) (*domain.ScheduledMessage, error) {
+	args := m.Called(ctx, id)
+	sm := args.Get(0)
+	if sm == nil {
+		return nil, args.Error(1)
+	}
+	return sm.(*domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *mockScheduledMessageRepository) List(ctx context.Context, tenantID, connectorID string) ([]*domain.ScheduledMessage, error) {
+	args := m.Called(ctx, tenantID, connectorID)
+	return args.Get(0).([]*domain.ScheduledMessage), args.Error(1)
+}
+
+func (m *mockScheduledMessageRepository) Cancel(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// ProcessDue hands every queued schedule to fn, the way the repository does
+// with the schedules it claimed.
+func (m *mockScheduledMessageRepository) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, sm *domain.ScheduledMessage) error) (int, error) {
+	for _, sm := range m.due {
+		if err := fn(ctx, sm); err != nil {
+			return 0, err
+		}
+	}
+	return len(m.due), nil
+}
+
+type mockSender struct {
+	usecase.ConnectorUsecase
+	mock.Mock
+}
+
+func (m *mockSender) Send(ctx context.Context, in usecase.SendMessageInput) (*domain.Message, error) {
+	args := m.Called(ctx, in)
+	msg := args.Get(0)
+	if msg == nil {
+		return nil, args.Error(1)
+	}
+	return msg.(*domain.Message), args.Error(1)
+}
+
+func TestScheduleMessage_RequiresSendAtOrCron(t *testing.T) {
+	u := usecase.NewScheduleUsecase(new(mockScheduledMessageRepository), new(mockConnectorRepository), new(mockSender))
+
+	_, err := u.ScheduleMessage(context.Background(), usecase.ScheduleMessageInput{
+		SendMessageInput: usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hi"},
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	_, err = u.ScheduleMessage(context.Background(), usecase.ScheduleMessageInput{
+		SendMessageInput: usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hi"},
+		CronExpr:         "every tuesday",
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	_, err = u.ScheduleMessage(context.Background(), usecase.ScheduleMessageInput{
+		SendMessageInput: usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hi"},
+		SendAt:           time.Now().Add(-time.Hour),
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestScheduleMessage_CronComputesNextRun(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockScheduledMessageRepository)
+	mockConnRepo := new(mockConnectorRepository)
+	u := usecase.NewScheduleUsecase(mockRepo, mockConnRepo, new(mockSender))
+
+	mockConnRepo.On("GetByID", ctx, "conn-1").
+		Return(&domain.Connector{ID: "conn-1", TenantID: "tenant-1"}, nil).Once()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.ScheduledMessage")).Return(nil).Once()
+
+	sm, err := u.ScheduleMessage(ctx, usecase.ScheduleMessageInput{
+		SendMessageInput: usecase.SendMessageInput{ConnectorID: "conn-1", Text: "standup"},
+		CronExpr:         "0 9 * * *",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tenant-1", sm.TenantID)
+	require.Equal(t, domain.ScheduleStatusScheduled, sm.Status)
+	require.Equal(t, 9, sm.NextRunAt.Hour())
+	require.Zero(t, sm.NextRunAt.Minute())
+	require.True(t, sm.NextRunAt.After(time.Now()))
+
+	mockRepo.AssertExpectations(t)
+	mockConnRepo.AssertExpectations(t)
+}
+
+func TestCancelScheduledMessage_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockScheduledMessageRepository)
+	u := usecase.NewScheduleUsecase(mockRepo, new(mockConnectorRepository), new(mockSender))
+
+	mockRepo.On("Cancel", ctx, "sched-1", mock.AnythingOfType("time.Time")).Return(sql.ErrNoRows).Once()
+
+	err := u.CancelScheduledMessage(ctx, "sched-1")
+	require.ErrorIs(t, err, errors.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 6, 9, 0, 30, 0, time.UTC)
+
+	oneOff := &domain.ScheduledMessage{ID: "once", ConnectorID: "conn-1", Text: "hello", NextRunAt: now, Status: domain.ScheduleStatusScheduled}
+	failing := &domain.ScheduledMessage{ID: "fail", ConnectorID: "conn-2", Text: "hello", NextRunAt: now, Status: domain.ScheduleStatusScheduled}
+	recurring := &domain.ScheduledMessage{ID: "daily", ConnectorID: "conn-1", Text: "standup", CronExpr: "0 9 * * *", NextRunAt: now, Status: domain.ScheduleStatusScheduled}
+
+	mockRepo := &mockScheduledMessageRepository{due: []*domain.ScheduledMessage{oneOff, failing, recurring}}
+	sender := new(mockSender)
+	u := usecase.NewScheduleUsecase(mockRepo, new(mockConnectorRepository), sender)
+
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{ConnectorID: "conn-1", Text: "hello"}).
+		Return(&domain.Message{ID: "msg-1"}, nil).Once()
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{ConnectorID: "conn-2", Text: "hello"}).
+		Return(nil, errors.ErrUnavailable).Once()
+	sender.On("Send", mock.Anything, usecase.SendMessageInput{ConnectorID: "conn-1", Text: "standup"}).
+		Return(&domain.Message{ID: "msg-2"}, nil).Once()
+
+	n, err := u.DispatchDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	require.Equal(t, domain.ScheduleStatusCompleted, oneOff.Status)
+	require.Equal(t, domain.ScheduleStatusFailed, failing.Status)
+	require.NotEmpty(t, failing.LastError)
+
+	require.Equal(t, domain.ScheduleStatusScheduled, recurring.Status)
+	require.Equal(t, time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC), recurring.NextRunAt)
+	require.Equal(t, now, *recurring.LastRunAt)
+
+	sender.AssertExpectations(t)
+}
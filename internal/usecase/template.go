@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/iBoBoTi/connector-service/pkg/msgtemplate"
+)
+
+type TemplateUsecase interface {
+	CreateTemplate(ctx context.Context, tenantID, name, body string) (*domain.Template, error)
+	GetTemplate(ctx context.Context, templateID string) (*domain.Template, error)
+	ListTemplates(ctx context.Context, tenantID string) ([]*domain.Template, error)
+	UpdateTemplate(ctx context.Context, templateID, name, body string) (*domain.Template, error)
+	DeleteTemplate(ctx context.Context, templateID string) error
+	RenderTemplate(ctx context.Context, in RenderTemplateInput) (string, error)
+}
+
+// RenderTemplateInput previews either a stored template (TemplateID) or an
+// unsaved template body.
+type RenderTemplateInput struct {
+	TemplateID string
+	Body       string
+	Variables  map[string]string
+}
+
+// templateRenderTimeout bounds the time spent rendering one template.
+const templateRenderTimeout = time.Second
+
+type templateUsecase struct {
+	repo repository.TemplateRepository
+}
+
+// NewTemplateUsecase creates a new TemplateUsecase.
+func NewTemplateUsecase(repo repository.TemplateRepository) TemplateUsecase {
+	return &templateUsecase{repo: repo}
+}
+
+// CreateTemplate validates the template syntax and stores it under the tenant.
+func (u *templateUsecase) CreateTemplate(ctx context.Context, tenantID, name, body string) (*domain.Template, error) {
+	if err := validateRequired(map[string]string{
+		"tenant_id": tenantID,
+		"name":      name,
+		"body":      body,
+	}); err != nil {
+		return nil, err
+	}
+	if err := parseTemplate(name, body); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	t := &domain.Template{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		Name:      name,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.repo.Create(ctx, t); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, errors.New(errors.ErrAlreadyExists, errors.ReasonTemplateExists, "template "+name+" already exists").
+				WithResource("template", name)
+		}
+		slog.Error("error creating template", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return t, nil
+}
+
+// GetTemplate retrieves a template by ID.
+func (u *templateUsecase) GetTemplate(ctx context.Context, templateID string) (*domain.Template, error) {
+	return getTemplate(ctx, u.repo, templateID)
+}
+
+// ListTemplates returns every template of the tenant ordered by name.
+func (u *templateUsecase) ListTemplates(ctx context.Context, tenantID string) ([]*domain.Template, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, err
+	}
+	templates, err := u.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		slog.Error("error listing templates", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return templates, nil
+}
+
+// UpdateTemplate replaces the name and body of a template. Empty fields are left unchanged.
+func (u *templateUsecase) UpdateTemplate(ctx context.Context, templateID, name, body string) (*domain.Template, error) {
+	t, err := getTemplate(ctx, u.repo, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		t.Name = name
+	}
+	if body != "" {
+		t.Body = body
+	}
+	if err := parseTemplate(t.Name, t.Body); err != nil {
+		return nil, err
+	}
+
+	t.UpdatedAt = time.Now()
+	if err := u.repo.Update(ctx, t); err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, errors.NotFound(errors.ReasonTemplateNotFound, "template", templateID)
+		case repository.IsUniqueViolation(err):
+			return nil, errors.New(errors.ErrAlreadyExists, errors.ReasonTemplateExists, "template "+t.Name+" already exists").
+				WithResource("template", t.Name)
+		}
+		slog.Error("error updating template", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return t, nil
+}
+
+// DeleteTemplate removes a template.
+func (u *templateUsecase) DeleteTemplate(ctx context.Context, templateID string) error {
+	if err := u.repo.Delete(ctx, templateID); err != nil {
+		slog.Error("error deleting template", "error", err)
+		return errors.ErrInternal
+	}
+	return nil
+}
+
+// RenderTemplate renders a stored template or an inline body without sending it.
+func (u *templateUsecase) RenderTemplate(ctx context.Context, in RenderTemplateInput) (string, error) {
+	name, body := "preview", in.Body
+	if in.TemplateID != "" {
+		t, err := getTemplate(ctx, u.repo, in.TemplateID)
+		if err != nil {
+			return "", err
+		}
+		name, body = t.Name, t.Body
+	}
+	if body == "" {
+		return "", errors.InvalidArgument(errors.FieldViolation{
+			Field:       "template_id",
+			Description: "template_id or body is required",
+		})
+	}
+	return renderTemplate(ctx, name, body, in.Variables)
+}
+
+func getTemplate(ctx context.Context, repo repository.TemplateRepository, templateID string) (*domain.Template, error) {
+	t, err := repo.GetByID(ctx, templateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonTemplateNotFound, "template", templateID)
+		}
+		slog.Error("error getting template by id", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return t, nil
+}
+
+func parseTemplate(name, body string) error {
+	if _, err := msgtemplate.Parse(name, body); err != nil {
+		return errors.New(errors.ErrInvalidArgument, errors.ReasonTemplateInvalid, "template body is not valid").
+			WithFieldViolation("body", err.Error())
+	}
+	return nil
+}
+
+// renderTemplate executes body, reporting missing variables as field violations.
+// Rendering is cut off after templateRenderTimeout.
+func renderTemplate(ctx context.Context, name, body string, vars map[string]string) (string, error) {
+	renderCtx, cancel := context.WithTimeout(ctx, templateRenderTimeout)
+	defer cancel()
+
+	out, err := msgtemplate.Render(renderCtx, name, body, vars)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", errors.New(errors.ErrInvalidArgument, errors.ReasonTemplateRender, "template could not be rendered").
+			WithFieldViolation("variables", err.Error()).
+			WithResource("template", name)
+	}
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *mockTemplateRepository) Create(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockTemplateRepository) GetByID(ctx context.Context, id string) (*domain.Template, error) {
+	args := m.Called(ctx, id)
+	t := args.Get(0)
+	if t == nil {
+		return nil, args.Error(1)
+	}
+	return t.(*domain.Template), args.Error(1)
+}
+
+func (m *mockTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.Template, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*domain.Template), args.Error(1)
+}
+
+func (m *mockTemplateRepository) Update(ctx context.Context, t *domain.Template) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *mockTemplateRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestCreateTemplate_Success(t *testing.T) {
+	ctx := context.Background()
+	mockTemplates := new(mockTemplateRepository)
+	u := usecase.NewTemplateUsecase(mockTemplates)
+
+	mockTemplates.On("Create", ctx, mock.AnythingOfType("*domain.Template")).Return(nil).Once()
+
+	tmpl, err := u.CreateTemplate(ctx, "tenant-1", "deploy", "{{ .service | upper }} deployed")
+	require.NoError(t, err)
+	require.NotEmpty(t, tmpl.ID)
+
+	mockTemplates.AssertExpectations(t)
+}
+
+func TestCreateTemplate_InvalidSyntax(t *testing.T) {
+	u := usecase.NewTemplateUsecase(new(mockTemplateRepository))
+
+	_, err := u.CreateTemplate(context.Background(), "tenant-1", "broken", "{{ .service ")
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+
+	_, err = u.CreateTemplate(context.Background(), "tenant-1", "exec", `{{ exec "rm" }}`)
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestRenderTemplate_MissingVariable(t *testing.T) {
+	ctx := context.Background()
+	mockTemplates := new(mockTemplateRepository)
+	u := usecase.NewTemplateUsecase(mockTemplates)
+
+	mockTemplates.
+		On("GetByID", ctx, "tmpl-1").
+		Return(&domain.Template{ID: "tmpl-1", Name: "deploy", Body: "{{ .service }} on {{ .env }}"}, nil).
+		Twice()
+
+	text, err := u.RenderTemplate(ctx, usecase.RenderTemplateInput{
+		TemplateID: "tmpl-1",
+		Variables:  map[string]string{"service": "billing", "env": "prod"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "billing on prod", text)
+
+	_, err = u.RenderTemplate(ctx, usecase.RenderTemplateInput{
+		TemplateID: "tmpl-1",
+		Variables:  map[string]string{"service": "billing"},
+	})
+	var typed *errors.Error
+	require.ErrorAs(t, err, &typed)
+	require.Equal(t, errors.ReasonTemplateRender, typed.Reason)
+	require.Equal(t, "variables", typed.Violations[0].Field)
+}
+
+func TestSend_RendersTenantTemplate(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockConnectorRepository)
+	mockSecrets := new(mockSecretsManager)
+	mockSlack := new(mockSlackClient)
+	mockTemplates := new(mockTemplateRepository)
+
+	u := usecase.NewConnectorUsecase(mockRepo, mockSecrets, mockSlack, usecase.WithTemplates(mockTemplates))
+
+	mockRepo.
+		On("GetByID", ctx, "conn-123").
+		Return(&domain.Connector{ID: "conn-123", TenantID: "tenant-1", DefaultChannelID: "C123456"}, nil).
+		Twice()
+	mockTemplates.
+		On("GetByID", ctx, "tmpl-1").
+		Return(&domain.Template{ID: "tmpl-1", TenantID: "tenant-1", Name: "deploy", Body: "{{ .service | title }} deployed"}, nil).
+		Once()
+	mockTemplates.
+		On("GetByID", ctx, "tmpl-other").
+		Return(&domain.Template{ID: "tmpl-other", TenantID: "tenant-2", Name: "deploy", Body: "hi"}, nil).
+		Once()
+	mockSecrets.On("GetCredentials", ctx, "conn-123").Return("dummy-token", nil).Once()
+	mockSlack.
+		On("SendMessage", mock.Anything, "dummy-token", "C123456", "Billing deployed").
+		Return(nil).
+		Once()
+
+	msg, err := u.Send(ctx, usecase.SendMessageInput{
+		ConnectorID: "conn-123",
+		TemplateID:  "tmpl-1",
+		Variables:   map[string]string{"service": "billing"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Billing deployed", msg.Text)
+
+	_, err = u.Send(ctx, usecase.SendMessageInput{ConnectorID: "conn-123", TemplateID: "tmpl-other"})
+	require.ErrorIs(t, err, errors.ErrNotFound)
+
+	mockSlack.AssertExpectations(t)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/repository"
+	"github.com/iBoBoTi/connector-service/internal/services"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+// EventWebhookTest is the type of the sample event posted by TestWebhook.
+const EventWebhookTest domain.EventType = "webhook.test"
+
+type WebhookUsecase interface {
+	CreateSubscription(ctx context.Context, in WebhookSubscriptionInput) (*domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error)
+	TestWebhook(ctx context.Context, subscriptionID string) (*domain.WebhookDelivery, error)
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+	MoveSigningSecrets(ctx context.Context) error
+}
+
+// WebhookSubscriptionInput registers a callback URL. A signing secret is
+// generated when SigningSecret is empty.
+type WebhookSubscriptionInput struct {
+	TenantID      string
+	URL           string
+	SigningSecret string
+	EventTypes    []domain.EventType
+}
+
+type webhookUsecase struct {
+	repo        repository.WebhookRepository
+	secrets     services.AWSSecretsManager
+	client      services.CallbackClient
+	retryPolicy RetryPolicy
+}
+
+// NewWebhookUsecase creates a new WebhookUsecase posting events through client.
+// Signing secrets are kept in secrets. Failed deliveries are retried with the
+// backoff of retryPolicy until MaxAttempts is reached.
+func NewWebhookUsecase(repo repository.WebhookRepository, secrets services.AWSSecretsManager, client services.CallbackClient, retryPolicy RetryPolicy) WebhookUsecase {
+	return &webhookUsecase{repo: repo, secrets: secrets, client: client, retryPolicy: retryPolicy}
+}
+
+// CreateSubscription validates and stores the subscription, and its signing
+// secret in the secret store. It receives the tenant's events appended from now
+// on.
+func (u *webhookUsecase) CreateSubscription(ctx context.Context, in WebhookSubscriptionInput) (*domain.WebhookSubscription, error) {
+	if err := validateRequired(map[string]string{
+		"tenant_id": in.TenantID,
+		"url":       in.URL,
+	}); err != nil {
+		return nil, err
+	}
+	if err := validateCallbackURL(in.URL); err != nil {
+		return nil, err
+	}
+	for _, t := range in.EventTypes {
+		if !t.Valid() {
+			return nil, errors.InvalidArgument(errors.FieldViolation{Field: "event_types", Description: "unknown event type " + string(t)})
+		}
+	}
+
+	secret := in.SigningSecret
+	if secret == "" {
+		var err error
+		if secret, err = newSigningSecret(); err != nil {
+			slog.Error("error generating webhook signing secret", "error", err)
+			return nil, errors.ErrInternal
+		}
+	}
+
+	now := time.Now()
+	id := uuid.NewString()
+	s := &domain.WebhookSubscription{
+		ID:               id,
+		TenantID:         in.TenantID,
+		URL:              in.URL,
+		SigningSecretRef: signingSecretRef(id),
+		EventTypes:       in.EventTypes,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := u.secrets.StoreCredentials(ctx, s.SigningSecretRef, secret); err != nil {
+		slog.Error("error storing webhook signing secret", "error", err)
+		return nil, errors.ErrInternal
+	}
+	if err := u.repo.CreateSubscription(ctx, s); err != nil {
+		slog.Error("error creating webhook subscription", "error", err)
+		if err := u.secrets.DeleteCredentials(ctx, s.SigningSecretRef); err != nil {
+			slog.Error("error deleting webhook signing secret", "subscription_id", id, "error", err)
+		}
+		return nil, errors.ErrInternal
+	}
+	s.SigningSecret = secret
+	return s, nil
+}
+
+// GetSubscription retrieves a webhook subscription by ID.
+func (u *webhookUsecase) GetSubscription(ctx context.Context, subscriptionID string) (*domain.WebhookSubscription, error) {
+	s, err := u.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NotFound(errors.ReasonWebhookNotFound, "webhook_subscription", subscriptionID)
+		}
+		slog.Error("error getting webhook subscription by id", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return s, nil
+}
+
+// ListSubscriptions returns the tenant's subscriptions, oldest first.
+func (u *webhookUsecase) ListSubscriptions(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	if err := validateRequired(map[string]string{"tenant_id": tenantID}); err != nil {
+		return nil, err
+	}
+	subs, err := u.repo.ListSubscriptions(ctx, tenantID)
+	if err != nil {
+		slog.Error("error listing webhook subscriptions", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return subs, nil
+}
+
+// DeleteSubscription removes the subscription, its delivery log and its
+// signing secret.
+func (u *webhookUsecase) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	s, err := u.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.DeleteSubscription(ctx, subscriptionID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NotFound(errors.ReasonWebhookNotFound, "webhook_subscription", subscriptionID)
+		}
+		slog.Error("error deleting webhook subscription", "error", err)
+		return errors.ErrInternal
+	}
+	if s.SigningSecretRef == "" {
+		return nil
+	}
+	if err := u.secrets.DeleteCredentials(ctx, s.SigningSecretRef); err != nil {
+		slog.Error("error deleting webhook signing secret", "error", err)
+		return errors.ErrInternal
+	}
+	return nil
+}
+
+// ListDeliveries returns the subscription's delivery log, newest first.
+func (u *webhookUsecase) ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error) {
+	if err := validateRequired(map[string]string{"subscription_id": subscriptionID}); err != nil {
+		return nil, err
+	}
+	limit = pageSize(limit)
+
+	deliveries, err := u.repo.ListDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		slog.Error("error listing webhook deliveries", "error", err)
+		return nil, errors.ErrInternal
+	}
+	return deliveries, nil
+}
+
+// TestWebhook posts a sample webhook.test event to the subscription once,
+// without retries, and returns the outcome. Test deliveries are not logged.
+func (u *webhookUsecase) TestWebhook(ctx context.Context, subscriptionID string) (*domain.WebhookDelivery, error) {
+	s, err := u.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(&domain.ConnectorEvent{
+		TenantID:  s.TenantID,
+		Type:      EventWebhookTest,
+		Data:      map[string]string{"subscription_id": s.ID},
+		CreatedAt: now.UTC(),
+	})
+	if err != nil {
+		return nil, errors.ErrInternal
+	}
+
+	d := &domain.WebhookDelivery{
+		ID:             s.ID + ":test:" + uuid.NewString(),
+		SubscriptionID: s.ID,
+		EventType:      EventWebhookTest,
+		Payload:        payload,
+		CreatedAt:      now,
+	}
+	u.attempt(ctx, s, d, now, 1)
+	return d, nil
+}
+
+// DeliverDue enqueues the events appended since the last run and posts every
+// delivery that is due. Deliveries are locked while they are posted, so each
+// is attempted by a single replica.
+func (u *webhookUsecase) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	if _, err := u.repo.EnqueueDeliveries(ctx, now.UTC()); err != nil {
+		return 0, err
+	}
+	return u.repo.ProcessDue(ctx, now.UTC(), dispatchBatchSize, func(ctx context.Context, s *domain.WebhookSubscription, d *domain.WebhookDelivery) error {
+		u.attempt(ctx, s, d, now.UTC(), u.retryPolicy.MaxAttempts)
+		return nil
+	})
+}
+
+// MoveSigningSecrets moves the signing secrets still stored in subscription
+// rows, by subscriptions created before secrets were kept in the secret store,
+// to the store. It runs on startup and does nothing once every secret moved.
+func (u *webhookUsecase) MoveSigningSecrets(ctx context.Context) error {
+	subs, err := u.repo.ListUnreferencedSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range subs {
+		ref := signingSecretRef(s.ID)
+		if err := u.secrets.StoreCredentials(ctx, ref, s.SigningSecret); err != nil {
+			return fmt.Errorf("storing signing secret of webhook subscription %s: %w", s.ID, err)
+		}
+		if err := u.repo.SetSigningSecretRef(ctx, s.ID, ref); err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("referencing signing secret of webhook subscription %s: %w", s.ID, err)
+		}
+	}
+	return nil
+}
+
+// attempt posts d once and updates its status. A failed delivery stays
+// pending, with a backoff, until maxAttempts were made.
+func (u *webhookUsecase) attempt(ctx context.Context, s *domain.WebhookSubscription, d *domain.WebhookDelivery, now time.Time, maxAttempts int) {
+	secret, err := u.signingSecret(ctx, s)
+	if err == nil {
+		err = u.client.Post(ctx, s.URL, secret, d.ID, d.Payload)
+	}
+	d.Attempts++
+	d.UpdatedAt = now
+	if err == nil {
+		d.Status = domain.WebhookDeliveryDelivered
+		d.LastError = ""
+		d.DeliveredAt = &now
+		return
+	}
+
+	d.LastError = errors.PublicMessage(err)
+	if d.Attempts >= maxAttempts {
+		d.Status = domain.WebhookDeliveryFailed
+		slog.Error("webhook delivery failed", "delivery_id", d.ID, "attempts", d.Attempts, "error", err)
+		return
+	}
+	d.Status = domain.WebhookDeliveryPending
+	d.NextAttemptAt = now.Add(u.retryPolicy.delay(d.Attempts, err))
+}
+
+// signingSecret returns the secret deliveries to s are signed with, read from
+// the secret store unless it was not moved there yet.
+func (u *webhookUsecase) signingSecret(ctx context.Context, s *domain.WebhookSubscription) (string, error) {
+	if s.SigningSecretRef == "" {
+		return s.SigningSecret, nil
+	}
+	secret, err := u.secrets.GetCredentials(ctx, s.SigningSecretRef)
+	if err != nil {
+		slog.Error("error getting webhook signing secret from secret manager", "subscription_id", s.ID, "error", err)
+		return "", errors.ErrInternal
+	}
+	return secret, nil
+}
+
+// signingSecretRef is the secret store key of a subscription's signing secret.
+func signingSecretRef(subscriptionID string) string {
+	return "webhook-subscription/" + subscriptionID
+}
+
+// validateCallbackURL refuses URLs that are not http(s) or name a loopback,
+// private or link-local host. Names resolving to such addresses are refused by
+// the callback client when it dials them.
+func validateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.InvalidArgument(errors.FieldViolation{Field: "url", Description: "must be an http(s) URL"})
+	}
+	if !services.PublicHost(u.Hostname()) {
+		return errors.InvalidArgument(errors.FieldViolation{Field: "url", Description: "must point to a public address"})
+	}
+	return nil
+}
+
+func newSigningSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
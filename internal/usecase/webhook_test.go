@@ -0,0 +1,289 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iBoBoTi/connector-service/internal/domain"
+	"github.com/iBoBoTi/connector-service/internal/usecase"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWebhookRepository struct {
+	mock.Mock
+	due []*domain.WebhookDelivery
+	sub *domain.WebhookSubscription
+}
+
+func (m *mockWebhookRepository) CreateSubscription(ctx context.Context, s *domain.WebhookSubscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *mockWebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	out := args.Get(0)
+	if out == nil {
+		return nil, args.Error(1)
+	}
+	return out.(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *mockWebhookRepository) ListSubscriptions(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *mockWebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockWebhookRepository) ListUnreferencedSecrets(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *mockWebhookRepository) SetSigningSecretRef(ctx context.Context, id, ref string) error {
+	args := m.Called(ctx, id, ref)
+	return args.Error(0)
+}
+
+func (m *mockWebhookRepository) EnqueueDeliveries(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	return args.Get(0).([]*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *mockWebhookRepository) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(ctx context.Context, s *domain.WebhookSubscription, d *domain.WebhookDelivery) error) (int, error) {
+	for _, d := range m.due {
+		if err := fn(ctx, m.sub, d); err != nil {
+			return 0, err
+		}
+	}
+	return len(m.due), nil
+}
+
+type mockCallbackClient struct {
+	mock.Mock
+}
+
+func (m *mockCallbackClient) Post(ctx context.Context, url, secret, deliveryID string, body []byte) error {
+	args := m.Called(ctx, url, secret, deliveryID, body)
+	return args.Error(0)
+}
+
+var webhookRetries = usecase.RetryPolicy{MaxAttempts: 3, BaseDelay: 30 * time.Second, MaxDelay: time.Hour}
+
+func TestDeliverDue_RetriesThenFails(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sub := &domain.WebhookSubscription{ID: "sub-1", URL: "https://hooks.example.com/events", SigningSecretRef: "webhook-subscription/sub-1"}
+	first := &domain.WebhookDelivery{ID: "sub-1:7", Payload: []byte(`{"id":7}`), Status: domain.WebhookDeliveryPending}
+	last := &domain.WebhookDelivery{ID: "sub-1:8", Payload: []byte(`{"id":8}`), Status: domain.WebhookDeliveryPending, Attempts: 2}
+	repo := &mockWebhookRepository{sub: sub, due: []*domain.WebhookDelivery{first, last}}
+	secrets := new(mockSecretsManager)
+	client := new(mockCallbackClient)
+	u := usecase.NewWebhookUsecase(repo, secrets, client, webhookRetries)
+
+	secrets.On("GetCredentials", ctx, "webhook-subscription/sub-1").Return("s3cr3t", nil).Twice()
+	unavailable := errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "callback is unavailable")
+	repo.On("EnqueueDeliveries", ctx, now).Return(int64(2), nil).Once()
+	client.On("Post", ctx, sub.URL, "s3cr3t", mock.Anything, mock.Anything).Return(unavailable).Twice()
+
+	n, err := u.DeliverDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.Equal(t, domain.WebhookDeliveryPending, first.Status)
+	require.Equal(t, 1, first.Attempts)
+	require.Equal(t, now.Add(30*time.Second), first.NextAttemptAt)
+	require.Contains(t, first.LastError, "callback is unavailable")
+
+	require.Equal(t, domain.WebhookDeliveryFailed, last.Status)
+	require.Equal(t, 3, last.Attempts)
+	client.AssertExpectations(t)
+}
+
+func TestDeliverDue_MarksDelivered(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sub := &domain.WebhookSubscription{ID: "sub-1", URL: "https://hooks.example.com/events", SigningSecretRef: "webhook-subscription/sub-1"}
+	d := &domain.WebhookDelivery{ID: "sub-1:7", Payload: []byte(`{"id":7}`), Status: domain.WebhookDeliveryPending, LastError: "timeout"}
+	repo := &mockWebhookRepository{sub: sub, due: []*domain.WebhookDelivery{d}}
+	secrets := new(mockSecretsManager)
+	client := new(mockCallbackClient)
+	u := usecase.NewWebhookUsecase(repo, secrets, client, webhookRetries)
+
+	repo.On("EnqueueDeliveries", ctx, now).Return(int64(1), nil).Once()
+	secrets.On("GetCredentials", ctx, "webhook-subscription/sub-1").Return("s3cr3t", nil).Once()
+	client.On("Post", ctx, sub.URL, "s3cr3t", "sub-1:7", []byte(`{"id":7}`)).Return(nil).Once()
+
+	_, err := u.DeliverDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryDelivered, d.Status)
+	require.Empty(t, d.LastError)
+	require.NotNil(t, d.DeliveredAt)
+}
+
+func TestDeliverDue_RetriesWhenSigningSecretIsUnavailable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sub := &domain.WebhookSubscription{ID: "sub-1", URL: "https://hooks.example.com/events", SigningSecretRef: "webhook-subscription/sub-1"}
+	d := &domain.WebhookDelivery{ID: "sub-1:7", Payload: []byte(`{"id":7}`), Status: domain.WebhookDeliveryPending}
+	repo := &mockWebhookRepository{sub: sub, due: []*domain.WebhookDelivery{d}}
+	secrets := new(mockSecretsManager)
+	client := new(mockCallbackClient)
+	u := usecase.NewWebhookUsecase(repo, secrets, client, webhookRetries)
+
+	repo.On("EnqueueDeliveries", ctx, now).Return(int64(1), nil).Once()
+	secrets.On("GetCredentials", ctx, "webhook-subscription/sub-1").Return("", stderrors.New("throttled")).Once()
+
+	_, err := u.DeliverDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryPending, d.Status)
+	require.Equal(t, 1, d.Attempts)
+	client.AssertNotCalled(t, "Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeliverDue_SignsWithSecretNotMovedYet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sub := &domain.WebhookSubscription{ID: "sub-1", URL: "https://hooks.example.com/events", SigningSecret: "s3cr3t"}
+	d := &domain.WebhookDelivery{ID: "sub-1:7", Payload: []byte(`{"id":7}`), Status: domain.WebhookDeliveryPending}
+	repo := &mockWebhookRepository{sub: sub, due: []*domain.WebhookDelivery{d}}
+	client := new(mockCallbackClient)
+	u := usecase.NewWebhookUsecase(repo, new(mockSecretsManager), client, webhookRetries)
+
+	repo.On("EnqueueDeliveries", ctx, now).Return(int64(1), nil).Once()
+	client.On("Post", ctx, sub.URL, "s3cr3t", "sub-1:7", []byte(`{"id":7}`)).Return(nil).Once()
+
+	_, err := u.DeliverDue(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryDelivered, d.Status)
+}
+
+func TestCreateSubscription_GeneratesSigningSecret(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockWebhookRepository)
+	secrets := new(mockSecretsManager)
+	u := usecase.NewWebhookUsecase(repo, secrets, new(mockCallbackClient), webhookRetries)
+
+	var stored string
+	secrets.On("StoreCredentials", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.String(2) }).
+		Return(nil).
+		Once()
+	var created domain.WebhookSubscription
+	repo.On("CreateSubscription", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { created = *args.Get(1).(*domain.WebhookSubscription) }).
+		Return(nil).
+		Once()
+
+	s, err := u.CreateSubscription(ctx, usecase.WebhookSubscriptionInput{
+		TenantID:   "tenant-1",
+		URL:        "https://hooks.example.com/events",
+		EventTypes: []domain.EventType{domain.EventMessageFailed},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(s.SigningSecret, "whsec_"))
+	require.Equal(t, []domain.EventType{domain.EventMessageFailed}, s.EventTypes)
+
+	// Only the reference is stored with the subscription.
+	require.Equal(t, s.SigningSecret, stored)
+	require.Equal(t, "webhook-subscription/"+s.ID, created.SigningSecretRef)
+	require.Empty(t, created.SigningSecret)
+	secrets.AssertCalled(t, "StoreCredentials", ctx, created.SigningSecretRef, stored)
+}
+
+func TestDeleteSubscription_DeletesSigningSecret(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockWebhookRepository)
+	secrets := new(mockSecretsManager)
+	u := usecase.NewWebhookUsecase(repo, secrets, new(mockCallbackClient), webhookRetries)
+
+	sub := &domain.WebhookSubscription{ID: "sub-1", SigningSecretRef: "webhook-subscription/sub-1"}
+	repo.On("GetSubscription", ctx, "sub-1").Return(sub, nil).Once()
+	repo.On("DeleteSubscription", ctx, "sub-1").Return(nil).Once()
+	secrets.On("DeleteCredentials", ctx, "webhook-subscription/sub-1").Return(nil).Once()
+
+	require.NoError(t, u.DeleteSubscription(ctx, "sub-1"))
+	secrets.AssertExpectations(t)
+}
+
+func TestMoveSigningSecrets(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockWebhookRepository)
+	secrets := new(mockSecretsManager)
+	u := usecase.NewWebhookUsecase(repo, secrets, new(mockCallbackClient), webhookRetries)
+
+	repo.On("ListUnreferencedSecrets", ctx).Return([]*domain.WebhookSubscription{{ID: "sub-1", SigningSecret: "s3cr3t"}}, nil).Once()
+	secrets.On("StoreCredentials", ctx, "webhook-subscription/sub-1", "s3cr3t").Return(nil).Once()
+	repo.On("SetSigningSecretRef", ctx, "sub-1", "webhook-subscription/sub-1").Return(nil).Once()
+
+	require.NoError(t, u.MoveSigningSecrets(ctx))
+	secrets.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateSubscription_RejectsInvalidInput(t *testing.T) {
+	u := usecase.NewWebhookUsecase(new(mockWebhookRepository), new(mockSecretsManager), new(mockCallbackClient), webhookRetries)
+
+	for _, url := range []string{
+		"ftp://hooks.example.com",
+		"http://localhost:8080/hook",
+		"http://127.0.0.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"https://10.1.2.3/hook",
+		"http://[::1]/hook",
+	} {
+		_, err := u.CreateSubscription(context.Background(), usecase.WebhookSubscriptionInput{
+			TenantID: "tenant-1",
+			URL:      url,
+		})
+		require.ErrorIs(t, err, errors.ErrInvalidArgument, url)
+	}
+
+	_, err := u.CreateSubscription(context.Background(), usecase.WebhookSubscriptionInput{
+		TenantID:   "tenant-1",
+		URL:        "https://hooks.example.com/events",
+		EventTypes: []domain.EventType{"connector.exploded"},
+	})
+	require.ErrorIs(t, err, errors.ErrInvalidArgument)
+}
+
+func TestTestWebhook_PostsSampleEvent(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockWebhookRepository)
+	secrets := new(mockSecretsManager)
+	client := new(mockCallbackClient)
+	u := usecase.NewWebhookUsecase(repo, secrets, client, webhookRetries)
+
+	sub := &domain.WebhookSubscription{ID: "sub-1", TenantID: "tenant-1", URL: "https://hooks.example.com/events", SigningSecretRef: "webhook-subscription/sub-1"}
+	repo.On("GetSubscription", ctx, "sub-1").Return(sub, nil).Once()
+	secrets.On("GetCredentials", ctx, "webhook-subscription/sub-1").Return("s3cr3t", nil).Once()
+
+	var event domain.ConnectorEvent
+	client.On("Post", ctx, sub.URL, "s3cr3t", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(4).([]byte), &event))
+		}).
+		Return(nil).
+		Once()
+
+	d, err := u.TestWebhook(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.WebhookDeliveryDelivered, d.Status)
+	require.Equal(t, usecase.EventWebhookTest, event.Type)
+	require.Equal(t, "tenant-1", event.TenantID)
+}
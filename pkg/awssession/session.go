@@ -0,0 +1,52 @@
+// Package awssession creates the AWS session shared by the service's AWS
+// clients from config.AWSConfig.
+package awssession
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/iBoBoTi/connector-service/config"
+)
+
+// New creates a session in cfg.Region using the default credential chain, or
+// cfg.Profile of the shared config files. With cfg.RoleARN the session's
+// credentials are replaced by those of the assumed role: through
+// AssumeRoleWithWebIdentity when cfg.WebIdentityTokenFile is set, otherwise
+// through AssumeRole with the base credentials. Role credentials are fetched
+// on first use and refreshed before they expire.
+//
+// cfg.Endpoint is not applied here, so that STS keeps its own endpoint; pass
+// it to the clients that need it.
+func New(cfg config.AWSConfig) (*session.Session, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(cfg.Region)},
+		Profile:           cfg.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session: %w", err)
+	}
+	if cfg.RoleARN == "" {
+		return sess, nil
+	}
+
+	var creds *credentials.Credentials
+	if cfg.WebIdentityTokenFile != "" {
+		creds = stscreds.NewWebIdentityCredentials(sess, cfg.RoleARN, cfg.RoleSessionName, cfg.WebIdentityTokenFile)
+	} else {
+		creds = stscreds.NewCredentials(sess, cfg.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+			if cfg.RoleSessionName != "" {
+				p.RoleSessionName = cfg.RoleSessionName
+			}
+			if cfg.ExternalID != "" {
+				p.ExternalID = aws.String(cfg.ExternalID)
+			}
+		})
+	}
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
@@ -0,0 +1,132 @@
+package awssession_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/config"
+	"github.com/iBoBoTi/connector-service/pkg/awssession"
+)
+
+// useSharedFiles points the SDK at shared config files in a temp directory and
+// hides credentials from the environment.
+func useSharedFiles(t *testing.T, credentials string) {
+	t.Helper()
+	dir := t.TempDir()
+	credsPath := filepath.Join(dir, "credentials")
+	require.NoError(t, os.WriteFile(credsPath, []byte(credentials), 0o600))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credsPath)
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE", "AWS_ROLE_ARN", "AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_CA_BUNDLE"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestNew_Profile(t *testing.T) {
+	useSharedFiles(t, "[default]\naws_access_key_id = AKIDDEFAULT\naws_secret_access_key = default\n\n[ops]\naws_access_key_id = AKIDOPS\naws_secret_access_key = ops\n")
+
+	sess, err := awssession.New(config.AWSConfig{Region: "eu-west-1", Profile: "ops"})
+	require.NoError(t, err)
+	creds, err := sess.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "AKIDOPS", creds.AccessKeyID)
+	require.Equal(t, "eu-west-1", *sess.Config.Region)
+
+	sess, err = awssession.New(config.AWSConfig{Region: "eu-west-1"})
+	require.NoError(t, err)
+	creds, err = sess.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "AKIDDEFAULT", creds.AccessKeyID)
+}
+
+func TestNew_UnknownProfile(t *testing.T) {
+	useSharedFiles(t, "[default]\naws_access_key_id = AKIDDEFAULT\naws_secret_access_key = default\n")
+
+	// Credentials of an unknown profile fail rather than fall back to default.
+	sess, err := awssession.New(config.AWSConfig{Region: "eu-west-1", Profile: "missing"})
+	require.NoError(t, err)
+	_, err = sess.Config.Credentials.Get()
+	require.Error(t, err)
+}
+
+// fakeSTS answers the STS requests of the default HTTP client with role
+// credentials, recording their form values.
+func fakeSTS(t *testing.T) *[]url.Values {
+	t.Helper()
+	var requests []url.Values
+	orig := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		require.True(t, strings.HasPrefix(r.URL.Host, "sts."), "unexpected request to %s", r.URL.Host)
+		require.NoError(t, r.ParseForm())
+		requests = append(requests, r.PostForm)
+		action := r.PostForm.Get("Action")
+		body := fmt.Sprintf(`<%[1]sResponse><%[1]sResult><Credentials>
+<AccessKeyId>ASIAROLE</AccessKeyId><SecretAccessKey>role</SecretAccessKey><SessionToken>token</SessionToken>
+<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></%[1]sResult></%[1]sResponse>`, action)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/xml"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = orig })
+	return &requests
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNew_AssumeRole(t *testing.T) {
+	useSharedFiles(t, "[default]\naws_access_key_id = AKIDDEFAULT\naws_secret_access_key = default\n")
+	requests := fakeSTS(t)
+
+	sess, err := awssession.New(config.AWSConfig{
+		Region:          "eu-west-1",
+		RoleARN:         "arn:aws:iam::123456789012:role/connector",
+		RoleSessionName: "connector-service",
+		ExternalID:      "ext-1",
+	})
+	require.NoError(t, err)
+	creds, err := sess.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "ASIAROLE", creds.AccessKeyID)
+
+	require.Len(t, *requests, 1)
+	form := (*requests)[0]
+	require.Equal(t, "AssumeRole", form.Get("Action"))
+	require.Equal(t, "arn:aws:iam::123456789012:role/connector", form.Get("RoleArn"))
+	require.Equal(t, "connector-service", form.Get("RoleSessionName"))
+	require.Equal(t, "ext-1", form.Get("ExternalId"))
+}
+
+func TestNew_WebIdentity(t *testing.T) {
+	useSharedFiles(t, "")
+	requests := fakeSTS(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("oidc-token"), 0o600))
+
+	sess, err := awssession.New(config.AWSConfig{
+		Region:               "eu-west-1",
+		RoleARN:              "arn:aws:iam::123456789012:role/connector",
+		WebIdentityTokenFile: tokenFile,
+	})
+	require.NoError(t, err)
+	creds, err := sess.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "ASIAROLE", creds.AccessKeyID)
+
+	require.Len(t, *requests, 1)
+	form := (*requests)[0]
+	require.Equal(t, "AssumeRoleWithWebIdentity", form.Get("Action"))
+	require.Equal(t, "oidc-token", form.Get("WebIdentityToken"))
+}
@@ -0,0 +1,244 @@
+// Package client is the Go client of connector-service.
+//
+// A Client embeds the generated SlackConnectorServiceClient, so every RPC is
+// available on it, and adds:
+//
+//   - TLS by default, and an auth token and actor sent with every call,
+//   - retries of reads and sends failing with Unavailable, or
+//     ResourceExhausted with a RetryInfo, waiting as long as the server asks,
+//   - an idempotency key on every SendMessage and BatchSendMessage item that
+//     has no dedup_key, so that a retried send is not posted twice,
+//   - helpers for the common calls, such as Send.
+//
+// The idempotency key is a dedup_key: when a retried send reaches the server
+// after an earlier attempt was delivered, it returns status "suppressed" with
+// the message ID of the earlier attempt.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+// Client calls a connector-service server.
+type Client struct {
+	connector_v1.SlackConnectorServiceClient
+	conn *grpc.ClientConn
+}
+
+type options struct {
+	tls         *tls.Config
+	insecure    bool
+	token       string
+	actor       string
+	retry       RetryPolicy
+	retryable   map[string]bool
+	dialOptions []grpc.DialOption
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithTLS verifies the server with cfg instead of the system roots.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tls = cfg
+	}
+}
+
+// WithInsecure disables TLS, e.g. for a local server.
+func WithInsecure() Option {
+	return func(o *options) {
+		o.insecure = true
+	}
+}
+
+// WithToken sends token as a bearer token with every call.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithActor records actor as the caller in the audit log.
+func WithActor(actor string) Option {
+	return func(o *options) {
+		o.actor = actor
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy. A MaxAttempts of 1 disables retries.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithRetryableMethods also retries the named RPCs, e.g. "SetDeliveryPolicy".
+// By default only the RPCs that read and the sends are retried; add a write
+// only when applying it twice is harmless.
+func WithRetryableMethods(rpcs ...string) Option {
+	return func(o *options) {
+		for _, rpc := range rpcs {
+			o.retryable[fullMethodName(rpc)] = true
+		}
+	}
+}
+
+// WithDialOptions adds gRPC dial options, e.g. a custom dialer.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// New returns a Client for the server at address. It does not wait for the
+// connection to be established.
+func New(address string, opts ...Option) (*Client, error) {
+	o := options{retry: DefaultRetryPolicy, retryable: make(map[string]bool, len(retryableMethods))}
+	for _, rpc := range retryableMethods {
+		o.retryable[fullMethodName(rpc)] = true
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds := insecure.NewCredentials()
+	if !o.insecure {
+		cfg := o.tls
+		if cfg == nil {
+			cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(
+			callerInterceptor(o.token, o.actor),
+			idempotencyInterceptor,
+			retryInterceptor(o.retry, o.retryable),
+		),
+		grpc.WithChainStreamInterceptor(callerStreamInterceptor(o.token, o.actor)),
+	}, o.dialOptions...)
+
+	conn, err := grpc.NewClient(address, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{SlackConnectorServiceClient: connector_v1.NewSlackConnectorServiceClient(conn), conn: conn}, nil
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Send posts text to the connector's default channel.
+func (c *Client) Send(ctx context.Context, connectorID, text string) (*connector_v1.SendMessageResponse, error) {
+	return c.SendMessage(ctx, &connector_v1.SendMessageRequest{ConnectorId: connectorID, Text: text})
+}
+
+// SendToChannel posts text to the named channel instead of the default channel.
+func (c *Client) SendToChannel(ctx context.Context, connectorID, channel, text string) (*connector_v1.SendMessageResponse, error) {
+	return c.SendMessage(ctx, &connector_v1.SendMessageRequest{ConnectorId: connectorID, Channel: channel, Text: text})
+}
+
+// SendTemplate renders the tenant's template with variables and posts it to
+// the connector's default channel.
+func (c *Client) SendTemplate(ctx context.Context, connectorID, templateID string, variables map[string]string) (*connector_v1.SendMessageResponse, error) {
+	return c.SendMessage(ctx, &connector_v1.SendMessageRequest{ConnectorId: connectorID, TemplateId: templateID, Variables: variables})
+}
+
+// NotifyEvent routes an event of the tenant to the destinations of its
+// matching routing rules.
+func (c *Client) NotifyEvent(ctx context.Context, tenantID, eventType string, severity connector_v1.Severity, text string) (*connector_v1.NotifyResponse, error) {
+	return c.Notify(ctx, &connector_v1.NotifyRequest{TenantId: tenantID, EventType: eventType, Severity: severity, Text: text})
+}
+
+// ListAllConnectors returns every connector of the tenant, fetching all pages.
+func (c *Client) ListAllConnectors(ctx context.Context, tenantID string) ([]*connector_v1.Connector, error) {
+	req := &connector_v1.ListConnectorsRequest{TenantId: tenantID}
+	var connectors []*connector_v1.Connector
+	for {
+		resp, err := c.ListConnectors(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		connectors = append(connectors, resp.GetConnectors()...)
+		if resp.GetNextPageToken() == "" {
+			return connectors, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// outgoingContext adds the caller's token and actor to the outgoing metadata.
+func outgoingContext(ctx context.Context, token, actor string) context.Context {
+	var kv []string
+	if actor != "" {
+		kv = append(kv, "x-actor", actor)
+	}
+	if token != "" {
+		kv = append(kv, "authorization", "Bearer "+token)
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func callerInterceptor(token, actor string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx, token, actor), method, req, reply, cc, opts...)
+	}
+}
+
+func callerStreamInterceptor(token, actor string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx, token, actor), desc, cc, method, opts...)
+	}
+}
+
+// idempotencyInterceptor gives sends without a dedup key a generated one before
+// the first attempt, so that every retry carries the same key. The caller's
+// request is left untouched.
+func idempotencyInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	switch r := req.(type) {
+	case *connector_v1.SendMessageRequest:
+		if r.GetDedupKey() == "" {
+			r = proto.Clone(r).(*connector_v1.SendMessageRequest)
+			r.DedupKey = NewIdempotencyKey()
+			req = r
+		}
+	case *connector_v1.BatchSendMessageRequest:
+		var cloned bool
+		for i, m := range r.GetMessages() {
+			if m.GetDedupKey() != "" {
+				continue
+			}
+			if !cloned {
+				r = proto.Clone(r).(*connector_v1.BatchSendMessageRequest)
+				cloned = true
+			}
+			r.Messages[i].DedupKey = NewIdempotencyKey()
+		}
+		req = r
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// NewIdempotencyKey returns a random key for SendMessageRequest.DedupKey. Set
+// it yourself to keep a send idempotent across restarts of your process.
+func NewIdempotencyKey() string {
+	return uuid.NewString()
+}
@@ -0,0 +1,219 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+	"github.com/iBoBoTi/connector-service/pkg/client"
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+type stubServer struct {
+	connector_v1.UnimplementedSlackConnectorServiceServer
+
+	mu       sync.Mutex
+	sends    []*connector_v1.SendMessageRequest
+	metadata []metadata.MD
+	// errs are returned by the first sends, one per call.
+	errs []error
+
+	batches []*connector_v1.BatchSendMessageRequest
+	pages   map[string]*connector_v1.ListConnectorsResponse
+
+	// notifyErrs are returned by the first Notify calls, one per call.
+	notifyErrs []error
+	notifies   int
+}
+
+func (s *stubServer) SendMessage(ctx context.Context, req *connector_v1.SendMessageRequest) (*connector_v1.SendMessageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.sends = append(s.sends, req)
+	s.metadata = append(s.metadata, md)
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, err
+	}
+	return &connector_v1.SendMessageResponse{Success: true, MessageId: "msg-1", Status: "delivered"}, nil
+}
+
+func (s *stubServer) BatchSendMessage(_ context.Context, req *connector_v1.BatchSendMessageRequest) (*connector_v1.BatchSendMessageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, req)
+	return &connector_v1.BatchSendMessageResponse{Succeeded: int32(len(req.GetMessages()))}, nil
+}
+
+func (s *stubServer) Notify(context.Context, *connector_v1.NotifyRequest) (*connector_v1.NotifyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifies++
+	if len(s.notifyErrs) > 0 {
+		err := s.notifyErrs[0]
+		s.notifyErrs = s.notifyErrs[1:]
+		return nil, err
+	}
+	return &connector_v1.NotifyResponse{}, nil
+}
+
+func (s *stubServer) ListConnectors(_ context.Context, req *connector_v1.ListConnectorsRequest) (*connector_v1.ListConnectorsResponse, error) {
+	return s.pages[req.GetPageToken()], nil
+}
+
+var fastRetries = client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+func newClient(t *testing.T, srv *stubServer, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	connector_v1.RegisterSlackConnectorServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	opts = append([]client.Option{
+		client.WithInsecure(),
+		client.WithRetryPolicy(fastRetries),
+		client.WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })),
+	}, opts...)
+	c, err := client.New("passthrough:///bufconn", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestSend_RetriesUnavailableWithSameIdempotencyKey(t *testing.T) {
+	srv := &stubServer{errs: []error{
+		errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "slack is down"),
+		status.Error(codes.Unavailable, "connection reset"),
+	}}
+	c := newClient(t, srv, client.WithToken("secret"), client.WithActor("alice@example.com"))
+
+	resp, err := c.Send(context.Background(), "conn-1", "hello")
+	require.NoError(t, err)
+	require.Equal(t, "msg-1", resp.GetMessageId())
+
+	require.Len(t, srv.sends, 3)
+	key := srv.sends[0].GetDedupKey()
+	require.NotEmpty(t, key)
+	for i, req := range srv.sends {
+		require.Equal(t, key, req.GetDedupKey())
+		require.Equal(t, []string{"Bearer secret"}, srv.metadata[i].Get("authorization"))
+		require.Equal(t, []string{"alice@example.com"}, srv.metadata[i].Get("x-actor"))
+	}
+}
+
+func TestSendMessage_KeepsCallersDedupKeyAndRequest(t *testing.T) {
+	srv := &stubServer{}
+	c := newClient(t, srv)
+
+	req := &connector_v1.SendMessageRequest{ConnectorId: "conn-1", Text: "hello"}
+	_, err := c.SendMessage(context.Background(), req)
+	require.NoError(t, err)
+	require.Empty(t, req.GetDedupKey())
+
+	_, err = c.SendMessage(context.Background(), &connector_v1.SendMessageRequest{ConnectorId: "conn-1", Text: "hello", DedupKey: "deploy-42"})
+	require.NoError(t, err)
+	require.Equal(t, "deploy-42", srv.sends[1].GetDedupKey())
+}
+
+func TestSend_HonorsRetryInfo(t *testing.T) {
+	srv := &stubServer{errs: []error{
+		errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "rate limited").WithRetryAfter(50 * time.Millisecond),
+	}}
+	c := newClient(t, srv)
+
+	start := time.Now()
+	_, err := c.Send(context.Background(), "conn-1", "hello")
+	require.NoError(t, err)
+	require.Len(t, srv.sends, 2)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestSend_DoesNotRetry(t *testing.T) {
+	tests := map[string]error{
+		"not retryable":              errors.NotFound(errors.ReasonConnectorNotFound, "connector", "conn-1"),
+		"exhausted without a delay":  errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "connector quota exceeded"),
+		"delay longer than MaxDelay": errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "daily quota exceeded").WithRetryAfter(time.Hour),
+	}
+	for name, sendErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := &stubServer{errs: []error{sendErr}}
+			c := newClient(t, srv)
+
+			_, err := c.Send(context.Background(), "conn-1", "hello")
+			require.Error(t, err)
+			require.Len(t, srv.sends, 1)
+		})
+	}
+}
+
+func TestSend_GivesUpAfterMaxAttempts(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	srv := &stubServer{errs: []error{unavailable, unavailable, unavailable, unavailable}}
+	c := newClient(t, srv)
+
+	_, err := c.Send(context.Background(), "conn-1", "hello")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Len(t, srv.sends, fastRetries.MaxAttempts)
+}
+
+func TestNotify_NotRetriedUnlessConfigured(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+
+	srv := &stubServer{notifyErrs: []error{unavailable}}
+	c := newClient(t, srv)
+	_, err := c.NotifyEvent(context.Background(), "tenant-1", "deploy", connector_v1.Severity_SEVERITY_INFO, "done")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 1, srv.notifies)
+
+	srv = &stubServer{notifyErrs: []error{unavailable}}
+	c = newClient(t, srv, client.WithRetryableMethods("Notify"))
+	_, err = c.NotifyEvent(context.Background(), "tenant-1", "deploy", connector_v1.Severity_SEVERITY_INFO, "done")
+	require.NoError(t, err)
+	require.Equal(t, 2, srv.notifies)
+}
+
+func TestBatchSendMessage_AddsIdempotencyKeys(t *testing.T) {
+	srv := &stubServer{}
+	c := newClient(t, srv)
+
+	_, err := c.BatchSendMessage(context.Background(), &connector_v1.BatchSendMessageRequest{Messages: []*connector_v1.SendMessageRequest{
+		{ConnectorId: "conn-1", Text: "a"},
+		{ConnectorId: "conn-2", Text: "b", DedupKey: "mine"},
+		{ConnectorId: "conn-3", Text: "c"},
+	}})
+	require.NoError(t, err)
+
+	msgs := srv.batches[0].GetMessages()
+	require.NotEmpty(t, msgs[0].GetDedupKey())
+	require.Equal(t, "mine", msgs[1].GetDedupKey())
+	require.NotEmpty(t, msgs[2].GetDedupKey())
+	require.NotEqual(t, msgs[0].GetDedupKey(), msgs[2].GetDedupKey())
+}
+
+func TestListAllConnectors(t *testing.T) {
+	srv := &stubServer{pages: map[string]*connector_v1.ListConnectorsResponse{
+		"":       {Connectors: []*connector_v1.Connector{{Id: "conn-1"}, {Id: "conn-2"}}, NextPageToken: "conn-2"},
+		"conn-2": {Connectors: []*connector_v1.Connector{{Id: "conn-3"}}},
+	}}
+	c := newClient(t, srv)
+
+	connectors, err := c.ListAllConnectors(context.Background(), "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, connectors, 3)
+	require.Equal(t, "conn-3", connectors[2].GetId())
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	connector_v1 "github.com/iBoBoTi/connector-service/gen/proto"
+)
+
+// RetryPolicy controls how calls failing with Unavailable, or ResourceExhausted
+// with a RetryInfo, are retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	// BaseDelay is doubled after every attempt, with jitter, unless the
+	// error carries a RetryInfo.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. An error asking to wait longer is returned
+	// without retrying, e.g. a daily message quota.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 200 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+// retryableMethods are the RPCs retried by default: those that only read, and
+// the sends, which carry an idempotency key. Other writes, such as Notify or
+// CreateConnector, could be applied twice when an attempt fails after the
+// server acted on it.
+var retryableMethods = []string{
+	"GetConnector",
+	"ListConnectors",
+	"VerifyConnector",
+	"SendMessage",
+	"BatchSendMessage",
+	"GetTemplate",
+	"ListTemplates",
+	"RenderTemplate",
+	"ListScheduledMessages",
+	"GetRoutingRule",
+	"ListRoutingRules",
+	"ListMessages",
+	"GetWebhookSubscription",
+	"ListWebhookSubscriptions",
+	"ListWebhookDeliveries",
+	"ListAuditEvents",
+	"GetTenantQuota",
+}
+
+// fullMethodName returns the gRPC method name of an RPC of the service, as
+// passed to interceptors.
+func fullMethodName(rpc string) string {
+	return "/" + connector_v1.SlackConnectorService_ServiceDesc.ServiceName + "/" + rpc
+}
+
+func retryInterceptor(p RetryPolicy, retryable map[string]bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= p.MaxAttempts || !retryable[method] {
+				return err
+			}
+			d, ok := p.delay(attempt, err)
+			if !ok {
+				return err
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+				return err
+			}
+
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// delay returns how long to wait before retrying after err, and false when
+// err is not worth retrying.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	st := status.Convert(err)
+	var retryDelay time.Duration
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			retryDelay = info.GetRetryDelay().AsDuration()
+		}
+	}
+
+	switch code := st.Code(); {
+	case code != codes.Unavailable && code != codes.ResourceExhausted:
+		return 0, false
+	case retryDelay > 0:
+		if p.MaxDelay > 0 && retryDelay > p.MaxDelay {
+			return 0, false
+		}
+		return retryDelay, true
+	case code == codes.ResourceExhausted:
+		// Without a RetryInfo, e.g. the connector quota, waiting does not help.
+		return 0, false
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	// Jitter keeps clients that failed together from retrying together.
+	return backoff/2 + rand.N(backoff/2+1), true
+}
@@ -1,24 +1,154 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/iBoBoTi/connector-service/config"
 )
 
-func NewPostgresDB(cfg config.DBConfig) (*sql.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name,
-	)
-	db, err := sql.Open("postgres", dsn)
+const (
+	connectRetryBaseDelay = 500 * time.Millisecond
+	connectRetryMaxDelay  = 10 * time.Second
+)
+
+// DSN returns the lib/pq connection string for cfg: cfg.DSN when set, else one
+// built from the individual settings. A StatementTimeout is added to either,
+// unless cfg.DSN already sets one.
+func DSN(cfg config.DBConfig) string {
+	dsn := cfg.DSN
+	if dsn == "" {
+		params := [][2]string{
+			{"host", cfg.Host},
+			{"port", strconv.Itoa(cfg.Port)},
+			{"user", cfg.User},
+			{"password", cfg.Password},
+			{"dbname", cfg.Name},
+			{"sslmode", cfg.SSLMode},
+		}
+		if cfg.SSLRootCert != "" {
+			params = append(params, [2]string{"sslrootcert", cfg.SSLRootCert})
+		}
+		parts := make([]string, 0, len(params))
+		for _, p := range params {
+			parts = append(parts, p[0]+"="+quote(p[1]))
+		}
+		dsn = strings.Join(parts, " ")
+	}
+	if cfg.StatementTimeout > 0 {
+		dsn = withParam(dsn, "statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10))
+	}
+	return dsn
+}
+
+// WithoutStatementTimeout returns cfg without its StatementTimeout, for
+// connections whose statements may rightly run long, such as migrations, or
+// that wait on Postgres, such as a LISTEN. A timeout set in cfg.DSN is kept.
+func WithoutStatementTimeout(cfg config.DBConfig) config.DBConfig {
+	cfg.StatementTimeout = 0
+	return cfg
+}
+
+// quote quotes a key=value connection string value when it is empty or holds
+// characters that would otherwise end it.
+func quote(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
+
+// withParam adds key to a URL or key=value connection string that does not
+// set it yet.
+func withParam(dsn, key, value string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return dsn
+		}
+		q := u.Query()
+		if q.Has(key) {
+			return dsn
+		}
+		q.Set(key, value)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	if strings.Contains(dsn, key+"=") {
+		return dsn
+	}
+	return dsn + " " + key + "=" + value
+}
+
+// NewPostgresDB opens a connection pool sized by cfg and pings it. While
+// Postgres is unreachable or still starting up it retries with exponential
+// backoff for up to cfg.ConnectRetryTimeout, or until ctx is done; other
+// errors, such as a wrong password, fail at once.
+func NewPostgresDB(ctx context.Context, cfg config.DBConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", DSN(cfg))
 	if err != nil {
 		return nil, err
 	}
-	if err = db.Ping(); err != nil {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := ping(ctx, db, cfg.ConnectRetryTimeout); err != nil {
+		db.Close()
 		return nil, err
 	}
 	return db, nil
 }
+
+func ping(ctx context.Context, db *sql.DB, retryTimeout time.Duration) error {
+	deadline := time.Now().Add(retryTimeout)
+	delay := connectRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) || ctx.Err() != nil || time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("connecting to Postgres (attempt %d): %w", attempt, err)
+		}
+
+		slog.Warn("Postgres is not reachable yet, retrying", "attempt", attempt, "retry_in", delay.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("connecting to Postgres (attempt %d): %w", attempt, err)
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, connectRetryMaxDelay)
+	}
+}
+
+// retryable reports whether a connection error may go away on its own: a
+// network error before Postgres answers, or Postgres answering that it is
+// starting up or shutting down. Configuration errors, such as an unreadable
+// sslrootcert or a failed certificate check, are not retried.
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "57P03" // cannot_connect_now
+	}
+	// net.Error would also match the syscall.Errno of a file error.
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) ||
+		errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
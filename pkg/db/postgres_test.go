@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/config"
+	"github.com/iBoBoTi/connector-service/pkg/db"
+)
+
+func TestDSN(t *testing.T) {
+	cfg := config.DBConfig{
+		Host:             "db.internal",
+		Port:             5432,
+		User:             "aryon",
+		Password:         "it's a secret",
+		Name:             "aryondb",
+		SSLMode:          "verify-full",
+		SSLRootCert:      "/etc/ssl/rds.pem",
+		StatementTimeout: 30 * time.Second,
+	}
+	require.Equal(t,
+		`host=db.internal port=5432 user=aryon password='it\'s a secret' dbname=aryondb sslmode=verify-full sslrootcert=/etc/ssl/rds.pem statement_timeout=30000`,
+		db.DSN(cfg))
+
+	cfg.DSN = "postgres://app@primary:5432/app?sslmode=require"
+	require.Equal(t, "postgres://app@primary:5432/app?sslmode=require&statement_timeout=30000", db.DSN(cfg))
+
+	// A timeout set by the override wins.
+	cfg.DSN = "host=primary dbname=app statement_timeout=1000"
+	require.Equal(t, cfg.DSN, db.DSN(cfg))
+}
+
+func TestNewPostgresDB_RetriesUntilTimeout(t *testing.T) {
+	// A port nothing listens on.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	cfg := config.DBConfig{Host: "127.0.0.1", Port: port, User: "u", Name: "n", SSLMode: "disable", ConnectRetryTimeout: 1200 * time.Millisecond}
+	start := time.Now()
+	_, err = db.NewPostgresDB(context.Background(), cfg)
+	require.ErrorContains(t, err, "attempt 2")
+	require.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+
+	// Cancelling the context stops the retries.
+	cfg.ConnectRetryTimeout = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = db.NewPostgresDB(ctx, cfg)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNewPostgresDB_DoesNotRetryConfigurationErrors(t *testing.T) {
+	// A server that accepts connections, so that the TLS setup is reached.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+
+	cfg := config.DBConfig{
+		Host:                "127.0.0.1",
+		Port:                lis.Addr().(*net.TCPAddr).Port,
+		User:                "u",
+		Name:                "n",
+		SSLMode:             "verify-full",
+		SSLRootCert:         filepath.Join(t.TempDir(), "missing.pem"),
+		ConnectRetryTimeout: time.Minute,
+	}
+	start := time.Now()
+	_, err = db.NewPostgresDB(context.Background(), cfg)
+	require.ErrorContains(t, err, "attempt 1")
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
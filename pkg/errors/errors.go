@@ -3,28 +3,334 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// Domain is reported in google.rpc.ErrorInfo for every error raised by this service.
+const Domain = "connector-service"
+
 // Sentinel errors
 var (
-	ErrNotFound        = errors.New("resource not found")
-	ErrAlreadyExists   = errors.New("resource already exists")
-	ErrInvalidArgument = errors.New("invalid argument")
-	ErrInternal        = errors.New("internal error")
+	ErrNotFound           = errors.New("resource not found")
+	ErrAlreadyExists      = errors.New("resource already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrResourceExhausted  = errors.New("resource exhausted")
+	ErrUnavailable        = errors.New("service unavailable")
+	ErrInternal           = errors.New("internal error")
+)
+
+// Reasons reported in google.rpc.ErrorInfo so that clients can tell failures apart
+// without parsing messages.
+const (
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonConnectorNotFound   = "CONNECTOR_NOT_FOUND"
+	ReasonChannelNotFound     = "CHANNEL_NOT_FOUND"
+	ReasonTokenRevoked        = "TOKEN_REVOKED"
+	ReasonInvalidAuth         = "INVALID_AUTH"
+	ReasonNotInChannel        = "NOT_IN_CHANNEL"
+	ReasonRateLimited         = "RATE_LIMITED"
+	ReasonProviderUnavailable = "PROVIDER_UNAVAILABLE"
+	ReasonUnsupportedProvider = "UNSUPPORTED_PROVIDER"
+	ReasonTemplateNotFound    = "TEMPLATE_NOT_FOUND"
+	ReasonTemplateExists      = "TEMPLATE_ALREADY_EXISTS"
+	ReasonTemplateInvalid     = "TEMPLATE_INVALID"
+	ReasonTemplateRender      = "TEMPLATE_RENDER_FAILED"
+	ReasonScheduleNotFound    = "SCHEDULED_MESSAGE_NOT_FOUND"
+	ReasonRoutingRuleNotFound = "ROUTING_RULE_NOT_FOUND"
+	ReasonRoutingRuleExists   = "ROUTING_RULE_ALREADY_EXISTS"
+	ReasonEventStreamLagged   = "EVENT_STREAM_INTERRUPTED"
+	ReasonWebhookNotFound     = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	ReasonQuotaExceeded       = "QUOTA_EXCEEDED"
+	ReasonDestinationBlocked  = "DESTINATION_NOT_ALLOWED"
+	ReasonFeatureDisabled     = "FEATURE_NOT_ENABLED"
 )
 
+// FieldViolation describes a single invalid request field.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// QuotaViolation describes a quota that was exceeded.
+type QuotaViolation struct {
+	Subject     string
+	Description string
+}
+
+// ResourceInfo describes the resource an error refers to.
+type ResourceInfo struct {
+	Type        string
+	Name        string
+	Owner       string
+	Description string
+}
+
+// Error is a typed error carrying the details attached to the gRPC status.
+// Kind is one of the sentinel errors above and decides the status code, so
+// errors.Is(err, ErrNotFound) keeps working for typed errors.
+type Error struct {
+	Kind       error
+	Reason     string
+	Message    string
+	Metadata   map[string]string
+	Violations []FieldViolation
+	Quota      []QuotaViolation
+	RetryAfter time.Duration
+	Resource   *ResourceInfo
+	Cause      error
+}
+
+// New creates a typed error of the given kind.
+func New(kind error, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// NotFound creates a typed not found error for the given resource.
+func NotFound(reason, resourceType, name string) *Error {
+	return New(ErrNotFound, reason, fmt.Sprintf("%s %q not found", resourceType, name)).
+		WithResource(resourceType, name)
+}
+
+// InvalidArgument creates a typed invalid argument error listing every violation.
+func InvalidArgument(violations ...FieldViolation) *Error {
+	fields := make([]string, 0, len(violations))
+	for _, v := range violations {
+		fields = append(fields, v.Field)
+	}
+	e := New(ErrInvalidArgument, ReasonInvalidArgument, "invalid argument: "+strings.Join(fields, ", "))
+	e.Violations = violations
+	return e
+}
+
+// WithMetadata adds a key to the ErrorInfo metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+// WithFieldViolation appends a BadRequest field violation.
+func (e *Error) WithFieldViolation(field, description string) *Error {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Description: description})
+	return e
+}
+
+// WithQuotaViolation appends a QuotaFailure violation.
+func (e *Error) WithQuotaViolation(subject, description string) *Error {
+	e.Quota = append(e.Quota, QuotaViolation{Subject: subject, Description: description})
+	return e
+}
+
+// WithRetryAfter sets the delay reported in RetryInfo.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	e.RetryAfter = d
+	return e
+}
+
+// WithResource sets the ResourceInfo detail.
+func (e *Error) WithResource(resourceType, name string) *Error {
+	e.Resource = &ResourceInfo{Type: resourceType, Name: name}
+	return e
+}
+
+// WithCause records the underlying error. The cause is logged but never sent to clients.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" && e.Kind != nil {
+		msg = e.Kind.Error()
+	}
+	if e.Cause != nil {
+		return msg + ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Cause}
+}
+
+// GRPCStatus builds the status with every populated detail attached.
+func (e *Error) GRPCStatus() *status.Status {
+	msg := e.Message
+	if msg == "" && e.Kind != nil {
+		msg = e.Kind.Error()
+	}
+	st := status.New(codeOf(e.Kind), msg)
+
+	var details []protoadapt.MessageV1
+	if e.Reason != "" {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   e.Reason,
+			Domain:   Domain,
+			Metadata: e.Metadata,
+		})
+	}
+	if len(e.Violations) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, br)
+	}
+	if len(e.Quota) > 0 {
+		qf := &errdetails.QuotaFailure{}
+		for _, v := range e.Quota {
+			qf.Violations = append(qf.Violations, &errdetails.QuotaFailure_Violation{
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+		details = append(details, qf)
+	}
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+	if e.Resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.Resource.Type,
+			ResourceName: e.Resource.Name,
+			Owner:        e.Resource.Owner,
+			Description:  e.Resource.Description,
+		})
+	}
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// Typed returns err when it is (or wraps) a typed *Error, otherwise fallback.
+func Typed(err error, fallback error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return fallback
+}
+
+// PublicMessage returns the text of err that may be stored or shown where
+// tenants can read it: the message of a typed error without its cause, the
+// sentinel err wraps, or the text of ErrInternal. Causes can hold credentials,
+// such as a webhook URL, and are only logged.
+func PublicMessage(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		if e.Message == "" && e.Kind != nil {
+			return e.Kind.Error()
+		}
+		return e.Message
+	}
+	for _, kind := range []error{ErrNotFound, ErrAlreadyExists, ErrInvalidArgument, ErrFailedPrecondition, ErrResourceExhausted, ErrUnavailable} {
+		if errors.Is(err, kind) {
+			return kind.Error()
+		}
+	}
+	return ErrInternal.Error()
+}
+
+// Code returns the gRPC code err is reported with, the same one
+// WrapGRPCError uses.
+func Code(err error) codes.Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return codeOf(e.Kind)
+	}
+	return codeOf(err)
+}
+
 func WrapGRPCError(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.GRPCStatus().Err()
+	}
+
+	switch code := codeOf(err); code {
+	case codes.Internal:
+		return status.Error(codes.Internal, fmt.Sprintf("internal error: %v", err))
+	default:
+		return status.Error(code, err.Error())
+	}
+}
+
+// HTTPStatus maps err to the status the REST gateway replies with. Both typed
+// errors and gRPC status errors are accepted.
+func HTTPStatus(err error) int {
+	code := codeOf(err)
+	if st, ok := status.FromError(err); ok {
+		code = st.Code()
+	}
+
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		// The request is well formed but the connector cannot serve it, e.g.
+		// its token was revoked.
+		return http.StatusUnprocessableEntity
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func codeOf(err error) codes.Code {
 	switch {
 	case errors.Is(err, ErrNotFound):
-		return status.Error(codes.NotFound, err.Error())
+		return codes.NotFound
 	case errors.Is(err, ErrAlreadyExists):
-		return status.Error(codes.AlreadyExists, err.Error())
+		return codes.AlreadyExists
 	case errors.Is(err, ErrInvalidArgument):
-		return status.Error(codes.InvalidArgument, err.Error())
+		return codes.InvalidArgument
+	case errors.Is(err, ErrFailedPrecondition):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrResourceExhausted):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrUnavailable):
+		return codes.Unavailable
 	default:
-		return status.Error(codes.Internal, fmt.Sprintf("internal error: %v", err))
+		return codes.Internal
 	}
 }
@@ -0,0 +1,202 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/iBoBoTi/connector-service/pkg/errors"
+)
+
+func TestWrapGRPCError_Codes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		http int
+	}{
+		{"not found", errors.ErrNotFound, codes.NotFound, http.StatusNotFound},
+		{"already exists", errors.ErrAlreadyExists, codes.AlreadyExists, http.StatusConflict},
+		{"invalid argument", errors.ErrInvalidArgument, codes.InvalidArgument, http.StatusBadRequest},
+		{"failed precondition", errors.ErrFailedPrecondition, codes.FailedPrecondition, http.StatusUnprocessableEntity},
+		{"resource exhausted", errors.ErrResourceExhausted, codes.ResourceExhausted, http.StatusTooManyRequests},
+		{"unavailable", errors.ErrUnavailable, codes.Unavailable, http.StatusServiceUnavailable},
+		{"internal", errors.ErrInternal, codes.Internal, http.StatusInternalServerError},
+		{"unknown", stderrors.New("boom"), codes.Internal, http.StatusInternalServerError},
+		{"wrapped sentinel", fmt.Errorf("loading connector: %w", errors.ErrNotFound), codes.NotFound, http.StatusNotFound},
+		{"typed", errors.New(errors.ErrResourceExhausted, errors.ReasonRateLimited, "slow down"), codes.ResourceExhausted, http.StatusTooManyRequests},
+		{"wrapped typed", fmt.Errorf("sending: %w", errors.NotFound(errors.ReasonConnectorNotFound, "connector", "c1")), codes.NotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := errors.WrapGRPCError(tt.err)
+			require.Equal(t, tt.code, status.Code(wrapped))
+			require.Equal(t, tt.http, errors.HTTPStatus(tt.err))
+			require.Equal(t, tt.http, errors.HTTPStatus(wrapped))
+		})
+	}
+}
+
+func TestHTTPStatus_GRPCCodes(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		http int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.Aborted, http.StatusConflict},
+		{codes.OutOfRange, http.StatusBadRequest},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Canceled, 499},
+		{codes.DataLoss, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			require.Equal(t, tt.http, errors.HTTPStatus(status.Error(tt.code, "x")))
+		})
+	}
+}
+
+func TestError_Details(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *errors.Error
+		code    codes.Code
+		details []proto.Message
+	}{
+		{
+			name: "no reason",
+			err:  &errors.Error{Kind: errors.ErrInternal},
+			code: codes.Internal,
+		},
+		{
+			name: "error info",
+			err:  errors.New(errors.ErrFailedPrecondition, errors.ReasonTokenRevoked, "revoked").WithMetadata("slack_error", "token_revoked"),
+			code: codes.FailedPrecondition,
+			details: []proto.Message{
+				&errdetails.ErrorInfo{Reason: errors.ReasonTokenRevoked, Domain: errors.Domain, Metadata: map[string]string{"slack_error": "token_revoked"}},
+			},
+		},
+		{
+			name: "bad request",
+			err: errors.InvalidArgument(
+				errors.FieldViolation{Field: "tenant_id", Description: "is required"},
+				errors.FieldViolation{Field: "name", Description: "is too long"},
+			),
+			code: codes.InvalidArgument,
+			details: []proto.Message{
+				&errdetails.ErrorInfo{Reason: errors.ReasonInvalidArgument, Domain: errors.Domain},
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "tenant_id", Description: "is required"},
+					{Field: "name", Description: "is too long"},
+				}},
+			},
+		},
+		{
+			name: "quota and retry info",
+			err: errors.New(errors.ErrResourceExhausted, errors.ReasonQuotaExceeded, "quota exceeded").
+				WithQuotaViolation("tenant:t1", "60 messages per minute").
+				WithRetryAfter(30 * time.Second),
+			code: codes.ResourceExhausted,
+			details: []proto.Message{
+				&errdetails.ErrorInfo{Reason: errors.ReasonQuotaExceeded, Domain: errors.Domain},
+				&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+					{Subject: "tenant:t1", Description: "60 messages per minute"},
+				}},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+			},
+		},
+		{
+			name: "resource info",
+			err:  errors.NotFound(errors.ReasonConnectorNotFound, "connector", "c1"),
+			code: codes.NotFound,
+			details: []proto.Message{
+				&errdetails.ErrorInfo{Reason: errors.ReasonConnectorNotFound, Domain: errors.Domain},
+				&errdetails.ResourceInfo{ResourceType: "connector", ResourceName: "c1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := tt.err.GRPCStatus()
+			require.Equal(t, tt.code, st.Code())
+
+			got := st.Details()
+			require.Len(t, got, len(tt.details))
+			for i, want := range tt.details {
+				require.True(t, proto.Equal(want, got[i].(proto.Message)), "detail %d: want %v, got %v", i, want, got[i])
+			}
+		})
+	}
+}
+
+func TestError_MessageAndCause(t *testing.T) {
+	cause := stderrors.New("dial tcp: connection refused")
+	err := errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "slack is unavailable").WithCause(cause)
+
+	require.Equal(t, "slack is unavailable: dial tcp: connection refused", err.Error())
+	require.ErrorIs(t, err, errors.ErrUnavailable)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, "slack is unavailable", err.GRPCStatus().Message())
+
+	bare := &errors.Error{Kind: errors.ErrNotFound}
+	require.Equal(t, errors.ErrNotFound.Error(), bare.Error())
+	require.Equal(t, errors.ErrNotFound.Error(), bare.GRPCStatus().Message())
+}
+
+func TestTyped(t *testing.T) {
+	typed := errors.NotFound(errors.ReasonConnectorNotFound, "connector", "c1")
+	fallback := errors.ErrInternal
+
+	require.Same(t, typed, errors.Typed(typed, fallback))
+	require.Same(t, typed, errors.Typed(fmt.Errorf("get: %w", typed), fallback))
+	require.Equal(t, fallback, errors.Typed(stderrors.New("boom"), fallback))
+	require.Nil(t, errors.Typed(stderrors.New("boom"), nil))
+}
+
+func TestPublicMessage(t *testing.T) {
+	cause := stderrors.New(`Post "https://discord.com/api/webhooks/1/secret": dial tcp: i/o timeout`)
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"typed drops the cause", errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "discord is unreachable").WithCause(cause), "discord is unreachable"},
+		{"typed without message", &errors.Error{Kind: errors.ErrNotFound, Cause: cause}, errors.ErrNotFound.Error()},
+		{"wrapped sentinel", fmt.Errorf("%w: %w", errors.ErrUnavailable, cause), errors.ErrUnavailable.Error()},
+		{"untyped", cause, errors.ErrInternal.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.PublicMessage(tt.err))
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"typed", errors.New(errors.ErrUnavailable, errors.ReasonProviderUnavailable, "discord is unreachable").WithCause(errors.ErrNotFound), codes.Unavailable},
+		{"wrapped sentinel", fmt.Errorf("lookup: %w", errors.ErrNotFound), codes.NotFound},
+		{"untyped", stderrors.New("boom"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Code(tt.err))
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package mrkdwn converts GitHub-flavored Markdown to Slack's mrkdwn and splits
+// long messages into chunks that fit Slack's text limits.
+package mrkdwn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	fenceRe     = regexp.MustCompile("^\\s*(```|~~~)")
+	headingRe   = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	taskRe      = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	bulletRe    = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedRe   = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	quoteRe     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ruleRe      = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))(\s*([-*_]))+\s*$`)
+	tableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	boldRe      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	italicRe    = regexp.MustCompile(`(^|[^*\w])\*(\S(?:[^*]*?\S)?)\*($|[^*\w])`)
+	strikeRe    = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	linkRe      = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	slackTokRe  = regexp.MustCompile(`<(?:[@#!][^<>\s]+|https?://[^<>\s]+|mailto:[^<>\s]+)>`)
+	codeSpanRe  = regexp.MustCompile("`+")
+	placeholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+const boldMark = "\x01"
+
+// Convert turns Markdown into Slack mrkdwn. Existing Slack tokens such as
+// <@U123>, <#C123> or <!here> are preserved; any other &, < and > are escaped.
+func Convert(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+
+	inFence := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if fenceRe.MatchString(line) {
+			inFence = !inFence
+			out = append(out, "```")
+			continue
+		}
+		if inFence {
+			out = append(out, Escape(line))
+			continue
+		}
+
+		if isTableStart(lines, i) {
+			end := i + 2
+			for end < len(lines) && strings.Contains(lines[end], "|") && strings.TrimSpace(lines[end]) != "" {
+				end++
+			}
+			out = append(out, renderTable(lines[i], lines[i+2:end])...)
+			i = end - 1
+			continue
+		}
+
+		out = append(out, convertLine(line))
+	}
+	if inFence {
+		out = append(out, "```")
+	}
+	return strings.Join(out, "\n")
+}
+
+func convertLine(line string) string {
+	switch {
+	case ruleRe.MatchString(line):
+		return "──────────"
+	case headingRe.MatchString(line):
+		m := headingRe.FindStringSubmatch(line)
+		return "*" + strings.ReplaceAll(inline(m[1]), "*", "") + "*"
+	case taskRe.MatchString(line):
+		m := taskRe.FindStringSubmatch(line)
+		box := "☐"
+		if m[2] != " " {
+			box = "☑"
+		}
+		return m[1] + box + " " + inline(m[3])
+	case bulletRe.MatchString(line) && !ruleRe.MatchString(line):
+		m := bulletRe.FindStringSubmatch(line)
+		return m[1] + "• " + inline(m[2])
+	case orderedRe.MatchString(line):
+		m := orderedRe.FindStringSubmatch(line)
+		return m[1] + m[2] + ". " + inline(m[3])
+	case quoteRe.MatchString(line):
+		m := quoteRe.FindStringSubmatch(line)
+		return "> " + inline(m[1])
+	default:
+		return inline(line)
+	}
+}
+
+// inline converts emphasis, links and code spans within a single line.
+func inline(s string) string {
+	var tokens []string
+	hold := func(tok string) string {
+		tokens = append(tokens, tok)
+		return fmt.Sprintf("\x00%d\x00", len(tokens)-1)
+	}
+
+	s = protectCodeSpans(s, hold)
+	s = slackTokRe.ReplaceAllStringFunc(s, hold)
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkRe.FindStringSubmatch(m)
+		text, url := sub[1], sub[2]
+		if text == "" {
+			return hold("<" + url + ">")
+		}
+		return hold("<" + url + "|" + Escape(text) + ">")
+	})
+
+	s = Escape(s)
+	s = boldRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := boldRe.FindStringSubmatch(m)
+		return boldMark + sub[1] + sub[2] + boldMark
+	})
+	s = italicRe.ReplaceAllString(s, "${1}_${2}_${3}")
+	s = strikeRe.ReplaceAllString(s, "~${1}~")
+	s = strings.ReplaceAll(s, boldMark, "*")
+
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		var idx int
+		fmt.Sscanf(placeholder.FindStringSubmatch(m)[1], "%d", &idx)
+		return tokens[idx]
+	})
+}
+
+// protectCodeSpans replaces `code` spans with placeholders so their content is
+// only escaped, never reformatted.
+func protectCodeSpans(s string, hold func(string) string) string {
+	var b strings.Builder
+	for {
+		loc := codeSpanRe.FindStringIndex(s)
+		if loc == nil {
+			b.WriteString(s)
+			return b.String()
+		}
+		ticks := s[loc[0]:loc[1]]
+		rest := s[loc[1]:]
+		end := strings.Index(rest, ticks)
+		if end < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:loc[0]])
+		b.WriteString(hold("`" + Escape(strings.TrimSpace(rest[:end])) + "`"))
+		s = rest[end+len(ticks):]
+	}
+}
+
+// Escape escapes the three characters Slack treats as control sequences.
+func Escape(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	return strings.ReplaceAll(s, ">", "&gt;")
+}
+
+func isTableStart(lines []string, i int) bool {
+	return i+1 < len(lines) &&
+		strings.Contains(lines[i], "|") &&
+		strings.Contains(lines[i+1], "-") &&
+		tableSepRe.MatchString(lines[i+1])
+}
+
+// renderTable lays a Markdown table out as an aligned code block, since Slack
+// has no table support.
+func renderTable(header string, rows []string) []string {
+	all := [][]string{splitRow(header)}
+	for _, r := range rows {
+		all = append(all, splitRow(r))
+	}
+
+	var widths []int
+	for _, row := range all {
+		for c, cell := range row {
+			if c >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[c] {
+				widths[c] = n
+			}
+		}
+	}
+
+	format := func(row []string) string {
+		cells := make([]string, len(widths))
+		for c := range widths {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			cells[c] = cell + strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell))
+		}
+		return strings.TrimRight(strings.Join(cells, " | "), " ")
+	}
+
+	out := []string{"```", format(all[0])}
+	seps := make([]string, len(widths))
+	for c, w := range widths {
+		seps[c] = strings.Repeat("-", w)
+	}
+	out = append(out, strings.Join(seps, "-+-"))
+	for _, row := range all[1:] {
+		out = append(out, format(row))
+	}
+	return append(out, "```")
+}
+
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	cells := strings.Split(row, "|")
+	for i, c := range cells {
+		cells[i] = Escape(strings.TrimSpace(c))
+	}
+	return cells
+}
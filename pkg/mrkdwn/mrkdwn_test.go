@@ -0,0 +1,116 @@
+package mrkdwn_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/iBoBoTi/connector-service/pkg/mrkdwn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bold", "**deploy** finished", "*deploy* finished"},
+		{"underscore bold", "__deploy__ finished", "*deploy* finished"},
+		{"italic", "this is *important*", "this is _important_"},
+		{"bold and italic", "**bold** and *italic*", "*bold* and _italic_"},
+		{"strike", "~~old~~ new", "~old~ new"},
+		{"link", "see [the runbook](https://wiki.example.com/run?a=1&b=2)", "see <https://wiki.example.com/run?a=1&b=2|the runbook>"},
+		{"escape", "a < b && c > d", "a &lt; b &amp;&amp; c &gt; d"},
+		{"slack tokens kept", "<!here> ping <@U123> in <#C456>", "<!here> ping <@U123> in <#C456>"},
+		{"inline code", "run `a **b** < c`", "run `a **b** &lt; c`"},
+		{"heading", "## Incident *summary*", "*Incident _summary_*"},
+		{"bullets", "- one\n  * two", "• one\n  • two"},
+		{"ordered", "1) first\n2. second", "1. first\n2. second"},
+		{"tasks", "- [ ] todo\n- [x] done", "☐ todo\n☑ done"},
+		{"quote", "> careful", "> careful"},
+		{"code fence", "```go\nif a < b && **x** {\n}\n```", "```\nif a &lt; b &amp;&amp; **x** {\n}\n```"},
+		{"unterminated fence", "```\ncode", "```\ncode\n```"},
+		{
+			"table",
+			"| Service | Status |\n|---|:---:|\n| api | ok |\n| billing | degraded |",
+			"```\nService | Status\n--------+---------\napi     | ok\nbilling | degraded\n```",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, mrkdwn.Convert(tt.in))
+		})
+	}
+}
+
+func TestSplit_ShortMessageUnchanged(t *testing.T) {
+	require.Equal(t, []string{"hello"}, mrkdwn.Split("hello", 100))
+}
+
+func TestSplit_RespectsLimitAndOrder(t *testing.T) {
+	var lines []string
+	for i := 0; i < 50; i++ {
+		lines = append(lines, strings.Repeat("x", 30))
+	}
+	text := strings.Join(lines, "\n")
+
+	chunks := mrkdwn.Split(text, 100)
+	require.Greater(t, len(chunks), 1)
+	for _, c := range chunks {
+		require.LessOrEqual(t, utf8.RuneCountInString(c), 100)
+	}
+	require.Equal(t, strings.ReplaceAll(text, "\n", ""), strings.ReplaceAll(strings.Join(chunks, ""), "\n", ""))
+}
+
+func TestSplit_KeepsCodeBlockTogether(t *testing.T) {
+	text := strings.Repeat("intro line\n", 5) + "```\nfunc main() {\n\tfmt.Println(1)\n}\n```\nafter"
+
+	chunks := mrkdwn.Split(text, 70)
+	for _, c := range chunks {
+		require.LessOrEqual(t, utf8.RuneCountInString(c), 70)
+		require.Equal(t, 0, strings.Count(c, "```")%2, "chunk has an unbalanced fence: %q", c)
+	}
+	require.Contains(t, chunks, "```\nfunc main() {\n\tfmt.Println(1)\n}\n```\nafter")
+}
+
+func TestSplit_ReopensOversizedCodeBlock(t *testing.T) {
+	var code []string
+	for i := 0; i < 20; i++ {
+		code = append(code, "line of code")
+	}
+	text := "```\n" + strings.Join(code, "\n") + "\n```"
+
+	chunks := mrkdwn.Split(text, 60)
+	require.Greater(t, len(chunks), 1)
+	for _, c := range chunks {
+		require.LessOrEqual(t, utf8.RuneCountInString(c), 60)
+		require.True(t, strings.HasPrefix(c, "```"), c)
+		require.True(t, strings.HasSuffix(c, "```"), c)
+	}
+}
+
+func TestSplit_WrapsLongLineOutsideEntitiesAndLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"entity", "&amp;"},
+		{"link with spaces", "<https://example.com/runbook|the on call runbook>"},
+		{"mention", "<@U00000001>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No spaces outside the tokens, so every cut falls near one.
+			line := strings.Repeat("x"+tt.token, 40)
+
+			chunks := mrkdwn.Split(line, 60)
+			require.Greater(t, len(chunks), 1)
+			for _, c := range chunks {
+				require.LessOrEqual(t, utf8.RuneCountInString(c), 60)
+				require.Empty(t, strings.Trim(strings.ReplaceAll(c, tt.token, ""), "x"), "chunk splits a token: %q", c)
+			}
+			require.Equal(t, line, strings.Join(chunks, ""))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package mrkdwn
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the length Slack recommends staying under for a single message.
+const MaxMessageLength = 4000
+
+const fence = "```"
+
+// Split breaks text into ordered chunks of at most limit characters. It breaks
+// between lines (wrapping a single overlong line at a space), moves a code block that would straddle
+// a boundary to the next chunk when it fits there, and otherwise closes and
+// reopens the fence so that every chunk renders on its own.
+func Split(text string, limit int) []string {
+	if limit <= 0 {
+		limit = MaxMessageLength
+	}
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var (
+		chunks  []string
+		cur     []string
+		curLen  int
+		inFence bool
+	)
+	// Reserve room for the fence lines added when a chunk ends inside a code block.
+	reserve := len(fence) + 1
+
+	flush := func() {
+		if len(cur) == 0 {
+			return
+		}
+		if inFence {
+			cur = append(cur, fence)
+		}
+		chunks = append(chunks, strings.TrimRight(strings.Join(cur, "\n"), "\n"))
+		cur, curLen = nil, 0
+		if inFence {
+			cur, curLen = []string{fence}, len(fence)
+		}
+	}
+	add := func(line string) {
+		n := utf8.RuneCountInString(line)
+		if len(cur) > 0 {
+			n++ // newline
+		}
+		cur = append(cur, line)
+		curLen += n
+	}
+
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		isFence := strings.HasPrefix(strings.TrimSpace(line), fence)
+
+		// Keep a whole code block together when it fits in an empty chunk.
+		if isFence && !inFence && curLen > 0 {
+			if block := blockLength(lines[i:]); curLen+1+block > limit && block <= limit {
+				flush()
+			}
+		}
+
+		budget := limit
+		if inFence || isFence {
+			budget -= reserve
+		}
+		for _, part := range hardWrap(line, budget) {
+			n := utf8.RuneCountInString(part) + 1
+			if curLen > 0 && curLen+n > budget {
+				flush()
+			}
+			add(part)
+		}
+		if isFence {
+			inFence = !inFence
+		}
+	}
+	inFence = false
+	flush()
+	return chunks
+}
+
+// blockLength returns the length of the code block starting at lines[0],
+// including both fences.
+func blockLength(lines []string) int {
+	n := utf8.RuneCountInString(lines[0])
+	for _, l := range lines[1:] {
+		n += 1 + utf8.RuneCountInString(l)
+		if strings.HasPrefix(strings.TrimSpace(l), fence) {
+			return n
+		}
+	}
+	return n
+}
+
+// hardWrap splits a single line longer than limit, preferring spaces. It does
+// not cut inside an entity such as &amp; or a <url|text> link unless the link
+// alone is longer than limit.
+func hardWrap(line string, limit int) []string {
+	if limit <= 0 || utf8.RuneCountInString(line) <= limit {
+		return []string{line}
+	}
+	var parts []string
+	r := []rune(line)
+	for len(r) > limit {
+		tokens := tokenSpans(r)
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if _, inside := tokens.around(i); r[i] == ' ' && !inside {
+				cut = i
+				break
+			}
+		}
+		if start, inside := tokens.around(cut); inside && start > 0 {
+			cut = start
+		}
+		parts = append(parts, string(r[:cut]))
+		r = r[cut:]
+		if len(r) > 0 && r[0] == ' ' {
+			r = r[1:]
+		}
+	}
+	return append(parts, string(r))
+}
+
+// maxEntityLength bounds the length of the entities Convert produces: &amp;,
+// &lt;, &gt; and numeric ones.
+const maxEntityLength = 10
+
+// spans holds the first and last index of each entity and <...> link,
+// mention or channel reference of a line.
+type spans [][2]int
+
+func tokenSpans(r []rune) spans {
+	var s spans
+	for i := 0; i < len(r); i++ {
+		end := -1
+		switch r[i] {
+		case '<':
+			end = indexRune(r, i+1, len(r), '>')
+		case '&':
+			end = indexRune(r, i+1, min(i+maxEntityLength, len(r)), ';')
+		}
+		if end >= 0 {
+			s = append(s, [2]int{i, end})
+			i = end
+		}
+	}
+	return s
+}
+
+// around reports whether cutting before index cut would split a token, and
+// where that token starts.
+func (s spans) around(cut int) (int, bool) {
+	for _, sp := range s {
+		if sp[0] < cut && cut <= sp[1] {
+			return sp[0], true
+		}
+	}
+	return 0, false
+}
+
+// indexRune returns the index of c in r[from:to], or -1.
+func indexRune(r []rune, from, to int, c rune) int {
+	for i := from; i < to; i++ {
+		if r[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,331 @@
+// Package msgtemplate renders tenant message templates written in Go text/template
+// syntax with a small set of side-effect free functions. Missing variables are
+// errors rather than "<no value>".
+//
+// Templates come from tenants, so both their structure and their execution are
+// bounded: ranges may only iterate over split, a small number or the variables,
+// and nest at most MaxRangeDepth deep; helpers refuse to build lists or strings
+// past the limits below; and rendering stops once its context is done.
+package msgtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxBodySize bounds the size of a stored template.
+	MaxBodySize = 16 * 1024
+	// MaxOutputSize bounds the size of a rendered message, and of any string
+	// built by a helper.
+	MaxOutputSize = 64 * 1024
+	// MaxVariables bounds the number of variables passed to a render.
+	MaxVariables = 64
+	// MaxVariablesSize bounds the total size of the variable names and values.
+	MaxVariablesSize = 16 * 1024
+	// MaxListItems bounds the items returned by split and the number a range
+	// may count to.
+	MaxListItems = 100
+	// MaxRangeDepth bounds how deep ranges nest.
+	MaxRangeDepth = 2
+)
+
+var (
+	ErrOutputTooLarge    = errors.New("rendered message exceeds maximum size")
+	ErrVariablesTooLarge = errors.New("template variables exceed maximum size")
+)
+
+// funcs returns the template functions, checking ctx where a template can
+// call them repeatedly. Every function that can grow a string refuses to
+// return more than MaxOutputSize bytes, so a range cannot keep doubling a
+// variable.
+func funcs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		"upper":      cappedString(strings.ToUpper),
+		"lower":      cappedString(strings.ToLower),
+		"title":      cappedString(title),
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    replace,
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"split":      func(sep, s string) ([]string, error) { return split(ctx, sep, s) },
+		"join":       join,
+		"default":    defaultValue,
+		"truncate":   truncate,
+		"quote":      cappedString(strconv.Quote),
+		"formatTime": formatTime,
+		// The builtins building strings are replaced by capped versions too.
+		"printf":   printf,
+		"print":    capped(fmt.Sprint),
+		"println":  capped(fmt.Sprintln),
+		"html":     capped(template.HTMLEscaper),
+		"js":       capped(template.JSEscaper),
+		"urlquery": capped(template.URLQueryEscaper),
+	}
+}
+
+// Parse compiles body, reporting syntax errors, unknown functions and
+// constructs that could make rendering unbounded.
+func Parse(name, body string) (*template.Template, error) {
+	return parseTemplate(context.Background(), name, body)
+}
+
+func parseTemplate(ctx context.Context, name, body string) (*template.Template, error) {
+	if len(body) > MaxBodySize {
+		return nil, fmt.Errorf("template body exceeds %d bytes", MaxBodySize)
+	}
+	t, err := template.New(name).Funcs(funcs(ctx)).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.Templates()) > 1 {
+		return nil, errors.New("define and block are not supported")
+	}
+	if err := checkNodes(t.Root, 0, true); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render compiles body and executes it against vars. It stops with ctx's
+// error once ctx is done.
+func Render(ctx context.Context, name, body string, vars map[string]string) (string, error) {
+	if err := checkVariables(vars); err != nil {
+		return "", err
+	}
+	t, err := parseTemplate(ctx, name, body)
+	if err != nil {
+		return "", err
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	w := &limitedWriter{ctx: ctx, limit: MaxOutputSize}
+	if err := t.Execute(w, vars); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		return "", err
+	}
+	return w.String(), nil
+}
+
+func checkVariables(vars map[string]string) error {
+	if len(vars) > MaxVariables {
+		return fmt.Errorf("%w: more than %d variables", ErrVariablesTooLarge, MaxVariables)
+	}
+	size := 0
+	for k, v := range vars {
+		size += len(k) + len(v)
+	}
+	if size > MaxVariablesSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrVariablesTooLarge, MaxVariablesSize)
+	}
+	return nil
+}
+
+// checkNodes rejects template calls, ranges nested deeper than MaxRangeDepth
+// and ranges over anything but split, a number up to MaxListItems or the
+// variables, so that no range runs more than MaxListItems times. rootDot
+// reports whether dot still holds the variables, i.e. no with or range
+// encloses node.
+func checkNodes(node parse.Node, depth int, rootDot bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkNodes(child, depth, rootDot); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return errors.New("template calls are not supported")
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode, depth, rootDot)
+	case *parse.WithNode:
+		if err := checkNodes(n.List, depth, false); err != nil {
+			return err
+		}
+		return checkNodes(n.ElseList, depth, rootDot)
+	case *parse.RangeNode:
+		if depth == MaxRangeDepth {
+			return fmt.Errorf("ranges cannot be nested more than %d deep (line %d)", MaxRangeDepth, n.Line)
+		}
+		if !rangeBounded(n.Pipe, rootDot) {
+			return fmt.Errorf("range must iterate over split, a number up to %d or the variables (line %d)", MaxListItems, n.Line)
+		}
+		if err := checkNodes(n.List, depth+1, false); err != nil {
+			return err
+		}
+		return checkNodes(n.ElseList, depth, rootDot)
+	}
+	return nil
+}
+
+func checkBranch(b *parse.BranchNode, depth int, rootDot bool) error {
+	if err := checkNodes(b.List, depth, rootDot); err != nil {
+		return err
+	}
+	return checkNodes(b.ElseList, depth, rootDot)
+}
+
+func rangeBounded(pipe *parse.PipeNode, rootDot bool) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) == 0 {
+		return false
+	}
+	args := pipe.Cmds[0].Args
+	switch arg := args[0].(type) {
+	case *parse.IdentifierNode:
+		return arg.Ident == "split"
+	case *parse.NumberNode:
+		return arg.IsInt && arg.Int64 >= 0 && arg.Int64 <= MaxListItems
+	case *parse.DotNode:
+		return rootDot && len(args) == 1
+	case *parse.VariableNode:
+		return len(args) == 1 && len(arg.Ident) == 1 && arg.Ident[0] == "$"
+	}
+	return false
+}
+
+type limitedWriter struct {
+	strings.Builder
+	ctx   context.Context
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if w.Len()+len(p) > w.limit {
+		return 0, ErrOutputTooLarge
+	}
+	return w.Builder.Write(p)
+}
+
+func split(ctx context.Context, sep, s string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	items := strings.SplitN(s, sep, MaxListItems+1)
+	if len(items) > MaxListItems {
+		return nil, fmt.Errorf("split returns more than %d items", MaxListItems)
+	}
+	return items, nil
+}
+
+func join(sep string, elems []string) (string, error) {
+	size := len(sep) * max(len(elems)-1, 0)
+	for _, e := range elems {
+		size += len(e)
+	}
+	if size > MaxOutputSize {
+		return "", ErrOutputTooLarge
+	}
+	return strings.Join(elems, sep), nil
+}
+
+func replace(old, new, s string) (string, error) {
+	if n := strings.Count(s, old); len(s)+n*(len(new)-len(old)) > MaxOutputSize {
+		return "", ErrOutputTooLarge
+	}
+	return strings.ReplaceAll(s, old, new), nil
+}
+
+// printf replaces the builtin so that a format cannot pad its output to an
+// arbitrary width: '*' and widths or precisions over three digits are refused.
+func printf(format string, args ...any) (string, error) {
+	digits := 0
+	inVerb := false
+	for _, c := range format {
+		switch {
+		case !inVerb:
+			inVerb = c == '%'
+		case c == '*':
+			return "", errors.New("printf: '*' widths are not supported")
+		case c >= '0' && c <= '9':
+			if digits++; digits > 3 {
+				return "", errors.New("printf: widths and precisions are limited to three digits")
+			}
+		case strings.ContainsRune("+-# .[]", c):
+			digits = 0
+		default:
+			inVerb, digits = false, 0
+		}
+	}
+	out := fmt.Sprintf(format, args...)
+	if len(out) > MaxOutputSize {
+		return "", ErrOutputTooLarge
+	}
+	return out, nil
+}
+
+func cappedString(fn func(string) string) func(string) (string, error) {
+	return func(s string) (string, error) {
+		out := fn(s)
+		if len(out) > MaxOutputSize {
+			return "", ErrOutputTooLarge
+		}
+		return out, nil
+	}
+}
+
+func capped(fn func(...any) string) func(...any) (string, error) {
+	return func(args ...any) (string, error) {
+		out := fn(args...)
+		if len(out) > MaxOutputSize {
+			return "", ErrOutputTooLarge
+		}
+		return out, nil
+	}
+}
+
+func title(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		r, size := utf8.DecodeRuneInString(word)
+		words[i] = strings.ToUpper(string(r)) + word[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// defaultValue is used as {{ .var | default "fallback" }}. Use {{ index . "var" | default "x" }}
+// for variables that may be absent, since missing keys are errors.
+func defaultValue(fallback, s string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func truncate(n int, s string) string {
+	r := []rune(s)
+	if n < 0 || len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
+
+// formatTime reformats an RFC 3339 timestamp with a Go layout.
+func formatTime(layout, s string) (string, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
@@ -0,0 +1,180 @@
+package msgtemplate_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iBoBoTi/connector-service/pkg/msgtemplate"
+)
+
+func TestRender_Functions(t *testing.T) {
+	vars := map[string]string{
+		"name":    "ada lovelace",
+		"env":     " prod ",
+		"tags":    "api,db,cache",
+		"empty":   "",
+		"at":      "2024-03-01T09:30:00Z",
+		"version": "v1.2.3",
+	}
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{{ .name | upper }}`, "ADA LOVELACE"},
+		{`{{ "HeLLo" | lower }}`, "hello"},
+		{`{{ .name | title }}`, "Ada Lovelace"},
+		{`[{{ .env | trim }}]`, "[prod]"},
+		{`{{ .version | trimPrefix "v" }}`, "1.2.3"},
+		{`{{ "file.txt" | trimSuffix ".txt" }}`, "file"},
+		{`{{ .tags | replace "," ", " }}`, "api, db, cache"},
+		{`{{ if .tags | contains "db" }}db{{ end }}`, "db"},
+		{`{{ if .version | hasPrefix "v1" }}v1{{ end }}`, "v1"},
+		{`{{ range split "," .tags }}<{{ . }}>{{ end }}`, "<api><db><cache>"},
+		{`{{ split "," .tags | join "|" }}`, "api|db|cache"},
+		{`{{ .empty | default "none" }}`, "none"},
+		{`{{ index . "missing" | default "none" }}`, "none"},
+		{`{{ .name | truncate 3 }}`, "ada…"},
+		{`{{ .env | quote }}`, `" prod "`},
+		{`{{ .at | formatTime "Jan 2 15:04" }}`, "Mar 1 09:30"},
+		{`{{ printf "%s-%03d" .version 7 }}`, "v1.2.3-007"},
+		{`{{ print .version "!" }}`, "v1.2.3!"},
+		{`{{ range 3 }}x{{ end }}`, "xxx"},
+		{`{{ range $k, $v := . }}{{ if eq $k "version" }}{{ $v }}{{ end }}{{ end }}`, "v1.2.3"},
+		{`{{ range split "," .tags }}{{ range split "" . }}{{ . }}{{ end }};{{ end }}`, "api;db;cache;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			out, err := msgtemplate.Render(context.Background(), "test", tt.body, vars)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestRender_MissingKeyIsError(t *testing.T) {
+	_, err := msgtemplate.Render(context.Background(), "test", "Hello {{ .name }}", nil)
+	require.ErrorContains(t, err, `map has no entry for key "name"`)
+
+	_, err = msgtemplate.Render(context.Background(), "test", "Hello {{ .name }}", map[string]string{"other": "x"})
+	require.Error(t, err)
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"syntax error", `{{ .name `, "unclosed action"},
+		{"unknown function", `{{ .name | shout }}`, `function "shout" not defined`},
+		{"body too large", strings.Repeat("x", msgtemplate.MaxBodySize+1), "exceeds"},
+		{"define", `{{ define "a" }}x{{ end }}`, "not supported"},
+		{"block", `{{ block "a" . }}x{{ end }}`, "not supported"},
+		{"template call", `{{ template "missing" }}`, "not supported"},
+		{"nested too deep", `{{ range 2 }}{{ range 2 }}{{ range 2 }}{{ end }}{{ end }}{{ end }}`, "nested"},
+		{"range over large number", `{{ range 1000000 }}{{ end }}`, "range must iterate"},
+		{"range over variable", `{{ $s := split "" .x }}{{ range $s }}{{ end }}`, "range must iterate"},
+		{"range over len", `{{ range len .x }}{{ end }}`, "range must iterate"},
+		{"range over dot inside with", `{{ with len .x }}{{ range . }}{{ end }}{{ end }}`, "range must iterate"},
+		{"range over pipeline", `{{ range .x | split "," }}{{ end }}`, "range must iterate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := msgtemplate.Parse("test", tt.body)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRender_Limits(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	tests := []struct {
+		name string
+		body string
+		vars map[string]string
+		want string
+	}{
+		{
+			name: "split items",
+			body: `{{ range split "" .x }}{{ range split "" $.x }}{{ end }}{{ end }}`,
+			vars: map[string]string{"x": long},
+			want: "more than 100 items",
+		},
+		{
+			name: "output size",
+			body: `{{ range 100 }}{{ $.x }}{{ end }}`,
+			vars: map[string]string{"x": strings.Repeat("a", 1000)},
+			want: msgtemplate.ErrOutputTooLarge.Error(),
+		},
+		{
+			name: "doubling a variable",
+			body: `{{ $s := .x }}{{ range 100 }}{{ $s = print $s $s }}{{ end }}`,
+			vars: map[string]string{"x": "ab"},
+			want: msgtemplate.ErrOutputTooLarge.Error(),
+		},
+		{
+			name: "replace growth",
+			body: `{{ replace "a" .x .x }}`,
+			vars: map[string]string{"x": strings.Repeat("a", 1000)},
+			want: msgtemplate.ErrOutputTooLarge.Error(),
+		},
+		{
+			name: "printf width",
+			body: `{{ printf "%0999999999d" 1 }}`,
+			want: "three digits",
+		},
+		{
+			name: "printf star width",
+			body: `{{ printf "%*d" 999999999 1 }}`,
+			want: "not supported",
+		},
+		{
+			name: "variable size",
+			body: `{{ .x }}`,
+			vars: map[string]string{"x": strings.Repeat("a", msgtemplate.MaxVariablesSize)},
+			want: msgtemplate.ErrVariablesTooLarge.Error(),
+		},
+		{
+			name: "variable count",
+			body: `hi`,
+			vars: manyVariables(msgtemplate.MaxVariables + 1),
+			want: msgtemplate.ErrVariablesTooLarge.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := msgtemplate.Render(context.Background(), "test", tt.body, tt.vars)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRender_StopsAtDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := msgtemplate.Render(ctx, "test", "hi", nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Even the heaviest template the limits allow stops soon after the deadline.
+	heavy := `{{ range split "" .x }}{{ range split "" $.x }}{{ end }}{{ end }}`
+	body := strings.Repeat(heavy, msgtemplate.MaxBodySize/len(heavy))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = msgtemplate.Render(ctx, "test", body, map[string]string{"x": strings.Repeat("a", 99)})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func manyVariables(n int) map[string]string {
+	vars := make(map[string]string, n)
+	for i := range n {
+		vars[fmt.Sprintf("v%d", i)] = "x"
+	}
+	return vars
+}
@@ -0,0 +1,72 @@
+// Package webhook signs outbound webhook requests and lets receivers verify them.
+//
+// The signature is an HMAC-SHA256 over "<timestamp>.<body>" using the shared
+// signing secret. Receivers should reject requests whose timestamp is outside
+// their tolerance and remember delivery IDs they have already processed.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderSignature  = "X-Connector-Signature"
+	HeaderTimestamp  = "X-Connector-Timestamp"
+	HeaderDeliveryID = "X-Connector-Delivery-Id"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrMissingHeaders    = errors.New("webhook: missing signature headers")
+	ErrInvalidTimestamp  = errors.New("webhook: invalid timestamp")
+	ErrTimestampExpired  = errors.New("webhook: timestamp outside tolerance")
+	ErrSignatureMismatch = errors.New("webhook: signature mismatch")
+)
+
+// Sign returns the signature header value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Headers returns the signature, timestamp and delivery ID headers for body.
+func Headers(secret, deliveryID string, now time.Time, body []byte) http.Header {
+	ts := now.Unix()
+	h := http.Header{}
+	h.Set(HeaderDeliveryID, deliveryID)
+	h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	h.Set(HeaderSignature, Sign(secret, ts, body))
+	return h
+}
+
+// Verify checks the signature headers of a received request against body.
+func Verify(secret string, header http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	sig, rawTS := header.Get(HeaderSignature), header.Get(HeaderTimestamp)
+	if sig == "" || rawTS == "" || !strings.HasPrefix(sig, signaturePrefix) {
+		return ErrMissingHeaders
+	}
+
+	ts, err := strconv.ParseInt(rawTS, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(Sign(secret, ts, body))) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}